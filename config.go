@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print [flags]",
+	Short: "Print the effective configuration with the secrets redacted",
+	Long: "Validate and print the configuration merged from the defaults, the configuration file, " +
+		"the environment variables and the flags, in this order of precedence. " +
+		"The output is a valid configuration file, each field commented with its environment variable.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfig(cmd)
+		cobra.CheckErr(cfg.Print(os.Stdout))
+	},
+}
+
+func init() {
+	ConfigCmd.AddCommand(configPrintCmd)
+}
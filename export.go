@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bufio"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/export"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportApp       string
+	exportEntity    string
+	exportFormat    string
+	exportFromInput uint64
+	exportToInput   uint64
+	exportOutput    string
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Dump the inputs, vouchers, notices or reports of an application as JSON lines or CSV",
+	Long: "Dump the synced inputs, vouchers, notices or reports of an application, with the fields " +
+		"decoded as in the GraphQL API, as JSON lines or CSV. The rows are streamed page by page " +
+		"to stdout or to a file.",
+	Args: cobra.NoArgs,
+	Run:  exportData,
+}
+
+func init() {
+	ExportCmd.Flags().StringVar(&exportApp, "app", "", "Address of the application to export")
+	ExportCmd.Flags().StringVar(&exportEntity, "entity", "", "Rows to export: inputs, vouchers, notices or reports")
+	ExportCmd.Flags().StringVar(&exportFormat, "format", export.FORMAT_JSONL, "Output format: jsonl or csv")
+	ExportCmd.Flags().Uint64Var(&exportFromInput, "from", 0, "Index of the first input to export")
+	ExportCmd.Flags().Uint64Var(&exportToInput, "to", 0, "Index of the last input to export, the latest when not set")
+	ExportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write, stdout when empty")
+	ExportCmd.Flags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
+		"The sqlite file to load the state")
+	ExportCmd.Flags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
+		"DB to use. PostgreSQL or SQLite")
+	cobra.CheckErr(ExportCmd.MarkFlagRequired("app"))
+	cobra.CheckErr(ExportCmd.MarkFlagRequired("entity"))
+}
+
+func exportData(cmd *cobra.Command, args []string) {
+	cfg := loadConfig(cmd)
+	// stdout may carry the export
+	commons.ConfigureLogForProductionTo(os.Stderr, slog.LevelInfo, cfg.Log.Color)
+	setOpts(cmd.Context(), cfg)
+
+	if !common.IsHexAddress(exportApp) {
+		exitf(cmd.Context(), "invalid application address: %s", exportApp)
+	}
+	if !slices.Contains(export.Entities, exportEntity) {
+		exitf(cmd.Context(), "invalid entity %s, expected one of %v", exportEntity, export.Entities)
+	}
+	if !slices.Contains(export.Formats, exportFormat) {
+		exitf(cmd.Context(), "invalid format %s, expected one of %v", exportFormat, export.Formats)
+	}
+	exportRange := repository.ExportRange{
+		AppContract: common.HexToAddress(exportApp).Hex(),
+		FromInput:   exportFromInput,
+	}
+	if cmd.Flags().Changed("to") {
+		if exportToInput < exportFromInput {
+			exitf(cmd.Context(), "--to must not be lower than --from")
+		}
+		exportRange.ToInput = &exportToInput
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db := bootstrap.CreateDBInstance(ctx, opts)
+	defer db.Close()
+	cobra.CheckErr(bootstrap.MigrateSchema(ctx, db, opts.MigrateOnStart))
+	container := convenience.NewContainer(db, opts.AutoCount)
+
+	out := os.Stdout
+	if exportOutput != "" {
+		file, err := os.Create(exportOutput)
+		cobra.CheckErr(err)
+		defer file.Close()
+		out = file
+	}
+	w := bufio.NewWriter(out)
+	exporter := export.NewExporter(container.GetExportRepository(ctx))
+	total, err := exporter.Export(ctx, w, exportRange, exportEntity, exportFormat)
+	cobra.CheckErr(err)
+	cobra.CheckErr(w.Flush())
+	slog.InfoContext(ctx, "Export finished", "entity", exportEntity, "rows", total)
+}
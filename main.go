@@ -18,10 +18,11 @@ import (
 	"github.com/carlmjohnson/versioninfo"
 	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/config"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/joho/godotenv"
-	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
 )
 
@@ -29,13 +30,13 @@ var (
 	MAX_FILE_SIZE uint64 = 1_440_000 // 1,44 MB
 )
 
+const tracingShutdownTimeout = 5 * time.Second
+
 var startupMessage = `
 GraphQL running at http://localhost:HTTP_PORT/graphql
 Press Ctrl+C to stop the node
 `
 
-var tempFromBlockL1 uint64
-
 var cmd = &cobra.Command{
 	Use:     "cartesi-rollups-graphql [flags] [-- application [args]...]",
 	Short:   "cartesi-rollups-graphql is a development node for Cartesi Rollups",
@@ -64,9 +65,10 @@ var CompletionCmd = &cobra.Command{
 }
 
 var (
-	debug bool
-	color bool
-	opts  = bootstrap.NewBootstrapOpts()
+	debug      bool
+	color      bool
+	configFile string
+	opts       = bootstrap.NewBootstrapOpts()
 )
 
 func ArrBytesAttr(key string, v []byte) slog.Attr {
@@ -87,6 +89,10 @@ func CheckIfValidSize(size uint64) error {
 }
 
 func init() {
+	cmd.PersistentFlags().StringVar(&configFile, "config", "",
+		"YAML configuration file, overridden by the environment variables and the flags. "+
+			"Defaults to "+config.EnvConfigFile)
+
 	// contracts-*
 	cmd.Flags().StringVar(&opts.ApplicationAddress, "contracts-application-address",
 		opts.ApplicationAddress, "Application contract address")
@@ -109,61 +115,69 @@ func init() {
 	cmd.Flags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
 		"The sqlite file to load the state")
 
-	cmd.Flags().Uint64VarP(&tempFromBlockL1, "from-l1-block", "", 0, "The beginning of the queried range for events")
-
 	cmd.Flags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
 		"DB to use. PostgreSQL or SQLite")
 
 	cmd.Flags().BoolVar(&opts.DisableSync, "disable-sync", opts.DisableSync, "If set disable data synchronization")
+
+	// the flags of the server take part in its effective configuration
+	configPrintCmd.Flags().AddFlagSet(cmd.Flags())
 }
 
-func deprecatedWarningCmd(cmd *cobra.Command, flag string, replacement string) {
-	if cmd.Flags().Changed(flag) {
-		slog.WarnContext(cmd.Context(), fmt.Sprintf("The '%s' flag is deprecated. %s", flag, replacement))
+// loadConfig reads the configuration file, the environment and the flags of the command.
+// It exits on any invalid value.
+func loadConfig(cmd *cobra.Command) config.Config {
+	ctx := cmd.Context()
+	LoadEnv(ctx)
+	path := configFile
+	if path == "" {
+		path = os.Getenv(config.EnvConfigFile)
 	}
+	cfg, err := config.Load(path, os.LookupEnv, cmd.Flags())
+	if err != nil {
+		exitf(ctx, "%s", err)
+	}
+	return cfg
 }
 
-func deprecatedFlags(cmd *cobra.Command) {
-	checkAndSetFlag(cmd, "contracts-application-address", func(val string) { opts.ApplicationAddress = val }, "APPLICATION_ADDRESS")
-	checkAndSetFlag(cmd, "enable-debug", func(val string) { debug = cast.ToBool(val) }, "GRAPHQL_DEBUG")
-	checkAndSetFlag(cmd, "enable-color", func(val string) { color = cast.ToBool(val) }, "COLOR")
-	checkAndSetFlag(cmd, "timeout-worker", func(val string) { opts.TimeoutWorker, _ = time.ParseDuration(val) }, "TIMEOUT_WORKER")
-	checkAndSetFlag(cmd, "http-address", func(val string) { opts.HttpAddress = val }, "HTTP_ADDRESS")
-	checkAndSetFlag(cmd, "http-port", func(val string) { opts.HttpPort = cast.ToInt(val) }, "HTTP_PORT")
-	checkAndSetFlag(cmd, "sqlite-file", func(val string) { opts.SqliteFile = val }, "SQLITE_FILE")
-	checkAndSetFlag(cmd, "from-l1-block", func(val string) { tempFromBlockL1 = cast.ToUint64(val) }, "FROM_BLOCK_L1")
-	checkAndSetFlag(cmd, "db-implementation", func(val string) { opts.DbImplementation = val }, "DB_IMPLEMENTATION")
-	checkAndSetFlag(cmd, "disable-sync", func(val string) { opts.DisableSync = cast.ToBool(val) }, "DISABLE_SYNC")
+// setOpts replaces the bootstrap options by the ones of the configuration.
+func setOpts(ctx context.Context, cfg config.Config) {
+	if cfg.UsesDeprecatedPostgres() {
+		slog.WarnContext(ctx, "The environment variables POSTGRES_HOST, POSTGRES_PORT, POSTGRES_DB, POSTGRES_USER, and POSTGRES_PASSWORD are deprecated. Please use CARTESI_GRAPHQL_DATABASE_CONNECTION instead.")
+	}
+	var err error
+	opts, err = cfg.BootstrapOpts()
+	if err != nil {
+		exitf(ctx, "%s", err)
+	}
 }
 
-/**
- * Check if the flag is set and set the value from the environment variable
- */
-func checkAndSetFlag(cmd *cobra.Command, flagName string, setOptEnv func(string), flagEnv string) {
-	val, isEnvVarPresent := os.LookupEnv(flagEnv)
-	if isEnvVarPresent {
-		setOptEnv(val)
+func requireNodeDb(ctx context.Context) {
+	if opts.NodeDbUrl == "" {
+		exitf(ctx, "the node database connection is required, set CARTESI_DATABASE_CONNECTION")
 	}
-	deprecatedMsg := fmt.Sprint("Please use ", flagEnv, " instead.")
-	deprecatedWarningCmd(cmd, flagName, deprecatedMsg)
 }
 
 func run(cmd *cobra.Command, args []string) {
-	LoadEnv(cmd.Context())
 	ctx := cmd.Context()
 	startTime := time.Now()
+	cfg := loadConfig(cmd)
 
 	// setup log
 	levelDebug := slog.LevelInfo
-	if debug {
+	if cfg.Log.Debug {
 		levelDebug = slog.LevelDebug
 	}
-	commons.ConfigureLogForProduction(levelDebug, color)
+	commons.LogLevel.Set(levelDebug)
+	commons.ConfigureLogForProduction(commons.LogLevel, cfg.Log.Color)
 
 	// check args
 	checkEthAddress(cmd, "address-input-box")
 	checkEthAddress(cmd, "address-application")
-	deprecatedFlags(cmd)
+	setOpts(ctx, cfg)
+	if !opts.DisableSync {
+		requireNodeDb(ctx)
+	}
 
 	// handle signals with notify context
 	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
@@ -191,10 +205,33 @@ func run(cmd *cobra.Command, args []string) {
 		case <-ctx.Done():
 		}
 	}()
+	shutdownTracing := setupTracing(ctx)
 	var err error = bootstrap.NewSupervisorGraphQL(ctx, opts).Start(ctx, ready)
+	shutdownTracing()
 	cobra.CheckErr(err)
 }
 
+// setupTracing starts the export of the spans, if enabled, and returns the function
+// flushing the pending spans.
+func setupTracing(ctx context.Context) func() {
+	if !opts.Tracing.Enabled() {
+		return func() {}
+	}
+	shutdown, err := tracing.Setup(ctx, opts.Tracing)
+	if err != nil {
+		exitf(ctx, "invalid tracing configuration: %s", err)
+	}
+	slog.InfoContext(ctx, "tracing: exporting spans", "exporter", opts.Tracing.Exporter)
+	return func() {
+		// the context of the command is already done
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			slog.WarnContext(ctx, "tracing: failed to flush the spans", "err", err)
+		}
+	}
+}
+
 //go:embed .env
 var envBuilded string
 
@@ -225,6 +262,13 @@ func LoadEnv(ctx context.Context) {
 
 func main() {
 	cmd.AddCommand(CompletionCmd)
+	cmd.AddCommand(MigrateCmd)
+	cmd.AddCommand(ResyncCmd)
+	cmd.AddCommand(VerifyCmd)
+	cmd.AddCommand(ExportCmd)
+	cmd.AddCommand(SnapshotCmd)
+	cmd.AddCommand(RestoreCmd)
+	cmd.AddCommand(ConfigCmd)
 	cobra.CheckErr(cmd.Execute())
 }
 
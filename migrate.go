@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+)
+
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the schema migrations of the GraphQL database",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		withSchema(cmd, func(ctx context.Context, schema *migrations.Schema) error {
+			if _, err := schema.Check(); err != nil {
+				return err
+			}
+			return schema.Up()
+		})
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [steps]",
+	Short: "Revert the last migrations, one by default",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		steps := 1
+		if len(args) == 1 {
+			var err error
+			steps, err = cast.ToIntE(args[0])
+			cobra.CheckErr(err)
+		}
+		withSchema(cmd, func(ctx context.Context, schema *migrations.Schema) error {
+			return schema.Down(steps)
+		})
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version of the database",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		withSchema(cmd, func(ctx context.Context, schema *migrations.Schema) error {
+			return nil
+		})
+	},
+}
+
+func init() {
+	MigrateCmd.PersistentFlags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
+		"The sqlite file to load the state")
+	MigrateCmd.PersistentFlags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
+		"DB to use. PostgreSQL or SQLite")
+	MigrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd)
+}
+
+// withSchema runs the migration command and prints the resulting status.
+func withSchema(cmd *cobra.Command, fn func(ctx context.Context, schema *migrations.Schema) error) {
+	ctx := cmd.Context()
+	cfg := loadConfig(cmd)
+	commons.ConfigureLogForProduction(slog.LevelInfo, cfg.Log.Color)
+	setOpts(ctx, cfg)
+
+	db := bootstrap.CreateDBInstance(ctx, opts)
+	defer db.Close()
+	schema, err := migrations.New(ctx, db)
+	cobra.CheckErr(err)
+	defer schema.Close()
+
+	cobra.CheckErr(fn(ctx, schema))
+
+	status, err := schema.Status()
+	cobra.CheckErr(err)
+	fmt.Printf("version: %d\nlatest: %d\ndirty: %t\n", status.Version, status.Latest, status.Dirty)
+}
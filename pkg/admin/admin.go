@@ -0,0 +1,229 @@
+// This package serves the admin API on its own listener,
+// apart from the public GraphQL API.
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/reader"
+	"github.com/cartesi/rollups-graphql/v2/pkg/supervisor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/cast"
+)
+
+// DeadLetterService lists the quarantined rows and retries them.
+type DeadLetterService interface {
+	FindAll(ctx context.Context, appContract string) ([]repository.DeadLetter, error)
+	// Retry returns nil when the row was synced and the updated dead letter otherwise.
+	Retry(ctx context.Context, kind string, appID uint64, rawIndex uint64) (*repository.DeadLetter, error)
+}
+
+type RetryResult struct {
+	Resolved   bool                   `json:"resolved"`
+	DeadLetter *repository.DeadLetter `json:"deadLetter,omitempty"`
+}
+
+// RegisterDeadLetters adds the dead letter endpoints to the admin API.
+func RegisterDeadLetters(e *echo.Echo, service DeadLetterService) {
+	e.GET("/admin/dead-letters", func(c echo.Context) error {
+		deadLetters, err := service.FindAll(c.Request().Context(), c.QueryParam("appContract"))
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, deadLetters)
+	})
+	e.POST("/admin/dead-letters/:kind/:appId/:index/retry", func(c echo.Context) error {
+		kind := c.Param("kind")
+		if kind != repository.DEAD_LETTER_INPUT && kind != repository.DEAD_LETTER_OUTPUT {
+			return echo.NewHTTPError(http.StatusBadRequest, "kind must be input or output")
+		}
+		appID, err := cast.ToUint64E(c.Param("appId"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid appId")
+		}
+		index, err := cast.ToUint64E(c.Param("index"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid index")
+		}
+		deadLetter, err := service.Retry(c.Request().Context(), kind, appID, index)
+		if errors.Is(err, repository.ErrDeadLetterNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, RetryResult{
+			Resolved:   deadLetter == nil,
+			DeadLetter: deadLetter,
+		})
+	})
+}
+
+// VerifyService compares the node database with the synced data.
+type VerifyService interface {
+	// LastReport returns nil before the first verification.
+	LastReport() *model.VerifyReport
+	Verify(ctx context.Context, appContract *common.Address, repair bool) (*model.VerifyReport, error)
+}
+
+// RegisterVerify adds the consistency verification endpoints to the admin API.
+func RegisterVerify(e *echo.Echo, service VerifyService) {
+	e.GET("/admin/verify", func(c echo.Context) error {
+		report := service.LastReport()
+		if report == nil {
+			return echo.NewHTTPError(http.StatusNotFound, "no verification has run yet")
+		}
+		return c.JSON(http.StatusOK, report)
+	})
+	e.POST("/admin/verify", func(c echo.Context) error {
+		var appContract *common.Address
+		if app := c.QueryParam("appContract"); app != "" {
+			if !common.IsHexAddress(app) {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid appContract")
+			}
+			address := common.HexToAddress(app)
+			appContract = &address
+		}
+		repair := false
+		if value := c.QueryParam("repair"); value != "" {
+			var err error
+			repair, err = cast.ToBoolE(value)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid repair")
+			}
+		}
+		report, err := service.Verify(c.Request().Context(), appContract, repair)
+		if errors.Is(err, repository.ErrApplicationNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, report)
+	})
+}
+
+// RetentionService exposes the retention policy and the last pruning run.
+type RetentionService interface {
+	Interval() time.Duration
+	RetentionPolicy() model.RetentionPolicy
+	// LastRun returns nil before the first run.
+	LastRun() *model.RetentionRun
+}
+
+type RetentionStatus struct {
+	Interval string                `json:"interval"`
+	Policy   model.RetentionPolicy `json:"policy"`
+	LastRun  *model.RetentionRun   `json:"lastRun"`
+}
+
+// RegisterRetention adds the retention endpoint to the admin API.
+func RegisterRetention(e *echo.Echo, service RetentionService) {
+	e.GET("/admin/retention", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, RetentionStatus{
+			Interval: service.Interval().String(),
+			Policy:   service.RetentionPolicy(),
+			LastRun:  service.LastRun(),
+		})
+	})
+}
+
+// CacheService exposes the counters of the GraphQL response cache.
+type CacheService interface {
+	Stats() reader.CacheStats
+}
+
+// RegisterCache adds the response cache endpoint to the admin API.
+func RegisterCache(e *echo.Echo, service CacheService) {
+	e.GET("/admin/cache", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, service.Stats())
+	})
+}
+
+// SyncService pauses, resumes and triggers the synchronizer and reads its checkpoints.
+type SyncService interface {
+	Status() model.SyncStatus
+	Pause()
+	Resume()
+	Trigger()
+	Checkpoints(ctx context.Context, appContract *common.Address) ([]model.SyncCheckpoint, error)
+}
+
+// RegisterSync adds the synchronizer endpoints to the admin API.
+func RegisterSync(e *echo.Echo, service SyncService) {
+	e.GET("/admin/sync", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, service.Status())
+	})
+	e.POST("/admin/sync/pause", func(c echo.Context) error {
+		service.Pause()
+		return c.JSON(http.StatusOK, service.Status())
+	})
+	e.POST("/admin/sync/resume", func(c echo.Context) error {
+		service.Resume()
+		return c.JSON(http.StatusOK, service.Status())
+	})
+	e.POST("/admin/sync/trigger", func(c echo.Context) error {
+		service.Trigger()
+		return c.JSON(http.StatusAccepted, service.Status())
+	})
+	e.GET("/admin/sync/checkpoints", func(c echo.Context) error {
+		var appContract *common.Address
+		if app := c.QueryParam("appContract"); app != "" {
+			if !common.IsHexAddress(app) {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid appContract")
+			}
+			address := common.HexToAddress(app)
+			appContract = &address
+		}
+		checkpoints, err := service.Checkpoints(c.Request().Context(), appContract)
+		if errors.Is(err, repository.ErrApplicationNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, checkpoints)
+	})
+}
+
+// RegisterWorkers adds the endpoint listing the supervised workers and their states.
+func RegisterWorkers(e *echo.Echo, states *supervisor.WorkerStates) {
+	e.GET("/admin/workers", func(c echo.Context) error {
+		workers := states.List()
+		if workers == nil {
+			workers = []supervisor.WorkerState{}
+		}
+		return c.JSON(http.StatusOK, workers)
+	})
+}
+
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+// RegisterLogLevel adds the endpoints reading and changing the log level at runtime.
+func RegisterLogLevel(e *echo.Echo, level *slog.LevelVar) {
+	e.GET("/admin/log-level", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, LogLevel{Level: level.Level().String()})
+	})
+	e.PUT("/admin/log-level", func(c echo.Context) error {
+		var body LogLevel
+		if err := c.Bind(&body); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid body")
+		}
+		var newLevel slog.Level
+		if err := newLevel.UnmarshalText([]byte(body.Level)); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid level")
+		}
+		level.Set(newLevel)
+		slog.InfoContext(c.Request().Context(), "admin: log level changed", "level", newLevel)
+		return c.JSON(http.StatusOK, LogLevel{Level: newLevel.String()})
+	})
+}
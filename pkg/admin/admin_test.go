@@ -0,0 +1,293 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/reader"
+	"github.com/cartesi/rollups-graphql/v2/pkg/supervisor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeDeadLetterService struct {
+	deadLetters []repository.DeadLetter
+	resolved    bool
+}
+
+func (f *fakeDeadLetterService) FindAll(ctx context.Context, appContract string) ([]repository.DeadLetter, error) {
+	return f.deadLetters, nil
+}
+
+func (f *fakeDeadLetterService) Retry(ctx context.Context, kind string, appID uint64, rawIndex uint64) (*repository.DeadLetter, error) {
+	for i := range f.deadLetters {
+		deadLetter := f.deadLetters[i]
+		if deadLetter.Kind == kind && deadLetter.AppID == appID && deadLetter.RawIndex == rawIndex {
+			if f.resolved {
+				return nil, nil
+			}
+			deadLetter.RetryCount++
+			return &deadLetter, nil
+		}
+	}
+	return nil, repository.ErrDeadLetterNotFound
+}
+
+const verifyApp = "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+
+type fakeVerifyService struct {
+	last   *model.VerifyReport
+	repair bool
+}
+
+func (f *fakeVerifyService) LastReport() *model.VerifyReport {
+	return f.last
+}
+
+func (f *fakeVerifyService) Verify(ctx context.Context, appContract *common.Address, repair bool) (*model.VerifyReport, error) {
+	if appContract != nil && *appContract != common.HexToAddress(verifyApp) {
+		return nil, repository.ErrApplicationNotFound
+	}
+	f.repair = repair
+	f.last = &model.VerifyReport{Consistent: true}
+	return f.last, nil
+}
+
+type fakeRetentionService struct{}
+
+func (f fakeRetentionService) Interval() time.Duration {
+	return time.Hour
+}
+
+func (f fakeRetentionService) RetentionPolicy() model.RetentionPolicy {
+	return model.RetentionPolicy{Default: model.RetentionRule{ReportsDays: 30}}
+}
+
+func (f fakeRetentionService) LastRun() *model.RetentionRun {
+	return nil
+}
+
+type fakeCacheService struct{}
+
+func (f fakeCacheService) Stats() reader.CacheStats {
+	return reader.CacheStats{Hits: 3, Misses: 1}
+}
+
+type fakeSyncService struct {
+	status model.SyncStatus
+}
+
+func (f *fakeSyncService) Status() model.SyncStatus {
+	return f.status
+}
+
+func (f *fakeSyncService) Pause() {
+	f.status.Paused = true
+}
+
+func (f *fakeSyncService) Resume() {
+	f.status.Paused = false
+}
+
+func (f *fakeSyncService) Trigger() {
+	f.status.TriggerPending = true
+}
+
+func (f *fakeSyncService) Checkpoints(ctx context.Context, appContract *common.Address) ([]model.SyncCheckpoint, error) {
+	if appContract != nil && *appContract != common.HexToAddress(verifyApp) {
+		return nil, repository.ErrApplicationNotFound
+	}
+	index := uint64(7)
+	return []model.SyncCheckpoint{{AppID: 1, AppContract: verifyApp, LastInputIndex: &index}}, nil
+}
+
+type AdminSuite struct {
+	suite.Suite
+	service *fakeDeadLetterService
+	verify  *fakeVerifyService
+	sync    *fakeSyncService
+	states  *supervisor.WorkerStates
+	level   *slog.LevelVar
+	e       *echo.Echo
+}
+
+func (s *AdminSuite) SetupTest() {
+	s.service = &fakeDeadLetterService{
+		deadLetters: []repository.DeadLetter{{
+			Kind:     repository.DEAD_LETTER_OUTPUT,
+			AppID:    1,
+			RawIndex: 2,
+			Error:    "value not found",
+		}},
+	}
+	s.verify = &fakeVerifyService{}
+	s.e = echo.New()
+	RegisterDeadLetters(s.e, s.service)
+	RegisterVerify(s.e, s.verify)
+	RegisterRetention(s.e, fakeRetentionService{})
+	RegisterCache(s.e, fakeCacheService{})
+	s.sync = &fakeSyncService{}
+	RegisterSync(s.e, s.sync)
+	s.states = supervisor.NewWorkerStates()
+	RegisterWorkers(s.e, s.states)
+	s.level = new(slog.LevelVar)
+	RegisterLogLevel(s.e, s.level)
+}
+
+func TestAdminSuite(t *testing.T) {
+	suite.Run(t, new(AdminSuite))
+}
+
+func (s *AdminSuite) request(method string, target string) *httptest.ResponseRecorder {
+	return s.requestBody(method, target, "")
+}
+
+func (s *AdminSuite) requestBody(method string, target string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *AdminSuite) TestListDeadLetters() {
+	rec := s.request(http.MethodGet, "/admin/dead-letters")
+	s.Equal(http.StatusOK, rec.Code)
+	var deadLetters []repository.DeadLetter
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &deadLetters))
+	s.Require().Len(deadLetters, 1)
+	s.Equal("value not found", deadLetters[0].Error)
+}
+
+func (s *AdminSuite) TestRetryFailsAgain() {
+	rec := s.request(http.MethodPost, "/admin/dead-letters/output/1/2/retry")
+	s.Equal(http.StatusOK, rec.Code)
+	var result RetryResult
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.False(result.Resolved)
+	s.Equal(uint64(1), result.DeadLetter.RetryCount)
+}
+
+func (s *AdminSuite) TestRetryResolved() {
+	s.service.resolved = true
+	rec := s.request(http.MethodPost, "/admin/dead-letters/output/1/2/retry")
+	s.Equal(http.StatusOK, rec.Code)
+	var result RetryResult
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+	s.True(result.Resolved)
+	s.Nil(result.DeadLetter)
+}
+
+func (s *AdminSuite) TestRetryValidation() {
+	s.Equal(http.StatusBadRequest, s.request(http.MethodPost, "/admin/dead-letters/report/1/2/retry").Code)
+	s.Equal(http.StatusBadRequest, s.request(http.MethodPost, "/admin/dead-letters/output/x/2/retry").Code)
+	s.Equal(http.StatusNotFound, s.request(http.MethodPost, "/admin/dead-letters/input/1/2/retry").Code)
+}
+
+func (s *AdminSuite) TestVerify() {
+	s.Equal(http.StatusNotFound, s.request(http.MethodGet, "/admin/verify").Code)
+
+	rec := s.request(http.MethodPost, "/admin/verify?appContract="+verifyApp+"&repair=true")
+	s.Equal(http.StatusOK, rec.Code)
+	s.True(s.verify.repair)
+	var report model.VerifyReport
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &report))
+	s.True(report.Consistent)
+
+	s.Equal(http.StatusOK, s.request(http.MethodGet, "/admin/verify").Code)
+}
+
+func (s *AdminSuite) TestVerifyValidation() {
+	s.Equal(http.StatusBadRequest, s.request(http.MethodPost, "/admin/verify?appContract=0xzz").Code)
+	s.Equal(http.StatusBadRequest, s.request(http.MethodPost, "/admin/verify?repair=maybe").Code)
+	s.Equal(http.StatusNotFound, s.request(http.MethodPost, "/admin/verify?appContract=0x75135d8adb7180640d29d822d9ad59e83e8695b2").Code)
+}
+
+func (s *AdminSuite) TestRetention() {
+	rec := s.request(http.MethodGet, "/admin/retention")
+	s.Equal(http.StatusOK, rec.Code)
+	var status RetentionStatus
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &status))
+	s.Equal("1h0m0s", status.Interval)
+	s.Equal(30, status.Policy.Default.ReportsDays)
+	s.Nil(status.LastRun)
+}
+
+func (s *AdminSuite) TestCache() {
+	rec := s.request(http.MethodGet, "/admin/cache")
+	s.Equal(http.StatusOK, rec.Code)
+	var stats reader.CacheStats
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &stats))
+	s.Equal(uint64(3), stats.Hits)
+	s.Equal(uint64(1), stats.Misses)
+}
+
+func (s *AdminSuite) TestSyncPauseResumeTrigger() {
+	var status model.SyncStatus
+	rec := s.request(http.MethodPost, "/admin/sync/pause")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &status))
+	s.True(status.Paused)
+
+	rec = s.request(http.MethodPost, "/admin/sync/trigger")
+	s.Equal(http.StatusAccepted, rec.Code)
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &status))
+	s.True(status.TriggerPending)
+
+	s.Equal(http.StatusOK, s.request(http.MethodPost, "/admin/sync/resume").Code)
+	rec = s.request(http.MethodGet, "/admin/sync")
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &status))
+	s.False(status.Paused)
+}
+
+func (s *AdminSuite) TestSyncCheckpoints() {
+	rec := s.request(http.MethodGet, "/admin/sync/checkpoints?appContract="+verifyApp)
+	s.Equal(http.StatusOK, rec.Code)
+	var checkpoints []model.SyncCheckpoint
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &checkpoints))
+	s.Require().Len(checkpoints, 1)
+	s.Equal(uint64(7), *checkpoints[0].LastInputIndex)
+
+	s.Equal(http.StatusBadRequest, s.request(http.MethodGet, "/admin/sync/checkpoints?appContract=0xzz").Code)
+	s.Equal(http.StatusNotFound,
+		s.request(http.MethodGet, "/admin/sync/checkpoints?appContract=0x75135d8adb7180640d29d822d9ad59e83e8695b2").Code)
+}
+
+func (s *AdminSuite) TestWorkers() {
+	rec := s.request(http.MethodGet, "/admin/workers")
+	s.Equal(http.StatusOK, rec.Code)
+	s.JSONEq(`[]`, rec.Body.String())
+
+	worker := supervisor.WithRestart(supervisor.HttpWorker{Name: "api"}, supervisor.RestartPolicy{
+		Mode: supervisor.RestartOnFailure,
+	}, s.states)
+	s.Equal("api", worker.String())
+	rec = s.request(http.MethodGet, "/admin/workers")
+	var workers []supervisor.WorkerState
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &workers))
+	s.Require().Len(workers, 1)
+	s.Equal("api", workers[0].Name)
+	s.Equal(supervisor.RestartOnFailure, workers[0].Policy)
+}
+
+func (s *AdminSuite) TestLogLevel() {
+	rec := s.request(http.MethodGet, "/admin/log-level")
+	s.JSONEq(`{"level": "INFO"}`, rec.Body.String())
+
+	rec = s.requestBody(http.MethodPut, "/admin/log-level", `{"level": "debug"}`)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(slog.LevelDebug, s.level.Level())
+
+	rec = s.requestBody(http.MethodPut, "/admin/log-level", `{"level": "verbose"}`)
+	s.Equal(http.StatusBadRequest, rec.Code)
+	s.Equal(slog.LevelDebug, s.level.Level())
+}
@@ -0,0 +1,281 @@
+// This package authenticates the requests of the public API with API keys,
+// restricting each key to its applications, and rate limits them.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderAPIKey = "X-API-Key"
+	// Grants a key access to every application, including the root endpoints
+	AllApps = "*"
+)
+
+// Options of the authentication and rate limiting of the public API.
+type Opts struct {
+	// JSON file with the API keys, authentication is disabled when empty
+	KeysFile string
+	// Requests per second of each key, or of each IP without authentication, zero disables it
+	RateLimit float64
+	// Requests allowed at once above the rate, at least one
+	RateLimitBurst int
+	// CIDRs of the reverse proxies whose X-Forwarded-For header gives the client IP,
+	// without them the IP is the address of the connection
+	TrustedProxies []string
+}
+
+var (
+	errMissingKey    = errors.New("missing or invalid API key")
+	errAppNotAllowed = errors.New("API key not allowed for this application")
+	errRateLimited   = errors.New("rate limit exceeded")
+)
+
+// Key is an API key as configured in the keys file.
+type Key struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	// Application addresses the key may read, or "*" for all of them
+	Apps []string `json:"apps"`
+	// Optional rate limit of the key, overriding the default one
+	RateLimit float64 `json:"rateLimit,omitempty"`
+	Burst     int     `json:"burst,omitempty"`
+}
+
+type keysFile struct {
+	Keys []Key `json:"keys"`
+}
+
+type apiKey struct {
+	name    string
+	allApps bool
+	apps    map[common.Address]bool
+	limit   limit
+}
+
+func (k *apiKey) allows(appContract string) bool {
+	if k.allApps {
+		return true
+	}
+	if appContract == "" || !common.IsHexAddress(appContract) {
+		return false
+	}
+	return k.apps[common.HexToAddress(appContract)]
+}
+
+// LoadKeys reads and validates the keys file.
+func LoadKeys(path string) ([]Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file keysFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid keys file %s: %w", path, err)
+	}
+	names := make(map[string]bool, len(file.Keys))
+	values := make(map[string]bool, len(file.Keys))
+	for _, key := range file.Keys {
+		if key.Name == "" || key.Key == "" {
+			return nil, fmt.Errorf("invalid keys file %s: every key needs a name and a key", path)
+		}
+		if names[key.Name] || values[key.Key] {
+			return nil, fmt.Errorf("invalid keys file %s: duplicated key %s", path, key.Name)
+		}
+		names[key.Name] = true
+		values[key.Key] = true
+		for _, app := range key.Apps {
+			if app != AllApps && !common.IsHexAddress(app) {
+				return nil, fmt.Errorf("invalid keys file %s: key %s has invalid app %s", path, key.Name, app)
+			}
+		}
+		if key.RateLimit < 0 || key.Burst < 0 {
+			return nil, fmt.Errorf("invalid keys file %s: key %s has a negative rate limit", path, key.Name)
+		}
+	}
+	return file.Keys, nil
+}
+
+// Authenticator checks the API key and the rate limit of the requests.
+// The keys are replaced as a whole by Reload, so a request sees either set.
+type Authenticator struct {
+	opts      Opts
+	keys      atomic.Pointer[map[[sha256.Size]byte]*apiKey]
+	limiter   *rateLimiter
+	extractIP echo.IPExtractor
+}
+
+func NewAuthenticator(opts Opts) (*Authenticator, error) {
+	extractIP, err := ipExtractor(opts.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	a := &Authenticator{
+		opts:      opts,
+		limiter:   newRateLimiter(),
+		extractIP: extractIP,
+	}
+	if err := a.Reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ipExtractor trusts the X-Forwarded-For header only when it comes from one of the proxies,
+// so the clients cannot pick the IP their requests are rate limited by.
+func ipExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %w", proxy, err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+// IPExtractor returns how the client IP of the requests is read, to be set on the echo instance.
+func (a *Authenticator) IPExtractor() echo.IPExtractor {
+	return a.extractIP
+}
+
+// Enabled tells whether the requests must carry an API key.
+func (a *Authenticator) Enabled() bool {
+	return a.opts.KeysFile != ""
+}
+
+// Reload reads the keys file again, keeping the current keys when it is invalid.
+func (a *Authenticator) Reload() error {
+	if !a.Enabled() {
+		return nil
+	}
+	keys, err := LoadKeys(a.opts.KeysFile)
+	if err != nil {
+		return err
+	}
+	byHash := make(map[[sha256.Size]byte]*apiKey, len(keys))
+	for _, key := range keys {
+		k := &apiKey{
+			name:  key.Name,
+			apps:  make(map[common.Address]bool, len(key.Apps)),
+			limit: a.defaultLimit(),
+		}
+		for _, app := range key.Apps {
+			if app == AllApps {
+				k.allApps = true
+			} else {
+				k.apps[common.HexToAddress(app)] = true
+			}
+		}
+		if key.RateLimit > 0 {
+			k.limit = limit{rate: key.RateLimit, burst: max(key.Burst, 1)}
+		}
+		// the lookup by hash does not leak the keys through timing
+		byHash[sha256.Sum256([]byte(key.Key))] = k
+	}
+	a.keys.Store(&byHash)
+	a.limiter.reset()
+	return nil
+}
+
+func (a *Authenticator) defaultLimit() limit {
+	return limit{rate: a.opts.RateLimit, burst: max(a.opts.RateLimitBurst, 1)}
+}
+
+func (a *Authenticator) lookup(value string) *apiKey {
+	keys := a.keys.Load()
+	if value == "" || keys == nil {
+		return nil
+	}
+	return (*keys)[sha256.Sum256([]byte(value))]
+}
+
+// Middleware authenticates and rate limits the requests of echo. A key only reaches
+// the routes of its applications, and the routes without an application, such as
+// the root GraphQL endpoint, when it has access to all of them.
+// The health checks and the playground pages are always served.
+func (a *Authenticator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skipAuth(c) {
+				return next(c)
+			}
+			err := a.authorize(c.Request().Context(), requestKey(c.Request()), c.Param("appContract"),
+				a.extractIP(c.Request()))
+			switch {
+			case errors.Is(err, errMissingKey):
+				return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+			case errors.Is(err, errAppNotAllowed):
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			case errors.Is(err, errRateLimited):
+				c.Response().Header().Set("Retry-After", "1")
+				return echo.NewHTTPError(http.StatusTooManyRequests, err.Error())
+			}
+			return next(c)
+		}
+	}
+}
+
+// authorize checks the key of a request to the application, empty for the routes
+// without one, and takes a token of the bucket of the key, or of the IP without keys.
+func (a *Authenticator) authorize(ctx context.Context, value string, appContract string, ip string) error {
+	identifier := "ip:" + ip
+	limit := a.defaultLimit()
+	if a.Enabled() {
+		key := a.lookup(value)
+		if key == nil {
+			return errMissingKey
+		}
+		if !key.allows(appContract) {
+			slog.DebugContext(ctx, "auth: application not allowed", "key", key.name, "app_contract", appContract)
+			return errAppNotAllowed
+		}
+		identifier = "key:" + key.name
+		limit = key.limit
+	}
+	if !a.limiter.allow(identifier, limit) {
+		return errRateLimited
+	}
+	return nil
+}
+
+func skipAuth(c echo.Context) bool {
+	path := c.Path()
+	if strings.HasPrefix(path, "/health") {
+		return true
+	}
+	return c.Request().Method == http.MethodGet && strings.HasPrefix(path, "/graphql")
+}
+
+// requestKey reads the key from the X-API-Key header or from a bearer token.
+func requestKey(r *http.Request) string {
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		return key
+	}
+	token, ok := strings.CutPrefix(r.Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	appA = "0x75135d8ADb7180640d29d822D9AD59E83E8695b2"
+	appB = "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+)
+
+type AuthSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
+
+func (s *AuthSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "keys.json")
+	s.writeKeys(`{"keys": [
+		{"name": "frontend", "key": "frontend-secret", "apps": ["` + appA + `"]},
+		{"name": "indexer", "key": "indexer-secret", "apps": ["*"], "rateLimit": 1, "burst": 2}
+	]}`)
+}
+
+func (s *AuthSuite) writeKeys(content string) {
+	s.Require().NoError(os.WriteFile(s.path, []byte(content), 0600))
+}
+
+func (s *AuthSuite) newEcho(opts Opts) (*echo.Echo, *Authenticator) {
+	authenticator, err := NewAuthenticator(opts)
+	s.Require().NoError(err)
+	e := echo.New()
+	e.Use(authenticator.Middleware())
+	ok := func(c echo.Context) error { return c.String(http.StatusOK, "ok") }
+	e.POST("/graphql", ok)
+	e.POST("/graphql/:appContract", ok)
+	e.GET("/graphql/:appContract", ok)
+	e.GET("/health", ok)
+	return e, authenticator
+}
+
+func (s *AuthSuite) request(e *echo.Echo, method string, target string, header string, value string) int {
+	req := httptest.NewRequest(method, target, nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func (s *AuthSuite) TestAuthentication() {
+	e, _ := s.newEcho(Opts{KeysFile: s.path})
+	s.Equal(http.StatusUnauthorized, s.request(e, http.MethodPost, "/graphql/"+appA, "", ""))
+	s.Equal(http.StatusUnauthorized, s.request(e, http.MethodPost, "/graphql/"+appA, HeaderAPIKey, "wrong"))
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appA, HeaderAPIKey, "frontend-secret"))
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appA,
+		echo.HeaderAuthorization, "Bearer frontend-secret"))
+	// the health checks and the playground do not need a key
+	s.Equal(http.StatusOK, s.request(e, http.MethodGet, "/health", "", ""))
+	s.Equal(http.StatusOK, s.request(e, http.MethodGet, "/graphql/"+appA, "", ""))
+}
+
+func (s *AuthSuite) TestAuthorization() {
+	e, _ := s.newEcho(Opts{KeysFile: s.path})
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appA, HeaderAPIKey, "frontend-secret"))
+	s.Equal(http.StatusForbidden, s.request(e, http.MethodPost, "/graphql/"+appB, HeaderAPIKey, "frontend-secret"))
+	s.Equal(http.StatusForbidden, s.request(e, http.MethodPost, "/graphql", HeaderAPIKey, "frontend-secret"))
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appB, HeaderAPIKey, "indexer-secret"))
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql", HeaderAPIKey, "indexer-secret"))
+}
+
+func (s *AuthSuite) TestRateLimitPerKey() {
+	e, _ := s.newEcho(Opts{KeysFile: s.path})
+	for range 2 {
+		s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql", HeaderAPIKey, "indexer-secret"))
+	}
+	s.Equal(http.StatusTooManyRequests, s.request(e, http.MethodPost, "/graphql", HeaderAPIKey, "indexer-secret"))
+	// the other keys have their own buckets, without a limit by default
+	for range 5 {
+		s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appA, HeaderAPIKey, "frontend-secret"))
+	}
+}
+
+func (s *AuthSuite) requestFrom(e *echo.Echo, remoteAddr string, header string, value string) int {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.RemoteAddr = remoteAddr
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func (s *AuthSuite) TestRateLimitPerIPWithoutKeys() {
+	e, _ := s.newEcho(Opts{RateLimit: 1, RateLimitBurst: 1})
+	s.Equal(http.StatusOK, s.requestFrom(e, "10.0.0.1:1234", "", ""))
+	s.Equal(http.StatusTooManyRequests, s.requestFrom(e, "10.0.0.1:1234", "", ""))
+	// the headers cannot pick another bucket
+	s.Equal(http.StatusTooManyRequests, s.requestFrom(e, "10.0.0.1:1234", echo.HeaderXRealIP, "10.0.0.2"))
+	s.Equal(http.StatusTooManyRequests, s.requestFrom(e, "10.0.0.1:1234", echo.HeaderXForwardedFor, "10.0.0.2"))
+	s.Equal(http.StatusOK, s.requestFrom(e, "10.0.0.2:1234", "", ""))
+}
+
+func (s *AuthSuite) TestRateLimitPerForwardedIPFromTrustedProxies() {
+	e, _ := s.newEcho(Opts{RateLimit: 1, RateLimitBurst: 1, TrustedProxies: []string{"10.1.0.0/16"}})
+	s.Equal(http.StatusOK, s.requestFrom(e, "10.1.0.1:1234", echo.HeaderXForwardedFor, "203.0.113.1"))
+	s.Equal(http.StatusTooManyRequests, s.requestFrom(e, "10.1.0.1:1234", echo.HeaderXForwardedFor, "203.0.113.1"))
+	s.Equal(http.StatusOK, s.requestFrom(e, "10.1.0.1:1234", echo.HeaderXForwardedFor, "203.0.113.2"))
+	// other addresses are not trusted to forward
+	s.Equal(http.StatusOK, s.requestFrom(e, "10.2.0.1:1234", echo.HeaderXForwardedFor, "203.0.113.1"))
+	s.Equal(http.StatusTooManyRequests, s.requestFrom(e, "10.2.0.1:1234", echo.HeaderXForwardedFor, "203.0.113.3"))
+
+	_, err := NewAuthenticator(Opts{TrustedProxies: []string{"10.1.0.1"}})
+	s.ErrorContains(err, "invalid trusted proxy 10.1.0.1")
+}
+
+func (s *AuthSuite) TestReload() {
+	e, authenticator := s.newEcho(Opts{KeysFile: s.path})
+	s.writeKeys(`{"keys": [{"name": "frontend", "key": "rotated", "apps": ["` + appB + `"]}]}`)
+	s.Require().NoError(authenticator.Reload())
+	s.Equal(http.StatusUnauthorized, s.request(e, http.MethodPost, "/graphql/"+appA, HeaderAPIKey, "frontend-secret"))
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appB, HeaderAPIKey, "rotated"))
+
+	// an invalid file keeps the current keys
+	s.writeKeys(`{"keys": [{"name": "frontend"}]}`)
+	s.Error(authenticator.Reload())
+	s.Equal(http.StatusOK, s.request(e, http.MethodPost, "/graphql/"+appB, HeaderAPIKey, "rotated"))
+}
+
+func (s *AuthSuite) TestLoadKeysValidation() {
+	s.writeKeys(`{"keys": [{"name": "a", "key": "k", "apps": ["0xzz"]}]}`)
+	_, err := LoadKeys(s.path)
+	s.ErrorContains(err, "key a has invalid app 0xzz")
+
+	s.writeKeys(`{"keys": [{"name": "a", "key": "k"}, {"name": "b", "key": "k"}]}`)
+	_, err = LoadKeys(s.path)
+	s.ErrorContains(err, "duplicated key b")
+}
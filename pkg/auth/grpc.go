@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// appScoped is a request of the gRPC API scoped to an application.
+type appScoped interface {
+	GetAppContract() string
+}
+
+// GrpcServerOptions returns the interceptors authenticating and rate limiting the calls
+// of the gRPC API as Middleware does for echo. The key is read from the x-api-key or
+// the authorization metadata and the application from the app_contract of the request,
+// so the calls without one need a key with access to all of them.
+func (a *Authenticator) GrpcServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(
+			ctx context.Context,
+			req any,
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			if err := a.authorizeCall(ctx, req); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(
+			srv any,
+			stream grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			return handler(srv, &authorizedStream{ServerStream: stream, authenticator: a})
+		}),
+	}
+}
+
+func (a *Authenticator) authorizeCall(ctx context.Context, req any) error {
+	appContract := ""
+	if scoped, ok := req.(appScoped); ok {
+		appContract = scoped.GetAppContract()
+	}
+	err := a.authorize(ctx, callKey(ctx), appContract, peerIP(ctx))
+	switch {
+	case errors.Is(err, errMissingKey):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, errAppNotAllowed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errRateLimited):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// authorizedStream checks the first request of the stream, which carries its application.
+type authorizedStream struct {
+	grpc.ServerStream
+	authenticator *Authenticator
+	checked       bool
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil || s.checked {
+		return err
+	}
+	s.checked = true
+	return s.authenticator.authorizeCall(s.Context(), m)
+}
+
+// callKey reads the key from the x-api-key metadata or from a bearer token.
+func callKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(HeaderAPIKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	for _, value := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(value, "Bearer "); ok {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// peerIP is the address of the connection, the gRPC API does not trust forwarded addresses.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
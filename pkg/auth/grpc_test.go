@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRequest struct {
+	appContract string
+}
+
+func (r *fakeRequest) GetAppContract() string {
+	return r.appContract
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req fakeRequest
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	*m.(*fakeRequest) = s.req
+	return nil
+}
+
+func callContext(ip string, pairs ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234},
+	})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(pairs...))
+}
+
+func (s *AuthSuite) TestGrpcAuthentication() {
+	authenticator, err := NewAuthenticator(Opts{KeysFile: s.path})
+	s.Require().NoError(err)
+	check := func(ctx context.Context, appContract string) codes.Code {
+		return status.Code(authenticator.authorizeCall(ctx, &fakeRequest{appContract: appContract}))
+	}
+	s.Equal(codes.Unauthenticated, check(callContext("10.0.0.1"), appA))
+	s.Equal(codes.Unauthenticated, check(callContext("10.0.0.1", "x-api-key", "wrong"), appA))
+	s.Equal(codes.OK, check(callContext("10.0.0.1", "x-api-key", "frontend-secret"), appA))
+	s.Equal(codes.OK, check(callContext("10.0.0.1", "authorization", "Bearer frontend-secret"), appA))
+	s.Equal(codes.PermissionDenied, check(callContext("10.0.0.1", "x-api-key", "frontend-secret"), appB))
+	// the calls without an application need all of them
+	s.Equal(codes.PermissionDenied, check(callContext("10.0.0.1", "x-api-key", "frontend-secret"), ""))
+	s.Equal(codes.OK, check(callContext("10.0.0.1", "x-api-key", "indexer-secret"), ""))
+	s.Equal(codes.OK, check(callContext("10.0.0.1", "x-api-key", "indexer-secret"), appB))
+	s.Equal(codes.ResourceExhausted, check(callContext("10.0.0.1", "x-api-key", "indexer-secret"), appB))
+}
+
+func (s *AuthSuite) TestGrpcRateLimitPerPeer() {
+	authenticator, err := NewAuthenticator(Opts{RateLimit: 1, RateLimitBurst: 1})
+	s.Require().NoError(err)
+	s.NoError(authenticator.authorizeCall(callContext("10.0.0.1"), &fakeRequest{}))
+	s.Equal(codes.ResourceExhausted, status.Code(authenticator.authorizeCall(callContext("10.0.0.1"), &fakeRequest{})))
+	s.NoError(authenticator.authorizeCall(callContext("10.0.0.2"), &fakeRequest{}))
+}
+
+func (s *AuthSuite) TestGrpcStreamChecksTheFirstRequest() {
+	authenticator, err := NewAuthenticator(Opts{KeysFile: s.path})
+	s.Require().NoError(err)
+	ctx := callContext("10.0.0.1", "x-api-key", "frontend-secret")
+	stream := &authorizedStream{
+		ServerStream:  &fakeStream{ctx: ctx, req: fakeRequest{appContract: appB}},
+		authenticator: authenticator,
+	}
+	var req fakeRequest
+	s.Equal(codes.PermissionDenied, status.Code(stream.RecvMsg(&req)))
+
+	stream = &authorizedStream{
+		ServerStream:  &fakeStream{ctx: ctx, req: fakeRequest{appContract: appA}},
+		authenticator: authenticator,
+	}
+	s.NoError(stream.RecvMsg(&req))
+	s.Equal(appA, req.appContract)
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Idle time after which the bucket of a key or IP is dropped
+const limiterExpiration = 3 * time.Minute
+
+type limit struct {
+	// Requests per second, zero disables the limit
+	rate  float64
+	burst int
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter keeps a token bucket for each key or IP.
+type rateLimiter struct {
+	mu          sync.Mutex
+	entries     map[string]*limiterEntry
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		entries: make(map[string]*limiterEntry),
+		now:     time.Now,
+	}
+}
+
+func (r *rateLimiter) allow(identifier string, l limit) bool {
+	if l.rate <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if now.Sub(r.lastCleanup) > limiterExpiration {
+		r.cleanup(now)
+	}
+	entry, ok := r.entries[identifier]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(l.rate), l.burst)}
+		r.entries[identifier] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.AllowN(now, 1)
+}
+
+func (r *rateLimiter) cleanup(now time.Time) {
+	for identifier, entry := range r.entries {
+		if now.Sub(entry.lastSeen) > limiterExpiration {
+			delete(r.entries, identifier)
+		}
+	}
+	r.lastCleanup = now
+}
+
+// reset drops the buckets, so that they pick the limits of the reloaded keys.
+func (r *rateLimiter) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]*limiterEntry)
+}
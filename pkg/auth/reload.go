@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadWorker reloads the keys file of the authenticator on SIGHUP.
+type ReloadWorker struct {
+	Authenticator *Authenticator
+}
+
+func (w ReloadWorker) String() string {
+	return "auth-reload"
+}
+
+// Start implements supervisor.Worker.
+func (w ReloadWorker) Start(ctx context.Context, ready chan<- struct{}) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+	ready <- struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-signals:
+			if err := w.Authenticator.Reload(); err != nil {
+				slog.ErrorContext(ctx, "auth: keeping the current keys", "err", err)
+				continue
+			}
+			slog.InfoContext(ctx, "auth: keys reloaded", "file", w.Authenticator.opts.KeysFile)
+		}
+	}
+}
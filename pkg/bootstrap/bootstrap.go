@@ -13,19 +13,26 @@ import (
 	"path"
 	"time"
 
+	"github.com/cartesi/rollups-graphql/v2/pkg/admin"
+	"github.com/cartesi/rollups-graphql/v2/pkg/auth"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/contracts"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/synchronizer"
 	synchronizernode "github.com/cartesi/rollups-graphql/v2/pkg/convenience/synchronizer_node"
 	"github.com/cartesi/rollups-graphql/v2/pkg/health"
 	"github.com/cartesi/rollups-graphql/v2/pkg/reader"
 	"github.com/cartesi/rollups-graphql/v2/pkg/supervisor"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	_ "github.com/lib/pq"
-	"github.com/spf13/cast"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -48,6 +55,61 @@ type BootstrapOpts struct {
 	DbImplementation   string
 	TimeoutWorker      time.Duration
 	DisableSync        bool
+	// Connection string of the GraphQL database when using PostgreSQL
+	DbUrl string
+	// Connection string of a read replica of the GraphQL database, serving the reads of the API
+	DbReadUrl string
+	// Maximum lag of the read replica before the reads fall back to the primary, zero disables the guard
+	DbReadMaxLag time.Duration
+	// Connection string of the node database
+	NodeDbUrl string
+	// Connection pool of the PostgreSQL databases
+	DbPool DbPoolOpts
+	// Listen for node database notifications instead of only polling it
+	SyncNotify bool
+	// Install the notify triggers on the node database tables
+	SyncNotifyInstallTriggers bool
+	// Polling interval kept as a fallback of the notifications
+	SyncNotifyFallback time.Duration
+	// Number of applications synced in parallel, zero keeps the single pass sync
+	SyncAppWorkers int
+	// Commit all the steps of a sync cycle of an application in a single transaction
+	SyncAtomic bool
+	// Duration aimed at by the transactions of the atomic sync cycles
+	SyncAtomicTarget time.Duration
+	// Address of the admin API listener, disabled when empty
+	AdminHttpAddress string
+	// Address of the gRPC reader API listener, disabled when empty
+	GrpcAddress string
+	// Restarts of the synchronizer before stopping the process, zero means no limit
+	SyncMaxRestarts int
+	// Apply the pending schema migrations at startup instead of refusing to start
+	MigrateOnStart bool
+	// Interval of the background consistency verification, zero disables it
+	VerifyInterval time.Duration
+	// Resync the applications found inconsistent by the background verification
+	VerifyRepair bool
+	// Interval of the pruning of the rows selected by the retention policy, zero disables it
+	RetentionInterval time.Duration
+	RetentionPolicy   model.RetentionPolicy
+	// Query limits, persisted queries and allowlist of the GraphQL API
+	GraphQL reader.GraphQLOpts
+	// Entries of the GraphQL response cache, zero disables it
+	GraphQLCacheSize int
+	// Expiration of the cached responses, which covers the writes made outside the synchronizer
+	GraphQLCacheTTL time.Duration
+	// Optional API key authentication and rate limiting of the public API
+	Auth *auth.Authenticator
+	// Export of the spans of the requests, the resolvers, the queries and the sync cycles
+	Tracing tracing.Opts
+}
+
+// Connection pool settings shared by the GraphQL and the node databases.
+type DbPoolOpts struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
 }
 
 // Create the options struct with default values.
@@ -61,115 +123,304 @@ func NewBootstrapOpts() BootstrapOpts {
 		TimeoutWorker:      0,
 		AutoCount:          false,
 		DisableSync:        false,
+		SyncNotify:         false,
+		SyncNotifyFallback: synchronizernode.DEFAULT_NOTIFY_FALLBACK,
+		SyncAtomicTarget:   synchronizernode.DEFAULT_ATOMIC_TARGET,
+		MigrateOnStart:     true,
+		GraphQL:            reader.NewGraphQLOpts(),
+		GraphQLCacheTTL:    reader.DefaultResponseCacheTTL,
+		Tracing:            tracing.Opts{SampleRatio: 1},
+		DbPool: DbPoolOpts{
+			MaxOpenConns:    DefaultMaxOpenConnections,
+			MaxIdleConns:    DefaultMaxIdleConnections,
+			ConnMaxLifetime: DefaultConnMaxLifetime,
+			ConnMaxIdleTime: DefaultConnMaxIdleTime,
+		},
 	}
 }
 
 func NewSupervisorGraphQL(ctx context.Context, opts BootstrapOpts) supervisor.SupervisorWorker {
 	var w supervisor.SupervisorWorker
 	w.Timeout = opts.TimeoutWorker
+	w.States = supervisor.NewWorkerStates()
 	db := CreateDBInstance(ctx, opts)
+	err := MigrateSchema(ctx, db, opts.MigrateOnStart)
+	if err != nil {
+		panic(err)
+	}
 	container := convenience.NewContainer(db, opts.AutoCount)
 	convenienceService := container.GetConvenienceService(ctx)
 	adapter := reader.NewAdapterV1(ctx, db, convenienceService)
 
 	e := echo.New()
+	// the clients cannot pick their IP with the X-Real-IP or X-Forwarded-For headers
+	e.IPExtractor = echo.ExtractIPDirect()
+	if opts.Auth != nil {
+		e.IPExtractor = opts.Auth.IPExtractor()
+	}
+	if opts.Tracing.Enabled() {
+		e.Use(tracing.Middleware())
+		opts.GraphQL.Tracing = true
+	}
 	e.Use(middleware.CORS())
 	e.Use(middleware.Recover())
+	if opts.Auth != nil {
+		e.Use(opts.Auth.Middleware())
+		if opts.Auth.Enabled() {
+			w.Workers = append(w.Workers, auth.ReloadWorker{Authenticator: opts.Auth})
+		}
+	}
 	e.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
 		ErrorMessage: "Request timed out",
 	}))
-	health.Register(e)
-	reader.Register(ctx, e, convenienceService, adapter)
+	healthChecks := []health.Check{health.DatabaseCheck("graphql_database", db, true)}
+	if opts.DbReadUrl != "" {
+		slog.InfoContext(ctx, "Reading from the replica of the GraphQL database", "max_lag", opts.DbReadMaxLag)
+		readDb := CreateReadDBInstance(opts)
+		opts.GraphQL.ReadRouter = repository.NewReadRouter(db, readDb, opts.DbReadMaxLag)
+		w.Workers = append(w.Workers, opts.GraphQL.ReadRouter)
+		healthChecks = append(healthChecks, health.DatabaseCheck("graphql_read_database", readDb, false))
+	}
+	if opts.GraphQLCacheSize > 0 {
+		router := opts.GraphQL.ReadRouter
+		if router != nil && router.MaxLag <= 0 {
+			// the replica could keep serving a change long after the cache was invalidated
+			slog.WarnContext(ctx, "The GraphQL response cache needs a maximum lag of the replica, disabling it")
+		} else {
+			opts.GraphQL.Cache = reader.NewResponseCache(opts.GraphQLCacheSize, opts.GraphQLCacheTTL)
+			if router != nil {
+				opts.GraphQL.Cache.ReplayDelay = router.MaxLag + router.CheckInterval
+			}
+		}
+	}
+	reader.Register(ctx, e, convenienceService, adapter, opts.GraphQL)
 	w.Workers = append(w.Workers, supervisor.HttpWorker{
 		Address: fmt.Sprintf("%v:%v", opts.HttpAddress, opts.HttpPort),
 		Handler: e,
 	})
-
-	if !opts.DisableSync {
-		dbRawUrl, ok := os.LookupEnv("CARTESI_DATABASE_CONNECTION")
-		if !ok {
-			panic("CARTESI_DATABASE_CONNECTION environment variable not set")
+	// the allowlist does not cover the gRPC API, config.Validate requires opening it explicitly
+	if opts.GrpcAddress != "" && (opts.GraphQL.Allowlist == nil || opts.GraphQL.AllowlistOpenAPIs) {
+		var grpcOpts []grpc.ServerOption
+		if opts.Auth != nil {
+			grpcOpts = append(grpcOpts, opts.Auth.GrpcServerOptions()...)
 		}
-		dbNodeV2 := sqlx.MustConnect("postgres", dbRawUrl)
-		rawRepository := synchronizernode.NewRawRepository(dbRawUrl, dbNodeV2)
-		synchronizerUpdate := synchronizernode.NewSynchronizerUpdate(
-			container.GetRawInputRepository(ctx),
-			rawRepository,
-			container.GetInputRepository(ctx),
-		)
-		synchronizerReport := synchronizernode.NewSynchronizerReport(
-			container.GetReportRepository(ctx),
-			rawRepository,
-		)
-		synchronizerOutputUpdate := synchronizernode.NewSynchronizerOutputUpdate(
-			container.GetVoucherRepository(ctx),
-			container.GetNoticeRepository(ctx),
-			rawRepository,
-			container.GetRawOutputRefRepository(ctx),
-		)
-
-		abi, err := contracts.OutputsMetaData.GetAbi()
-		if err != nil {
-			panic(err)
+		if opts.GraphQL.ReadRouter != nil {
+			grpcOpts = append(grpcOpts, reader.GrpcReadRouting(opts.GraphQL.ReadRouter)...)
 		}
-		abiDecoder := synchronizernode.NewAbiDecoder(abi)
+		grpcServer := grpc.NewServer(grpcOpts...)
+		reader.RegisterGrpc(grpcServer, adapter, db)
+		w.Workers = append(w.Workers, supervisor.GrpcWorker{
+			Address: opts.GrpcAddress,
+			Server:  grpcServer,
+		})
+	}
 
-		inputAbi, err := contracts.InputsMetaData.GetAbi()
-		if err != nil {
-			panic(err)
-		}
+	adminEcho := echo.New()
+	adminEcho.Use(middleware.Recover())
 
-		inputAbiDecoder := synchronizernode.NewAbiDecoder(inputAbi)
+	retention := synchronizer.NewRetentionSynchronizer(
+		container.GetApplicationRepository(ctx),
+		container.GetRetentionRepository(ctx),
+		nil,
+		opts.RetentionPolicy,
+		opts.RetentionInterval,
+	)
+	admin.RegisterRetention(adminEcho, retention)
+	admin.RegisterWorkers(adminEcho, w.States)
+	admin.RegisterLogLevel(adminEcho, commons.LogLevel)
+	if opts.GraphQL.Cache != nil {
+		retention.ChangeListener = opts.GraphQL.Cache
+		admin.RegisterCache(adminEcho, opts.GraphQL.Cache)
+	}
 
-		synchronizerOutputCreate := synchronizernode.NewSynchronizerOutputCreate(
-			container.GetVoucherRepository(ctx),
-			container.GetNoticeRepository(ctx),
-			rawRepository,
-			container.GetRawOutputRefRepository(ctx),
-			abiDecoder,
+	if !opts.DisableSync {
+		dbRawUrl, dbNodeV2 := OpenNodeDb(opts)
+		healthChecks = append(healthChecks, health.DatabaseCheck("node_database", dbNodeV2, false))
+		synchronizerWorker := NewSynchronizerWorker(ctx, container, dbRawUrl, dbNodeV2)
+		// set before the worker is copied by the dead letters and the verifier
+		if opts.GraphQL.Cache != nil {
+			synchronizerWorker.ChangeListener = opts.GraphQL.Cache
+		}
+		deadLetters := synchronizernode.NewSynchronizerDeadLetter(
+			container.GetDeadLetterRepository(ctx),
+			synchronizerWorker.RawRepository,
+			synchronizerWorker.SynchronizerCreateInput,
+			synchronizerWorker.SynchronizerOutputCreate,
 		)
+		deadLetters.ChangeListener = synchronizerWorker.ChangeListener
+		admin.RegisterDeadLetters(adminEcho, deadLetters)
+		retention.DisabledApps = synchronizerWorker.RawRepository
+		verifier := NewSynchronizerVerifier(ctx, container, synchronizerWorker)
+		admin.RegisterVerify(adminEcho, verifier)
+		if opts.VerifyInterval > 0 {
+			w.Workers = append(w.Workers, supervisor.WithRestart(synchronizernode.VerifyWorker{
+				Verifier: verifier,
+				Interval: opts.VerifyInterval,
+				Repair:   opts.VerifyRepair,
+			}, supervisor.RestartPolicy{
+				Mode: supervisor.RestartOnFailure,
+			}, w.States))
+		}
+		if opts.SyncNotify {
+			synchronizerWorker.Notifier = synchronizernode.NewNodeNotifier(dbRawUrl, dbNodeV2)
+			synchronizerWorker.Notifier.InstallTriggersOnPrepare = opts.SyncNotifyInstallTriggers
+			synchronizerWorker.NotifyFallback = opts.SyncNotifyFallback
+		}
+		synchronizerWorker.AppWorkers = syncAppWorkers(ctx, opts)
+		if opts.SyncAtomic {
+			synchronizerWorker.Atomic = synchronizernode.NewAtomicCycles(opts.SyncAtomicTarget)
+		}
+		synchronizerWorker.Control = synchronizernode.NewSyncControl()
+		admin.RegisterSync(adminEcho, synchronizernode.NewSyncAdmin(synchronizerWorker))
+		// a node database outage restarts only the synchronizer
+		w.Workers = append(w.Workers, supervisor.WithRestart(synchronizerWorker, supervisor.RestartPolicy{
+			Mode:        supervisor.RestartOnFailure,
+			MaxRestarts: opts.SyncMaxRestarts,
+		}, w.States))
+	}
 
-		synchronizerOutputExecuted := synchronizernode.NewSynchronizerOutputExecuted(
-			container.GetVoucherRepository(ctx),
-			container.GetNoticeRepository(ctx),
-			rawRepository,
-			container.GetRawOutputRefRepository(ctx),
-		)
+	health.Register(e, healthChecks...)
 
-		synchronizerInputCreate := synchronizernode.NewSynchronizerInputCreator(
-			container.GetInputRepository(ctx),
-			container.GetRawInputRepository(ctx),
-			rawRepository,
-			inputAbiDecoder,
-		)
+	if opts.AdminHttpAddress != "" {
+		w.Workers = append(w.Workers, supervisor.HttpWorker{
+			Name:    "admin",
+			Address: opts.AdminHttpAddress,
+			Handler: adminEcho,
+		})
+	}
 
-		synchronizerAppCreate := synchronizernode.NewSynchronizerAppCreator(container.GetApplicationRepository(ctx), rawRepository)
-
-		synchronizerWorker := synchronizernode.NewSynchronizerCreateWorker(
-			container.GetInputRepository(ctx),
-			container.GetRawInputRepository(ctx),
-			dbRawUrl,
-			rawRepository,
-			&synchronizerUpdate,
-			container.GetOutputDecoder(ctx),
-			synchronizerAppCreate,
-			synchronizerReport,
-			synchronizerOutputUpdate,
-			container.GetRawOutputRefRepository(ctx),
-			synchronizerOutputCreate,
-			synchronizerInputCreate,
-			synchronizerOutputExecuted,
-		)
-		w.Workers = append(w.Workers, synchronizerWorker)
+	if opts.RetentionInterval > 0 {
+		w.Workers = append(w.Workers, supervisor.WithRestart(retention, supervisor.RestartPolicy{
+			Mode: supervisor.RestartOnFailure,
+		}, w.States))
 	}
 
 	cleanSync := synchronizer.NewCleanSynchronizer(container.GetSyncRepository(ctx), nil)
-	w.Workers = append(w.Workers, cleanSync)
+	w.Workers = append(w.Workers, supervisor.WithRestart(cleanSync, supervisor.RestartPolicy{
+		Mode: supervisor.RestartOnFailure,
+	}, w.States))
 
 	slog.InfoContext(ctx, "Listening", "port", opts.HttpPort)
 	return w
 }
 
+// NewSynchronizerVerifier creates the consistency verifier, repairing with the given worker.
+func NewSynchronizerVerifier(
+	ctx context.Context,
+	container *convenience.Container,
+	worker synchronizernode.SynchronizerCreateWorker,
+) *synchronizernode.SynchronizerVerifier {
+	return synchronizernode.NewSynchronizerVerifier(
+		worker,
+		container.GetVerifyRepository(ctx),
+		synchronizernode.NewSynchronizerResync(worker, container.GetResyncRepository(ctx)),
+	)
+}
+
+// OpenNodeDb opens the node database of the options.
+// It connects lazily, the synchronizer waits for the node database in the background.
+func OpenNodeDb(opts BootstrapOpts) (string, *sqlx.DB) {
+	if opts.NodeDbUrl == "" {
+		panic("node database connection not set, use CARTESI_DATABASE_CONNECTION")
+	}
+	dbNodeV2, err := sqlx.Open("postgres", opts.NodeDbUrl)
+	if err != nil {
+		panic(err)
+	}
+	configureConnectionPool(dbNodeV2, opts.DbPool)
+	return opts.NodeDbUrl, dbNodeV2
+}
+
+// NewSynchronizerWorker wires the synchronizers of the node database to the convenience repositories.
+func NewSynchronizerWorker(
+	ctx context.Context,
+	container *convenience.Container,
+	dbRawUrl string,
+	dbNodeV2 *sqlx.DB,
+) synchronizernode.SynchronizerCreateWorker {
+	rawRepository := synchronizernode.NewRawRepository(dbRawUrl, dbNodeV2)
+	synchronizerUpdate := synchronizernode.NewSynchronizerUpdate(
+		container.GetRawInputRepository(ctx),
+		rawRepository,
+		container.GetInputRepository(ctx),
+	)
+	synchronizerReport := synchronizernode.NewSynchronizerReport(
+		container.GetReportRepository(ctx),
+		rawRepository,
+	)
+	synchronizerOutputUpdate := synchronizernode.NewSynchronizerOutputUpdate(
+		container.GetVoucherRepository(ctx),
+		container.GetNoticeRepository(ctx),
+		rawRepository,
+		container.GetRawOutputRefRepository(ctx),
+	)
+
+	abi, err := contracts.OutputsMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	abiDecoder := synchronizernode.NewAbiDecoder(abi)
+
+	inputAbi, err := contracts.InputsMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	inputAbiDecoder := synchronizernode.NewAbiDecoder(inputAbi)
+
+	synchronizerOutputCreate := synchronizernode.NewSynchronizerOutputCreate(
+		container.GetVoucherRepository(ctx),
+		container.GetNoticeRepository(ctx),
+		rawRepository,
+		container.GetRawOutputRefRepository(ctx),
+		abiDecoder,
+	)
+	synchronizerOutputCreate.DeadLetterRepository = container.GetDeadLetterRepository(ctx)
+
+	synchronizerOutputExecuted := synchronizernode.NewSynchronizerOutputExecuted(
+		container.GetVoucherRepository(ctx),
+		container.GetNoticeRepository(ctx),
+		rawRepository,
+		container.GetRawOutputRefRepository(ctx),
+	)
+
+	synchronizerInputCreate := synchronizernode.NewSynchronizerInputCreator(
+		container.GetInputRepository(ctx),
+		container.GetRawInputRepository(ctx),
+		rawRepository,
+		inputAbiDecoder,
+	)
+	synchronizerInputCreate.DeadLetterRepository = container.GetDeadLetterRepository(ctx)
+
+	synchronizerAppCreate := synchronizernode.NewSynchronizerAppCreator(container.GetApplicationRepository(ctx), rawRepository)
+
+	synchronizerWorker := synchronizernode.NewSynchronizerCreateWorker(
+		container.GetInputRepository(ctx),
+		container.GetRawInputRepository(ctx),
+		dbRawUrl,
+		rawRepository,
+		&synchronizerUpdate,
+		container.GetOutputDecoder(ctx),
+		synchronizerAppCreate,
+		synchronizerReport,
+		synchronizerOutputUpdate,
+		container.GetRawOutputRefRepository(ctx),
+		synchronizerOutputCreate,
+		synchronizerInputCreate,
+		synchronizerOutputExecuted,
+	)
+	return synchronizerWorker
+}
+
+func syncAppWorkers(ctx context.Context, opts BootstrapOpts) int {
+	if opts.SyncAppWorkers > 1 && opts.DbImplementation != "postgres" {
+		slog.WarnContext(ctx, "SQLite does not support concurrent writes, syncing one application at a time",
+			"requested", opts.SyncAppWorkers)
+		return 1
+	}
+	return max(opts.SyncAppWorkers, 0)
+}
+
 func NewAbiDecoder(abi *abi.ABI) {
 	panic("unimplemented")
 }
@@ -178,60 +429,57 @@ func CreateDBInstance(ctx context.Context, opts BootstrapOpts) *sqlx.DB {
 	var db *sqlx.DB
 	if opts.DbImplementation == "postgres" {
 		slog.InfoContext(ctx, "Using PostGres DB ...")
-		postgresHost := os.Getenv("POSTGRES_HOST")
-		postgresPort := os.Getenv("POSTGRES_PORT")
-		postgresDataBase := os.Getenv("POSTGRES_DB")
-		postgresUser := os.Getenv("POSTGRES_USER")
-		postgresPassword := os.Getenv("POSTGRES_PASSWORD")
-		connectionString := fmt.Sprintf("host=%s port=%s user=%s "+
-			"dbname=%s password=%s sslmode=disable",
-			postgresHost, postgresPort, postgresUser,
-			postgresDataBase, postgresPassword)
-		dbUrl, ok := os.LookupEnv("CARTESI_GRAPHQL_DATABASE_CONNECTION")
-		if ok {
-			connectionString = dbUrl
-		} else {
-			slog.WarnContext(ctx, "The environment variables POSTGRES_HOST, POSTGRES_PORT, POSTGRES_DB, POSTGRES_USER, and POSTGRES_PASSWORD are deprecated. Please use CARTESI_GRAPHQL_DATABASE_CONNECTION instead.")
-		}
-		db = sqlx.MustConnect("postgres", connectionString)
-		configureConnectionPool(ctx, db)
+		db = sqlx.MustConnect("postgres", opts.DbUrl)
+		configureConnectionPool(db, opts.DbPool)
 	} else {
 		db = handleSQLite(ctx, opts)
 	}
 	return db
 }
 
-// configureConnectionPool sets the connection pool settings for the database connection.
-// The following environment variables are used to configure the connection pool:
-// - DB_MAX_OPEN_CONNS: Maximum number of open connections to the database
-// - DB_MAX_IDLE_CONNS: Maximum number of idle connections in the pool
-// - DB_CONN_MAX_LIFETIME: Maximum amount of time a connection may be reused
-// - DB_CONN_MAX_IDLE_TIME: Maximum amount of time a connection may be idle
-func configureConnectionPool(ctx context.Context, db *sqlx.DB) {
-	defaultConnMaxLifetime := int(DefaultConnMaxLifetime.Seconds())
-	defaultConnMaxIdleTime := int(DefaultConnMaxIdleTime.Seconds())
-
-	maxOpenConns := getEnvInt(ctx, "DB_MAX_OPEN_CONNS", DefaultMaxOpenConnections)
-	maxIdleConns := getEnvInt(ctx, "DB_MAX_IDLE_CONNS", DefaultMaxIdleConnections)
-	connMaxLifetime := getEnvInt(ctx, "DB_CONN_MAX_LIFETIME", defaultConnMaxLifetime)
-	connMaxIdleTime := getEnvInt(ctx, "DB_CONN_MAX_IDLE_TIME", defaultConnMaxIdleTime)
-	db.SetMaxOpenConns(maxOpenConns)
-	db.SetMaxIdleConns(maxIdleConns)
-	db.SetConnMaxLifetime(time.Duration(connMaxLifetime) * time.Second)
-	db.SetConnMaxIdleTime(time.Duration(connMaxIdleTime) * time.Second)
+// CreateReadDBInstance opens the read replica of the GraphQL database.
+// It connects lazily, the reads fall back to the primary while the replica is unreachable
+// when the lag guard is enabled.
+func CreateReadDBInstance(opts BootstrapOpts) *sqlx.DB {
+	readDb, err := sqlx.Open("postgres", opts.DbReadUrl)
+	if err != nil {
+		panic(err)
+	}
+	configureConnectionPool(readDb, opts.DbPool)
+	return readDb
 }
 
-func getEnvInt(ctx context.Context, envName string, defaultValue int) int {
-	value, exists := os.LookupEnv(envName)
-	if !exists {
-		return defaultValue
+// MigrateSchema checks the schema version of the database at startup.
+// The pending migrations are applied when migrate is set, otherwise they must be applied
+// with the migrate command first.
+func MigrateSchema(ctx context.Context, db *sqlx.DB, migrate bool) error {
+	schema, err := migrations.New(ctx, db)
+	if err != nil {
+		return err
 	}
-	intValue, err := cast.ToIntE(value)
+	defer schema.Close()
+	status, err := schema.Check()
 	if err != nil {
-		slog.ErrorContext(ctx, "configuration error", "envName", envName, "value", value)
-		panic(err)
+		return err
+	}
+	if !status.Pending() {
+		slog.InfoContext(ctx, "Database schema is up to date", "version", status.Version)
+		return nil
 	}
-	return intValue
+	if !migrate {
+		return fmt.Errorf("%w: version %d is behind version %d, run the migrate up command",
+			migrations.ErrPending, status.Version, status.Latest)
+	}
+	slog.InfoContext(ctx, "Migrating the database schema", "from", status.Version, "to", status.Latest)
+	return schema.Up()
+}
+
+// configureConnectionPool sets the connection pool settings for the database connection.
+func configureConnectionPool(db *sqlx.DB, pool DbPoolOpts) {
+	db.SetMaxOpenConns(pool.MaxOpenConns)
+	db.SetMaxIdleConns(pool.MaxIdleConns)
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
 }
 
 func handleSQLite(ctx context.Context, opts BootstrapOpts) *sqlx.DB {
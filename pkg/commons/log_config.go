@@ -16,6 +16,9 @@ type contextKey int
 // workerKey is the key for logger values in contexts.
 const workerKey contextKey = iota
 
+// LogLevel is the level of the production logs, which the admin API changes at runtime.
+var LogLevel = new(slog.LevelVar)
+
 type LoggerWithContext struct {
 	slog.Handler
 }
@@ -52,14 +55,19 @@ func removeTimestampFromLog(groups []string, a slog.Attr) slog.Attr {
 }
 
 func ConfigureLogForProduction(level slog.Leveler, hasColor bool) {
+	ConfigureLogForProductionTo(os.Stdout, level, hasColor)
+}
+
+// ConfigureLogForProductionTo logs to the given file, e.g. stderr when stdout carries data.
+func ConfigureLogForProductionTo(out *os.File, level slog.Leveler, hasColor bool) {
 	logOpts := &tint.Options{
 		Level:       level,
-		AddSource:   level == slog.LevelDebug,
-		NoColor:     !hasColor || !isatty.IsTerminal(os.Stdout.Fd()),
+		AddSource:   level.Level() == slog.LevelDebug,
+		NoColor:     !hasColor || !isatty.IsTerminal(out.Fd()),
 		ReplaceAttr: removeTimestampFromLog,
 	}
 
-	handler := tint.NewHandler(os.Stdout, logOpts)
+	handler := tint.NewHandler(out, logOpts)
 	wrappedHandler := &LoggerWithContext{Handler: handler}
 
 	logger := slog.New(wrappedHandler)
@@ -0,0 +1,505 @@
+// Package config loads the typed configuration of the server from a YAML file,
+// the environment variables and the command line flags.
+//
+// Each source overrides the previous one: defaults, file, environment, flags.
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/auth"
+	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/reader"
+	"github.com/cartesi/rollups-graphql/v2/pkg/supervisor"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfigFile selects the configuration file when the --config flag is not set.
+const EnvConfigFile = "CONFIG_FILE"
+
+// Fields are bound to their sources by the tags:
+//   - yaml: key in the configuration file
+//   - env: environment variable
+//   - flag: command line flag, when the command defines it
+//   - secret: redacted when printed
+type Config struct {
+	ApplicationAddress string        `yaml:"application_address" env:"APPLICATION_ADDRESS" flag:"contracts-application-address"`
+	TimeoutWorker      time.Duration `yaml:"timeout_worker" env:"TIMEOUT_WORKER" flag:"timeout-worker"`
+	MigrateOnStart     bool          `yaml:"migrate_on_start" env:"MIGRATE_ON_START"`
+	Log                Log           `yaml:"log"`
+	HTTP               HTTP          `yaml:"http"`
+	Database           Database      `yaml:"database"`
+	Sync               Sync          `yaml:"sync"`
+	Verify             Verify        `yaml:"verify"`
+	Retention          Retention     `yaml:"retention"`
+	GraphQL            GraphQL       `yaml:"graphql"`
+	Auth               Auth          `yaml:"auth"`
+	Tracing            Tracing       `yaml:"tracing"`
+}
+
+type Log struct {
+	Debug bool `yaml:"debug" env:"GRAPHQL_DEBUG" flag:"enable-debug"`
+	Color bool `yaml:"color" env:"COLOR" flag:"enable-color"`
+}
+
+type HTTP struct {
+	Address string `yaml:"address" env:"HTTP_ADDRESS" flag:"http-address"`
+	Port    int    `yaml:"port" env:"HTTP_PORT" flag:"http-port"`
+	// Listener of the admin API, a host:port or a unix socket
+	AdminAddress string `yaml:"admin_address" env:"ADMIN_HTTP_ADDRESS"`
+	GrpcAddress  string `yaml:"grpc_address" env:"GRPC_ADDRESS"`
+}
+
+type Database struct {
+	Implementation string        `yaml:"implementation" env:"DB_IMPLEMENTATION" flag:"db-implementation"`
+	SqliteFile     string        `yaml:"sqlite_file" env:"SQLITE_FILE" flag:"sqlite-file"`
+	URL            string        `yaml:"url" env:"CARTESI_GRAPHQL_DATABASE_CONNECTION" secret:"true"`
+	NodeURL        string        `yaml:"node_url" env:"CARTESI_DATABASE_CONNECTION" secret:"true"`
+	ReadURL        string        `yaml:"read_url" env:"CARTESI_GRAPHQL_DATABASE_READ_CONNECTION" secret:"true"`
+	ReadMaxLag     time.Duration `yaml:"read_max_lag" env:"DB_READ_MAX_LAG"`
+	// Deprecated: replaced by URL
+	Postgres               Postgres `yaml:"postgres"`
+	MaxOpenConns           int      `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns           int      `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetimeSeconds int      `yaml:"conn_max_lifetime_seconds" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTimeSeconds int      `yaml:"conn_max_idle_time_seconds" env:"DB_CONN_MAX_IDLE_TIME"`
+}
+
+type Postgres struct {
+	Host     string `yaml:"host" env:"POSTGRES_HOST"`
+	Port     string `yaml:"port" env:"POSTGRES_PORT"`
+	DB       string `yaml:"db" env:"POSTGRES_DB"`
+	User     string `yaml:"user" env:"POSTGRES_USER"`
+	Password string `yaml:"password" env:"POSTGRES_PASSWORD" secret:"true"`
+}
+
+type Sync struct {
+	Disable               bool          `yaml:"disable" env:"DISABLE_SYNC" flag:"disable-sync"`
+	Notify                bool          `yaml:"notify" env:"SYNC_NOTIFY"`
+	NotifyInstallTriggers bool          `yaml:"notify_install_triggers" env:"SYNC_NOTIFY_INSTALL_TRIGGERS"`
+	NotifyFallback        time.Duration `yaml:"notify_fallback" env:"SYNC_NOTIFY_FALLBACK"`
+	AppWorkers            int           `yaml:"app_workers" env:"SYNC_APP_WORKERS"`
+	MaxRestarts           int           `yaml:"max_restarts" env:"SYNC_MAX_RESTARTS"`
+	Atomic                bool          `yaml:"atomic" env:"SYNC_ATOMIC"`
+	AtomicTarget          time.Duration `yaml:"atomic_target" env:"SYNC_ATOMIC_TARGET"`
+}
+
+type Verify struct {
+	Interval time.Duration `yaml:"interval" env:"VERIFY_INTERVAL"`
+	Repair   bool          `yaml:"repair" env:"VERIFY_REPAIR"`
+}
+
+type Retention struct {
+	Interval                    time.Duration `yaml:"interval" env:"RETENTION_INTERVAL"`
+	ReportsDays                 int           `yaml:"reports_days" env:"RETENTION_REPORTS_DAYS"`
+	ExecutedVoucherPayloadsDays int           `yaml:"executed_voucher_payloads_days" env:"RETENTION_EXECUTED_VOUCHER_PAYLOADS_DAYS"`
+	DisabledAppInputs           bool          `yaml:"disabled_app_inputs" env:"RETENTION_DISABLED_APP_INPUTS"`
+	Apps                        RetentionApps `yaml:"apps" env:"RETENTION_APPS"`
+}
+
+// RetentionApps maps application addresses to the rules replacing the default one.
+// The environment variable holds it as a JSON object.
+type RetentionApps map[string]model.RetentionRule
+
+func (a *RetentionApps) UnmarshalText(text []byte) error {
+	apps, err := model.ParseRetentionApps(string(text))
+	if err != nil {
+		return err
+	}
+	*a = apps
+	return nil
+}
+
+type GraphQL struct {
+	MaxDepth          int           `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH"`
+	MaxComplexity     int           `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY"`
+	MaxPageSize       int           `yaml:"max_page_size" env:"GRAPHQL_MAX_PAGE_SIZE"`
+	APQCacheSize      int           `yaml:"apq_cache_size" env:"GRAPHQL_APQ_CACHE_SIZE"`
+	AllowlistFile     string        `yaml:"allowlist_file" env:"GRAPHQL_ALLOWLIST_FILE"`
+	AllowlistOpenAPIs bool          `yaml:"allowlist_open_apis" env:"GRAPHQL_ALLOWLIST_OPEN_APIS"`
+	CacheSize         int           `yaml:"cache_size" env:"GRAPHQL_CACHE_SIZE"`
+	CacheTTL          time.Duration `yaml:"cache_ttl" env:"GRAPHQL_CACHE_TTL"`
+}
+
+type Auth struct {
+	KeysFile       string  `yaml:"keys_file" env:"API_KEYS_FILE"`
+	RateLimit      float64 `yaml:"rate_limit" env:"RATE_LIMIT"`
+	RateLimitBurst int     `yaml:"rate_limit_burst" env:"RATE_LIMIT_BURST"`
+	TrustedProxies List    `yaml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+}
+
+// List is a list in the configuration file and a comma separated value in the environment.
+type List []string
+
+func (l *List) UnmarshalText(text []byte) error {
+	*l = nil
+	for _, item := range strings.Split(string(text), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+type Tracing struct {
+	// otlp, file or empty to disable the tracing
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER"`
+	File        string  `yaml:"file" env:"TRACING_FILE"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// Default returns the configuration without any file, environment variable or flag.
+func Default() Config {
+	opts := bootstrap.NewBootstrapOpts()
+	return Config{
+		ApplicationAddress: opts.ApplicationAddress,
+		TimeoutWorker:      opts.TimeoutWorker,
+		MigrateOnStart:     opts.MigrateOnStart,
+		Log:                Log{Color: true},
+		HTTP: HTTP{
+			Address: opts.HttpAddress,
+			Port:    opts.HttpPort,
+		},
+		Database: Database{
+			Implementation:         opts.DbImplementation,
+			SqliteFile:             opts.SqliteFile,
+			MaxOpenConns:           opts.DbPool.MaxOpenConns,
+			MaxIdleConns:           opts.DbPool.MaxIdleConns,
+			ConnMaxLifetimeSeconds: int(opts.DbPool.ConnMaxLifetime.Seconds()),
+			ConnMaxIdleTimeSeconds: int(opts.DbPool.ConnMaxIdleTime.Seconds()),
+		},
+		Sync: Sync{
+			NotifyFallback: opts.SyncNotifyFallback,
+			AtomicTarget:   opts.SyncAtomicTarget,
+		},
+		GraphQL: GraphQL{
+			MaxDepth:      opts.GraphQL.Limits.MaxDepth,
+			MaxComplexity: opts.GraphQL.Limits.MaxComplexity,
+			MaxPageSize:   opts.GraphQL.Limits.MaxPageSize,
+			APQCacheSize:  opts.GraphQL.PersistedQueryCacheSize,
+			CacheTTL:      opts.GraphQLCacheTTL,
+		},
+		Tracing: Tracing{
+			Exporter:    opts.Tracing.Exporter,
+			File:        opts.Tracing.File,
+			SampleRatio: opts.Tracing.SampleRatio,
+		},
+	}
+}
+
+// Load reads the file, when the path is not empty, the environment and the changed flags
+// over the defaults and validates the result.
+func Load(path string, lookupEnv func(string) (string, bool), flags *pflag.FlagSet) (Config, error) {
+	cfg := Default()
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return cfg, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	var errs []error
+	for _, f := range cfg.fields() {
+		// empty variables are ignored, as if they were not set
+		if raw, ok := lookupEnv(f.env); ok && raw != "" {
+			if err := setValue(f.value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s: %w", f.env, err))
+			}
+		}
+		if flags == nil || f.flag == "" {
+			continue
+		}
+		if flag := flags.Lookup(f.flag); flag != nil && flag.Changed {
+			if err := setValue(f.value, flag.Value.String()); err != nil {
+				errs = append(errs, fmt.Errorf("invalid --%s: %w", f.flag, err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+func (c *Config) readFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	err = decoder.Decode(c)
+	if errors.Is(err, io.EOF) {
+		// an empty file keeps the defaults
+		return nil
+	}
+	return err
+}
+
+// Validate checks all fields and returns every error found.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(failed bool, format string, args ...any) {
+		if failed {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	for _, f := range c.fields() {
+		check(isNegative(f.value), "%s cannot be negative", f.key)
+	}
+	check(!common.IsHexAddress(c.ApplicationAddress),
+		"application_address is not a valid address: %q", c.ApplicationAddress)
+	check(c.HTTP.Address == "", "http.address is required")
+	check(c.HTTP.Port < 1 || c.HTTP.Port > 65535, "http.port out of range: %d", c.HTTP.Port)
+	if c.HTTP.AdminAddress != "" && !strings.HasPrefix(c.HTTP.AdminAddress, supervisor.UnixAddressPrefix) {
+		errs = append(errs, validateHostPort("http.admin_address", c.HTTP.AdminAddress))
+	}
+	if c.HTTP.GrpcAddress != "" {
+		errs = append(errs, validateHostPort("http.grpc_address", c.HTTP.GrpcAddress))
+		check(c.GraphQL.AllowlistFile != "" && !c.GraphQL.AllowlistOpenAPIs,
+			"http.grpc_address is not covered by graphql.allowlist_file, set graphql.allowlist_open_apis to serve it")
+	}
+	check(c.Database.Implementation != "postgres" && c.Database.Implementation != "sqlite",
+		"database.implementation must be postgres or sqlite: %q", c.Database.Implementation)
+	errs = append(errs,
+		validateConnection("database.url", c.Database.URL),
+		validateConnection("database.node_url", c.Database.NodeURL),
+		validateConnection("database.read_url", c.Database.ReadURL),
+	)
+	check(c.Database.ReadURL != "" && c.Database.Implementation != "postgres",
+		"database.read_url requires the postgres implementation")
+	check(c.Database.ReadURL != "" && c.Database.ReadMaxLag <= 0 && c.GraphQL.CacheSize > 0,
+		"graphql.cache_size requires database.read_max_lag with database.read_url")
+	switch c.Tracing.Exporter {
+	case "", tracing.ExporterOTLP:
+	case tracing.ExporterFile:
+		check(c.Tracing.File == "", "tracing.file is required by the file exporter")
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter must be otlp or file: %q", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio > 1, "tracing.sample_ratio cannot be greater than 1")
+	for app, rule := range c.Retention.Apps {
+		check(!common.IsHexAddress(app), "retention.apps has an invalid address: %s", app)
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("retention.apps %s: %w", app, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
+
+func validateHostPort(key string, address string) error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("%s is not a host:port address: %w", key, err)
+	}
+	return nil
+}
+
+// validateConnection accepts the URLs and the key=value connection strings of PostgreSQL.
+func validateConnection(key string, connection string) error {
+	if connection == "" {
+		return nil
+	}
+	if strings.Contains(connection, "://") {
+		if _, err := pq.ParseURL(connection); err != nil {
+			return fmt.Errorf("%s is not a valid connection URL: %w", key, err)
+		}
+		return nil
+	}
+	if !strings.Contains(connection, "=") {
+		return fmt.Errorf("%s is not a connection URL or a key=value connection string", key)
+	}
+	return nil
+}
+
+// BootstrapOpts converts the configuration, loading the allowlist and the API keys files.
+func (c Config) BootstrapOpts() (bootstrap.BootstrapOpts, error) {
+	opts := bootstrap.NewBootstrapOpts()
+	opts.ApplicationAddress = c.ApplicationAddress
+	opts.TimeoutWorker = c.TimeoutWorker
+	opts.MigrateOnStart = c.MigrateOnStart
+	opts.HttpAddress = c.HTTP.Address
+	opts.HttpPort = c.HTTP.Port
+	opts.AdminHttpAddress = c.HTTP.AdminAddress
+	opts.GrpcAddress = c.HTTP.GrpcAddress
+	opts.DbImplementation = c.Database.Implementation
+	opts.SqliteFile = c.Database.SqliteFile
+	opts.DbUrl = c.Database.URL
+	if opts.DbUrl == "" && opts.DbImplementation == "postgres" {
+		opts.DbUrl = c.Database.Postgres.connectionString()
+	}
+	opts.NodeDbUrl = c.Database.NodeURL
+	opts.DbReadUrl = c.Database.ReadURL
+	opts.DbReadMaxLag = c.Database.ReadMaxLag
+	opts.DbPool = bootstrap.DbPoolOpts{
+		MaxOpenConns:    c.Database.MaxOpenConns,
+		MaxIdleConns:    c.Database.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(c.Database.ConnMaxLifetimeSeconds) * time.Second,
+		ConnMaxIdleTime: time.Duration(c.Database.ConnMaxIdleTimeSeconds) * time.Second,
+	}
+	opts.DisableSync = c.Sync.Disable
+	opts.SyncNotify = c.Sync.Notify
+	opts.SyncNotifyInstallTriggers = c.Sync.NotifyInstallTriggers
+	opts.SyncNotifyFallback = c.Sync.NotifyFallback
+	opts.SyncAppWorkers = c.Sync.AppWorkers
+	opts.SyncMaxRestarts = c.Sync.MaxRestarts
+	opts.SyncAtomic = c.Sync.Atomic
+	opts.SyncAtomicTarget = c.Sync.AtomicTarget
+	opts.VerifyInterval = c.Verify.Interval
+	opts.VerifyRepair = c.Verify.Repair
+	opts.RetentionInterval = c.Retention.Interval
+	opts.RetentionPolicy = model.RetentionPolicy{
+		Default: model.RetentionRule{
+			ReportsDays:                 c.Retention.ReportsDays,
+			ExecutedVoucherPayloadsDays: c.Retention.ExecutedVoucherPayloadsDays,
+			DisabledAppInputs:           c.Retention.DisabledAppInputs,
+		},
+		Apps: make(map[string]model.RetentionRule, len(c.Retention.Apps)),
+	}
+	for app, rule := range c.Retention.Apps {
+		opts.RetentionPolicy.Apps[common.HexToAddress(app).Hex()] = rule
+	}
+	opts.GraphQL.Limits.MaxDepth = c.GraphQL.MaxDepth
+	opts.GraphQL.Limits.MaxComplexity = c.GraphQL.MaxComplexity
+	opts.GraphQL.Limits.MaxPageSize = c.GraphQL.MaxPageSize
+	opts.GraphQL.PersistedQueryCacheSize = c.GraphQL.APQCacheSize
+	if c.GraphQL.AllowlistFile != "" {
+		allowlist, err := reader.LoadQueryAllowlist(c.GraphQL.AllowlistFile)
+		if err != nil {
+			return opts, fmt.Errorf("invalid graphql.allowlist_file: %w", err)
+		}
+		opts.GraphQL.Allowlist = allowlist
+	}
+	opts.GraphQL.AllowlistOpenAPIs = c.GraphQL.AllowlistOpenAPIs
+	opts.GraphQLCacheSize = c.GraphQL.CacheSize
+	opts.GraphQLCacheTTL = c.GraphQL.CacheTTL
+	if c.Auth.KeysFile != "" || c.Auth.RateLimit > 0 {
+		authenticator, err := auth.NewAuthenticator(auth.Opts{
+			KeysFile:       c.Auth.KeysFile,
+			RateLimit:      c.Auth.RateLimit,
+			RateLimitBurst: c.Auth.RateLimitBurst,
+			TrustedProxies: c.Auth.TrustedProxies,
+		})
+		if err != nil {
+			return opts, fmt.Errorf("invalid auth: %w", err)
+		}
+		opts.Auth = authenticator
+	}
+	opts.Tracing = tracing.Opts{
+		Exporter:    c.Tracing.Exporter,
+		File:        c.Tracing.File,
+		SampleRatio: c.Tracing.SampleRatio,
+	}
+	return opts, nil
+}
+
+func (p Postgres) connectionString() string {
+	return fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
+		p.Host, p.Port, p.User, p.DB, p.Password)
+}
+
+// UsesDeprecatedPostgres reports whether the GraphQL database is set by the deprecated postgres fields.
+func (c Config) UsesDeprecatedPostgres() bool {
+	return c.Database.Implementation == "postgres" && c.Database.URL == ""
+}
+
+// field is a leaf of the configuration with its tags.
+type field struct {
+	// dotted path of the yaml keys
+	key    string
+	env    string
+	flag   string
+	secret bool
+	value  reflect.Value
+}
+
+func (c *Config) fields() []field {
+	return collectFields(reflect.ValueOf(c).Elem(), "")
+}
+
+func collectFields(v reflect.Value, prefix string) []field {
+	var fields []field
+	for i := range v.NumField() {
+		structField := v.Type().Field(i)
+		key := prefix + structField.Tag.Get("yaml")
+		if structField.Type.Kind() == reflect.Struct && structField.Type != durationType {
+			fields = append(fields, collectFields(v.Field(i), key+".")...)
+			continue
+		}
+		if structField.Tag.Get("env") == "" {
+			panic(fmt.Sprintf("config: field %s has no environment variable", key))
+		}
+		fields = append(fields, field{
+			key:    key,
+			env:    structField.Tag.Get("env"),
+			flag:   structField.Tag.Get("flag"),
+			secret: structField.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		})
+	}
+	return fields
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// setValue parses the raw value of a variable or flag into the field.
+func setValue(v reflect.Value, raw string) error {
+	if unmarshaler, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw))
+	}
+	if v.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(duration))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(value)
+	case reflect.Int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(value))
+	case reflect.Float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(value)
+	default:
+		panic(fmt.Sprintf("config: unsupported field type %s", v.Type()))
+	}
+	return nil
+}
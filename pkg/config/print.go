@@ -0,0 +1,59 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces the secrets that are set when printing the configuration.
+const Redacted = "<redacted>"
+
+// Print writes the configuration as a YAML file, commenting each field with its
+// environment variable. The secrets that are set are redacted.
+func (c Config) Print(w io.Writer) error {
+	node, err := structNode(reflect.ValueOf(c))
+	if err != nil {
+		return err
+	}
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+func structNode(v reflect.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for i := range v.NumField() {
+		structField := v.Type().Field(i)
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: structField.Tag.Get("yaml")}
+		var value *yaml.Node
+		var err error
+		switch {
+		case structField.Type.Kind() == reflect.Struct:
+			value, err = structNode(v.Field(i))
+		case structField.Tag.Get("secret") == "true" && !v.Field(i).IsZero():
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: Redacted}
+		case structField.Type == durationType:
+			value = &yaml.Node{Kind: yaml.ScalarNode, Value: time.Duration(v.Field(i).Int()).String()}
+		default:
+			value = &yaml.Node{}
+			err = value.Encode(v.Field(i).Interface())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key.Value, err)
+		}
+		if value.Kind == yaml.ScalarNode || len(value.Content) == 0 {
+			value.LineComment = structField.Tag.Get("env")
+		} else {
+			key.LineComment = structField.Tag.Get("env")
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
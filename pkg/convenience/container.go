@@ -26,6 +26,11 @@ type Container struct {
 	rawInputRefRepository  *repository.RawInputRefRepository
 	rawOutputRefRepository *repository.RawOutputRefRepository
 	appRepository          *repository.ApplicationRepository
+	deadLetterRepository   *repository.DeadLetterRepository
+	resyncRepository       *repository.ResyncRepository
+	verifyRepository       *repository.VerifyRepository
+	retentionRepository    *repository.RetentionRepository
+	exportRepository       *repository.ExportRepository
 }
 
 func NewContainer(db *sqlx.DB, autoCount bool) *Container {
@@ -144,6 +149,60 @@ func (c *Container) GetRawOutputRefRepository(ctx context.Context) *repository.R
 	return c.rawOutputRefRepository
 }
 
+func (c *Container) GetDeadLetterRepository(ctx context.Context) *repository.DeadLetterRepository {
+	if c.deadLetterRepository != nil {
+		return c.deadLetterRepository
+	}
+	c.deadLetterRepository = &repository.DeadLetterRepository{
+		Db: c.db,
+	}
+	err := c.deadLetterRepository.CreateTables(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return c.deadLetterRepository
+}
+
+func (c *Container) GetResyncRepository(ctx context.Context) *repository.ResyncRepository {
+	if c.resyncRepository != nil {
+		return c.resyncRepository
+	}
+	c.resyncRepository = &repository.ResyncRepository{
+		Db: c.db,
+	}
+	return c.resyncRepository
+}
+
+func (c *Container) GetVerifyRepository(ctx context.Context) *repository.VerifyRepository {
+	if c.verifyRepository != nil {
+		return c.verifyRepository
+	}
+	c.verifyRepository = &repository.VerifyRepository{
+		Db: c.db,
+	}
+	return c.verifyRepository
+}
+
+func (c *Container) GetRetentionRepository(ctx context.Context) *repository.RetentionRepository {
+	if c.retentionRepository != nil {
+		return c.retentionRepository
+	}
+	c.retentionRepository = &repository.RetentionRepository{
+		Db: c.db,
+	}
+	return c.retentionRepository
+}
+
+func (c *Container) GetExportRepository(ctx context.Context) *repository.ExportRepository {
+	if c.exportRepository != nil {
+		return c.exportRepository
+	}
+	c.exportRepository = &repository.ExportRepository{
+		Db: c.db,
+	}
+	return c.exportRepository
+}
+
 func (c *Container) GetInputRepository(ctx context.Context) *repository.InputRepository {
 	if c.inputRepository != nil {
 		return c.inputRepository
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/contracts"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
@@ -42,6 +43,7 @@ func (s *OutputDecoderSuite) TearDownTest() {
 func (s *OutputDecoderSuite) SetupTest() {
 	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
 	s.db = sqlx.MustConnect("sqlite3", ":memory:")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	outputRepository := repository.OutputRepository{
 		Db: s.db,
 	}
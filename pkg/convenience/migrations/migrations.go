@@ -0,0 +1,222 @@
+// Package migrations versions the schema of the convenience database.
+// The SQL files are embedded per dialect and applied with golang-migrate.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sync"
+
+	mig "github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var content embed.FS
+
+// Table that records the applied version, apart from the one used by the node
+const MigrationsTable = "convenience_schema_migrations"
+
+var ErrDirty = errors.New("database schema is dirty, a migration failed halfway")
+var ErrPending = errors.New("database schema has pending migrations")
+
+type Status struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	Latest  uint `json:"latest"`
+}
+
+// Pending reports whether there are migrations to apply.
+func (s Status) Pending() bool {
+	return s.Version < s.Latest
+}
+
+type Schema struct {
+	migrate *mig.Migrate
+	latest  uint
+}
+
+// New returns the schema of the database, using the migrations of its driver.
+func New(ctx context.Context, db *sqlx.DB) (*Schema, error) {
+	dir, err := dialect(db)
+	if err != nil {
+		return nil, err
+	}
+	src, err := iofs.New(content, dir)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := latestVersion(src)
+	if err != nil {
+		return nil, err
+	}
+	var driver database.Driver
+	if dir == "sqlite" {
+		driver, err = newSqliteDriver(db.DB, MigrationsTable)
+	} else {
+		driver, err = newPostgresDriver(ctx, db.DB)
+	}
+	if err != nil {
+		return nil, err
+	}
+	migrate, err := mig.NewWithInstance("iofs", src, dir, driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{migrate: migrate, latest: latest}, nil
+}
+
+func dialect(db *sqlx.DB) (string, error) {
+	switch db.DriverName() {
+	case "postgres", "pgx":
+		return "postgres", nil
+	case "sqlite3", "sqlite":
+		return "sqlite", nil
+	default:
+		return "", fmt.Errorf("unsupported database driver %s", db.DriverName())
+	}
+}
+
+// newPostgresDriver keeps a dedicated connection, so closing the schema does not close the pool.
+func newPostgresDriver(ctx context.Context, db *sql.DB) (database.Driver, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{
+		MigrationsTable: MigrationsTable,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return driver, nil
+}
+
+func latestVersion(src source.Driver) (uint, error) {
+	version, err := src.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
+// Up applies all pending migrations.
+func (s *Schema) Up() error {
+	err := s.migrate.Up()
+	if err != nil && !errors.Is(err, mig.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down reverts the given number of migrations, stopping at the first one.
+func (s *Schema) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("invalid number of steps %d", steps)
+	}
+	err := s.migrate.Steps(-steps)
+	var shortLimit mig.ErrShortLimit
+	if err != nil && !errors.Is(err, mig.ErrNoChange) && !errors.As(err, &shortLimit) {
+		return err
+	}
+	return nil
+}
+
+func (s *Schema) Status() (Status, error) {
+	version, dirty, err := s.migrate.Version()
+	if err != nil && !errors.Is(err, mig.ErrNilVersion) {
+		return Status{}, err
+	}
+	return Status{Version: version, Dirty: dirty, Latest: s.latest}, nil
+}
+
+// Check refuses a schema this binary cannot use: a dirty one or one newer than its migrations.
+// A schema behind the latest version is fine when the pending migrations are applied afterwards.
+func (s *Schema) Check() (Status, error) {
+	status, err := s.Status()
+	if err != nil {
+		return status, err
+	}
+	if status.Dirty {
+		return status, fmt.Errorf("%w at version %d, fix it and force the version", ErrDirty, status.Version)
+	}
+	if status.Version > status.Latest {
+		return status, fmt.Errorf(
+			"database schema version %d is newer than the latest known version %d",
+			status.Version, status.Latest,
+		)
+	}
+	return status, nil
+}
+
+func (s *Schema) Close() error {
+	srcErr, dbErr := s.migrate.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+var checked = struct {
+	sync.Mutex
+	dbs map[*sql.DB]bool
+}{dbs: map[*sql.DB]bool{}}
+
+// CheckSchema refuses a database whose schema is not at the latest version, once per connection pool.
+// The repositories call it when creating their tables, the migrations are only applied
+// by the migrate command or at startup.
+func CheckSchema(ctx context.Context, db *sqlx.DB) error {
+	checked.Lock()
+	defer checked.Unlock()
+	if checked.dbs[db.DB] {
+		return nil
+	}
+	schema, err := New(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer schema.Close()
+	status, err := schema.Check()
+	if err != nil {
+		return err
+	}
+	if status.Pending() {
+		return fmt.Errorf("%w: version %d is behind version %d, run the migrate up command",
+			ErrPending, status.Version, status.Latest)
+	}
+	checked.dbs[db.DB] = true
+	return nil
+}
+
+// Migrate applies the pending migrations of the database.
+func Migrate(ctx context.Context, db *sqlx.DB) error {
+	schema, err := New(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer schema.Close()
+	status, err := schema.Check()
+	if err != nil {
+		return err
+	}
+	if status.Pending() {
+		slog.InfoContext(ctx, "Migrating the database schema", "from", status.Version, "to", status.Latest)
+	}
+	return schema.Up()
+}
@@ -0,0 +1,185 @@
+package migrations
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+type MigrationsSuite struct {
+	suite.Suite
+	tempDir string
+	db      *sqlx.DB
+	ctx     context.Context
+}
+
+func (s *MigrationsSuite) SetupTest() {
+	s.ctx = context.Background()
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "migrations.sqlite3"))
+}
+
+func (s *MigrationsSuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestMigrationsSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsSuite))
+}
+
+func (s *MigrationsSuite) newSchema() *Schema {
+	schema, err := New(s.ctx, s.db)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { schema.Close() })
+	return schema
+}
+
+func (s *MigrationsSuite) tableExists(name string) bool {
+	var count int
+	err := s.db.Get(&count, `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = $1`, name)
+	s.Require().NoError(err)
+	return count == 1
+}
+
+func (s *MigrationsSuite) TestUpAndDown() {
+	schema := s.newSchema()
+	status, err := schema.Status()
+	s.Require().NoError(err)
+	s.Equal(uint(0), status.Version)
+	s.True(status.Pending())
+
+	s.Require().NoError(schema.Up())
+	status, err = schema.Status()
+	s.Require().NoError(err)
+	s.Equal(status.Latest, status.Version)
+	s.False(status.Dirty)
+	s.True(s.tableExists("convenience_inputs"))
+	s.True(s.tableExists("convenience_dead_letters"))
+
+	// idempotent
+	s.Require().NoError(schema.Up())
+
+	s.Require().NoError(schema.Down(1))
+	status, err = schema.Status()
+	s.Require().NoError(err)
+	s.Equal(status.Latest-1, status.Version)
+
+	s.Require().NoError(schema.Down(int(status.Version)))
+	status, err = schema.Status()
+	s.Require().NoError(err)
+	s.Equal(uint(0), status.Version)
+	s.False(s.tableExists("convenience_inputs"))
+}
+
+func (s *MigrationsSuite) columnExists(table string, column string) bool {
+	var count int
+	err := s.db.Get(&count, `SELECT count(*) FROM pragma_table_info($1) WHERE name = $2`, table, column)
+	s.Require().NoError(err)
+	return count == 1
+}
+
+// preMigrationSchema has the tables as created by the repositories before the migrations,
+// without the columns added to their CREATE TABLE IF NOT EXISTS afterwards.
+var preMigrationSchema = []string{
+	`CREATE TABLE convenience_application (id integer NOT NULL, name text NOT NULL, app_contract text NOT NULL)`,
+	`INSERT INTO convenience_application (id, name, app_contract) VALUES (1, 'app', '0x01')`,
+	`CREATE TABLE convenience_inputs (id text NOT NULL, input_index integer, app_contract text, status text,
+		msg_sender text, payload text, block_number integer, block_timestamp NUMERIC, prev_randao text,
+		exception text, espresso_block_number integer, espresso_block_timestamp NUMERIC, input_box_index integer,
+		avail_block_number integer, avail_block_timestamp NUMERIC, type text, cartesi_transaction_id text,
+		chain_id text)`,
+	`INSERT INTO convenience_inputs (id, input_index, app_contract, status, payload) VALUES ('1', 0, '0x01', 'ACCEPTED', '0x')`,
+	`CREATE TABLE convenience_vouchers (destination text, payload text, executed BOOLEAN, input_index integer,
+		output_index integer, value text, output_hashes_siblings text, app_contract text,
+		PRIMARY KEY (input_index, output_index, app_contract))`,
+	`INSERT INTO convenience_vouchers (input_index, output_index, app_contract, payload) VALUES (0, 0, '0x01', '0x')`,
+	`CREATE TABLE convenience_notices (payload text, input_index integer, output_index integer, app_contract text,
+		output_hashes_siblings text, PRIMARY KEY (input_index, output_index, app_contract))`,
+	`INSERT INTO convenience_notices (input_index, output_index, app_contract, payload) VALUES (0, 1, '0x01', '0x')`,
+	`CREATE TABLE convenience_reports (output_index integer, payload text, input_index integer, app_contract text,
+		PRIMARY KEY (input_index, output_index, app_contract))`,
+	`INSERT INTO convenience_reports (input_index, output_index, app_contract, payload) VALUES (0, 0, '0x01', '0x')`,
+	`CREATE TABLE convenience_input_raw_references (id text NOT NULL, app_id integer NOT NULL,
+		input_index integer NOT NULL, app_contract text NOT NULL, status text, chain_id text,
+		created_at TIMESTAMP NOT NULL)`,
+	`INSERT INTO convenience_input_raw_references (id, app_id, input_index, app_contract, created_at)
+		VALUES ('1', 1, 0, '0x01', '2025-01-01 00:00:00')`,
+	`CREATE TABLE convenience_output_raw_references (app_id integer NOT NULL, input_index integer NOT NULL,
+		app_contract text NOT NULL, output_index integer NOT NULL, has_proof BOOLEAN,
+		type text NOT NULL CHECK (type IN ('voucher', 'notice')), executed BOOLEAN, updated_at TIMESTAMP NOT NULL,
+		created_at TIMESTAMP NOT NULL, sync_priority integer NOT NULL,
+		PRIMARY KEY (input_index, output_index, app_contract))`,
+	`INSERT INTO convenience_output_raw_references (app_id, input_index, app_contract, output_index, type,
+		updated_at, created_at, sync_priority) VALUES (1, 0, '0x01', 0, 'voucher', '2025-01-01', '2025-01-01', 1)`,
+	`CREATE TABLE synchronizer_fetch (id INTEGER NOT NULL PRIMARY KEY, timestamp_after bigint,
+		ini_cursor_after text, log_vouchers_ids text, end_cursor_after text, ini_input_cursor_after text,
+		end_input_cursor_after text, ini_report_cursor_after text, end_report_cursor_after text)`,
+	`INSERT INTO synchronizer_fetch (id, timestamp_after) VALUES (1, 1700000000)`,
+}
+
+func (s *MigrationsSuite) TestAdoptExistingTables() {
+	for _, query := range preMigrationSchema {
+		_, err := s.db.Exec(query)
+		s.Require().NoError(err, query)
+	}
+
+	s.Require().NoError(Migrate(s.ctx, s.db))
+
+	for _, table := range []string{
+		"convenience_application",
+		"convenience_inputs",
+		"convenience_vouchers",
+		"convenience_notices",
+		"convenience_reports",
+		"convenience_input_raw_references",
+		"convenience_output_raw_references",
+		"synchronizer_fetch",
+	} {
+		var count int
+		s.Require().NoError(s.db.Get(&count, `SELECT count(*) FROM `+table))
+		s.Equal(1, count, table)
+	}
+	s.True(s.columnExists("convenience_vouchers", "transaction_hash"))
+	s.True(s.columnExists("convenience_vouchers", "proof_output_index"))
+	s.True(s.columnExists("convenience_vouchers", "is_delegated_call"))
+	s.True(s.columnExists("convenience_notices", "proof_output_index"))
+	s.True(s.columnExists("convenience_reports", "app_id"))
+	s.True(s.tableExists("convenience_dead_letters"))
+
+	var transactionHash string
+	s.Require().NoError(s.db.Get(&transactionHash, `SELECT transaction_hash FROM convenience_vouchers`))
+	s.Equal("", transactionHash)
+	s.Require().NoError(CheckSchema(s.ctx, s.db))
+}
+
+func (s *MigrationsSuite) TestCheckSchemaRefusesPendingMigrations() {
+	s.ErrorIs(CheckSchema(s.ctx, s.db), ErrPending)
+	s.False(s.tableExists("convenience_inputs"))
+}
+
+func (s *MigrationsSuite) TestCheckRejectsNewerVersion() {
+	schema := s.newSchema()
+	s.Require().NoError(schema.Up())
+	_, err := s.db.Exec(`UPDATE convenience_schema_migrations SET version = 999`)
+	s.Require().NoError(err)
+	_, err = schema.Check()
+	s.ErrorContains(err, "newer than the latest known version")
+}
+
+func (s *MigrationsSuite) TestCheckRejectsDirty() {
+	schema := s.newSchema()
+	s.Require().NoError(schema.Up())
+	_, err := s.db.Exec(`UPDATE convenience_schema_migrations SET dirty = true`)
+	s.Require().NoError(err)
+	_, err = schema.Check()
+	s.ErrorIs(err, ErrDirty)
+}
@@ -0,0 +1,130 @@
+-- Baseline of the tables previously created by each repository.
+-- Every statement is idempotent to adopt databases created before the migrations.
+
+CREATE TABLE IF NOT EXISTS convenience_application (
+	id				integer NOT NULL,
+	name			text NOT NULL,
+	app_contract	text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS convenience_application_id ON convenience_application (id);
+CREATE INDEX IF NOT EXISTS convenience_application_app_contract ON convenience_application (app_contract);
+CREATE INDEX IF NOT EXISTS convenience_application_name ON convenience_application (name);
+
+CREATE TABLE IF NOT EXISTS convenience_inputs (
+	id							text NOT NULL,
+	input_index					integer,
+	app_contract				text,
+	status						text,
+	msg_sender					text,
+	payload						text,
+	block_number				integer,
+	block_timestamp				NUMERIC,
+	prev_randao					text,
+	exception					text,
+	espresso_block_number		integer,
+	espresso_block_timestamp	NUMERIC,
+	input_box_index				integer,
+	avail_block_number			integer,
+	avail_block_timestamp		NUMERIC,
+	type						text,
+	cartesi_transaction_id		text,
+	chain_id					text
+);
+CREATE INDEX IF NOT EXISTS idx_input_index ON convenience_inputs(input_index);
+CREATE INDEX IF NOT EXISTS idx_status ON convenience_inputs(status);
+CREATE INDEX IF NOT EXISTS idx_input_id ON convenience_inputs(app_contract, id);
+CREATE INDEX IF NOT EXISTS idx_status_app_contract ON convenience_inputs(status, app_contract);
+CREATE INDEX IF NOT EXISTS idx_input_index_app_contract ON convenience_inputs(input_index, app_contract);
+
+CREATE TABLE IF NOT EXISTS convenience_vouchers (
+	destination				text,
+	payload					text,
+	executed				BOOLEAN,
+	input_index				integer,
+	output_index			integer,
+	value					text,
+	output_hashes_siblings	text,
+	app_contract			text,
+	transaction_hash		text DEFAULT '' NOT NULL,
+	proof_output_index		integer DEFAULT 0,
+	is_delegated_call		BOOLEAN,
+	PRIMARY KEY (input_index, output_index, app_contract)
+);
+CREATE INDEX IF NOT EXISTS idx_input_index_output_index ON convenience_vouchers(input_index, output_index);
+CREATE INDEX IF NOT EXISTS idx_app_contract_output_index ON convenience_vouchers(app_contract, output_index);
+CREATE INDEX IF NOT EXISTS idx_app_contract_input_index ON convenience_vouchers(app_contract, input_index);
+
+CREATE TABLE IF NOT EXISTS convenience_notices (
+	payload					text,
+	input_index				integer,
+	output_index			integer,
+	app_contract			text,
+	output_hashes_siblings	text,
+	proof_output_index		integer DEFAULT 0,
+	PRIMARY KEY (input_index, output_index, app_contract)
+);
+
+CREATE TABLE IF NOT EXISTS convenience_reports (
+	output_index	integer,
+	payload			text,
+	input_index		integer,
+	app_contract	text,
+	app_id			integer,
+	PRIMARY KEY (input_index, output_index, app_contract)
+);
+CREATE INDEX IF NOT EXISTS idx_output_index_app_contract ON convenience_reports(output_index, app_contract);
+
+CREATE TABLE IF NOT EXISTS convenience_input_raw_references (
+	id				text NOT NULL,
+	app_id			integer NOT NULL,
+	input_index		integer NOT NULL,
+	app_contract	text NOT NULL,
+	status			text,
+	chain_id		text,
+	created_at		TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_app_id_input_index ON convenience_input_raw_references(app_id, input_index);
+CREATE INDEX IF NOT EXISTS idx_convenience_input_raw_references_status_raw_id ON convenience_input_raw_references(status, app_id);
+
+CREATE TABLE IF NOT EXISTS convenience_output_raw_references (
+	app_id			integer NOT NULL,
+	input_index		integer NOT NULL,
+	app_contract	text NOT NULL,
+	output_index	integer NOT NULL,
+	has_proof		BOOLEAN,
+	type			text NOT NULL CHECK (type IN ('voucher', 'notice')),
+	executed		BOOLEAN,
+	updated_at		TIMESTAMP NOT NULL,
+	created_at		TIMESTAMP NOT NULL,
+	sync_priority	integer NOT NULL,
+	PRIMARY KEY (input_index, output_index, app_contract)
+);
+CREATE INDEX IF NOT EXISTS idx_convenience_output_raw_references_app_id ON convenience_output_raw_references(app_id);
+CREATE INDEX IF NOT EXISTS idx_convenience_output_raw_references_has_proof_app_id ON convenience_output_raw_references(has_proof, app_id);
+
+CREATE TABLE IF NOT EXISTS convenience_dead_letters (
+	kind			text NOT NULL CHECK (kind IN ('input', 'output')),
+	app_id			integer NOT NULL,
+	app_contract	text NOT NULL,
+	raw_index		integer NOT NULL,
+	input_index		integer NOT NULL,
+	error			text NOT NULL,
+	retry_count		integer NOT NULL DEFAULT 0,
+	created_at		TIMESTAMP NOT NULL,
+	updated_at		TIMESTAMP NOT NULL,
+	PRIMARY KEY (kind, app_id, raw_index)
+);
+CREATE INDEX IF NOT EXISTS idx_convenience_dead_letters_app_contract ON convenience_dead_letters(app_contract);
+
+CREATE TABLE IF NOT EXISTS synchronizer_fetch (
+	id						SERIAL NOT NULL PRIMARY KEY,
+	timestamp_after			bigint,
+	ini_cursor_after		text,
+	log_vouchers_ids		text,
+	end_cursor_after		text,
+	ini_input_cursor_after	text,
+	end_input_cursor_after	text,
+	ini_report_cursor_after	text,
+	end_report_cursor_after	text
+);
+CREATE INDEX IF NOT EXISTS idx_last_fetched_id ON synchronizer_fetch(id DESC);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_convenience_reports_input_index_app_contract;
+DROP INDEX IF EXISTS idx_convenience_reports_input_index_output_index;
+DROP INDEX IF EXISTS idx_convenience_notices_input_index_output_index;
+DROP INDEX IF EXISTS idx_convenience_notices_app_contract_output_index;
+DROP INDEX IF EXISTS idx_convenience_notices_app_contract_input_index;
+DROP INDEX IF EXISTS idx_convenience_output_raw_references_input_index;
+DROP INDEX IF EXISTS idx_convenience_input_raw_references_status;
+DROP INDEX IF EXISTS idx_convenience_input_raw_references_input_index;
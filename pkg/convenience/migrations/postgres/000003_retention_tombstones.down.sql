@@ -0,0 +1,3 @@
+ALTER TABLE convenience_inputs DROP COLUMN IF EXISTS pruned_at;
+ALTER TABLE convenience_vouchers DROP COLUMN IF EXISTS pruned_at;
+ALTER TABLE convenience_reports DROP COLUMN IF EXISTS pruned_at;
@@ -0,0 +1,4 @@
+-- Pruned rows are kept with an empty payload so the pagination offsets do not move.
+ALTER TABLE convenience_inputs ADD COLUMN IF NOT EXISTS pruned_at TIMESTAMP;
+ALTER TABLE convenience_vouchers ADD COLUMN IF NOT EXISTS pruned_at TIMESTAMP;
+ALTER TABLE convenience_reports ADD COLUMN IF NOT EXISTS pruned_at TIMESTAMP;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS synchronizer_fetch;
+DROP TABLE IF EXISTS convenience_dead_letters;
+DROP TABLE IF EXISTS convenience_output_raw_references;
+DROP TABLE IF EXISTS convenience_input_raw_references;
+DROP TABLE IF EXISTS convenience_reports;
+DROP TABLE IF EXISTS convenience_notices;
+DROP TABLE IF EXISTS convenience_vouchers;
+DROP TABLE IF EXISTS convenience_inputs;
+DROP TABLE IF EXISTS convenience_application;
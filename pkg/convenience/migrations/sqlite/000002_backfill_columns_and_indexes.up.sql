@@ -0,0 +1,18 @@
+-- Columns added to CREATE TABLE IF NOT EXISTS after the table existed were never created.
+-- SQLite has no ADD COLUMN IF NOT EXISTS, so the driver adds the columns of these lines when missing.
+-- add-column: convenience_vouchers transaction_hash text DEFAULT '' NOT NULL
+-- add-column: convenience_vouchers proof_output_index integer DEFAULT 0
+-- add-column: convenience_vouchers is_delegated_call BOOLEAN
+-- add-column: convenience_notices proof_output_index integer DEFAULT 0
+-- add-column: convenience_reports app_id integer
+
+-- Index names are global to the database, so these indexes were silently
+-- skipped when another table already had an index with the same name.
+CREATE INDEX IF NOT EXISTS idx_convenience_input_raw_references_input_index ON convenience_input_raw_references(input_index, app_contract);
+CREATE INDEX IF NOT EXISTS idx_convenience_input_raw_references_status ON convenience_input_raw_references(status);
+CREATE INDEX IF NOT EXISTS idx_convenience_output_raw_references_input_index ON convenience_output_raw_references(input_index, app_contract);
+CREATE INDEX IF NOT EXISTS idx_convenience_notices_app_contract_input_index ON convenience_notices(app_contract, input_index);
+CREATE INDEX IF NOT EXISTS idx_convenience_notices_app_contract_output_index ON convenience_notices(app_contract, output_index);
+CREATE INDEX IF NOT EXISTS idx_convenience_notices_input_index_output_index ON convenience_notices(input_index, output_index);
+CREATE INDEX IF NOT EXISTS idx_convenience_reports_input_index_output_index ON convenience_reports(input_index, output_index);
+CREATE INDEX IF NOT EXISTS idx_convenience_reports_input_index_app_contract ON convenience_reports(input_index, app_contract);
@@ -0,0 +1,3 @@
+ALTER TABLE convenience_inputs DROP COLUMN pruned_at;
+ALTER TABLE convenience_vouchers DROP COLUMN pruned_at;
+ALTER TABLE convenience_reports DROP COLUMN pruned_at;
@@ -0,0 +1,4 @@
+-- Pruned rows are kept with an empty payload so the pagination offsets do not move.
+ALTER TABLE convenience_inputs ADD COLUMN pruned_at TIMESTAMP;
+ALTER TABLE convenience_vouchers ADD COLUMN pruned_at TIMESTAMP;
+ALTER TABLE convenience_reports ADD COLUMN pruned_at TIMESTAMP;
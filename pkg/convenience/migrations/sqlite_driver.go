@@ -0,0 +1,183 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/golang-migrate/migrate/v4/database"
+)
+
+// sqliteDriver is a golang-migrate driver over an open SQLite pool,
+// since the drivers shipped with golang-migrate depend on cgo.
+type sqliteDriver struct {
+	db       *sql.DB
+	table    string
+	isLocked atomic.Bool
+}
+
+func newSqliteDriver(db *sql.DB, table string) (database.Driver, error) {
+	d := &sqliteDriver{db: db, table: table}
+	query := fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (version bigint NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`,
+		table,
+	)
+	_, err := db.Exec(query)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *sqliteDriver) Open(url string) (database.Driver, error) {
+	return nil, errors.New("sqlite migrations require an open database")
+}
+
+// Close keeps the pool open, it belongs to the caller.
+func (d *sqliteDriver) Close() error {
+	return nil
+}
+
+// Lock only guards this process, SQLite serializes the writes of other ones.
+func (d *sqliteDriver) Lock() error {
+	if !d.isLocked.CompareAndSwap(false, true) {
+		return database.ErrLocked
+	}
+	return nil
+}
+
+func (d *sqliteDriver) Unlock() error {
+	if !d.isLocked.CompareAndSwap(true, false) {
+		return database.ErrNotLocked
+	}
+	return nil
+}
+
+func (d *sqliteDriver) Run(migration io.Reader) error {
+	query, err := io.ReadAll(migration)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	err = addMissingColumns(ctx, tx, string(query))
+	if err != nil {
+		_ = tx.Rollback()
+		return database.Error{OrigErr: err, Query: query}
+	}
+	_, err = tx.ExecContext(ctx, string(query))
+	if err != nil {
+		_ = tx.Rollback()
+		return database.Error{OrigErr: err, Query: query}
+	}
+	return tx.Commit()
+}
+
+// Prefix of the lines of a migration adding a column to a table when it is missing,
+// followed by the table and the column definition
+const addColumnDirective = "-- add-column:"
+
+// addMissingColumns runs the add-column lines of the migration, checking the columns of the table first.
+func addMissingColumns(ctx context.Context, tx *sql.Tx, query string) error {
+	for _, line := range strings.Split(query, "\n") {
+		def, ok := strings.CutPrefix(strings.TrimSpace(line), addColumnDirective)
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(def)
+		if len(fields) < 3 {
+			return fmt.Errorf("invalid add-column line %q", line)
+		}
+		table, column := fields[0], fields[1]
+		var count int
+		err := tx.QueryRowContext(ctx,
+			`SELECT count(*) FROM pragma_table_info($1) WHERE name = $2`, table, column,
+		).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN %s", table, strings.Join(fields[1:], " "),
+		))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *sqliteDriver) SetVersion(version int, dirty bool) error {
+	ctx := context.Background()
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s`, d.table))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	// a nil version with a clean state means no migration is applied
+	if version >= 0 || (version == database.NilVersion && dirty) {
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT INTO %s (version, dirty) VALUES ($1, $2)`, d.table),
+			version, dirty,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (d *sqliteDriver) Version() (int, bool, error) {
+	var version int
+	var dirty bool
+	query := fmt.Sprintf(`SELECT version, dirty FROM %s LIMIT 1`, d.table)
+	err := d.db.QueryRow(query).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.NilVersion, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return version, dirty, nil
+}
+
+func (d *sqliteDriver) Drop() error {
+	rows, err := d.db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return err
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		_, err = d.db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s`, table))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RetentionRule selects the rows of an application to prune.
+// Pruned rows keep their indexes with an empty payload.
+type RetentionRule struct {
+	// Prune the reports of inputs older than this many days, zero keeps them
+	ReportsDays int `json:"reportsDays" yaml:"reports_days"`
+	// Prune the payloads of executed vouchers of inputs older than this many days, zero keeps them
+	ExecutedVoucherPayloadsDays int `json:"executedVoucherPayloadsDays" yaml:"executed_voucher_payloads_days"`
+	// Prune the inputs of the applications disabled on the node
+	DisabledAppInputs bool `json:"disabledAppInputs" yaml:"disabled_app_inputs"`
+}
+
+func (r RetentionRule) Validate() error {
+	if r.ReportsDays < 0 || r.ExecutedVoucherPayloadsDays < 0 {
+		return fmt.Errorf("retention days cannot be negative")
+	}
+	return nil
+}
+
+// RetentionPolicy is the default rule and the rules replacing it for some applications.
+type RetentionPolicy struct {
+	Default RetentionRule            `json:"default"`
+	Apps    map[string]RetentionRule `json:"apps"`
+}
+
+// Rule returns the rule of the application.
+func (p RetentionPolicy) Rule(appContract string) RetentionRule {
+	rule, ok := p.Apps[common.HexToAddress(appContract).Hex()]
+	if ok {
+		return rule
+	}
+	return p.Default
+}
+
+// ParseRetentionApps reads the JSON object mapping application addresses to their rules.
+func ParseRetentionApps(raw string) (map[string]RetentionRule, error) {
+	rules := map[string]RetentionRule{}
+	err := json.Unmarshal([]byte(raw), &rules)
+	if err != nil {
+		return nil, err
+	}
+	apps := make(map[string]RetentionRule, len(rules))
+	for app, rule := range rules {
+		if !common.IsHexAddress(app) {
+			return nil, fmt.Errorf("invalid application address: %s", app)
+		}
+		err = rule.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %w", app, err)
+		}
+		apps[common.HexToAddress(app).Hex()] = rule
+	}
+	return apps, nil
+}
+
+// RetentionRun sums up a pruning run.
+type RetentionRun struct {
+	StartedAt  time.Time         `json:"startedAt"`
+	FinishedAt time.Time         `json:"finishedAt"`
+	Apps       []AppRetentionRun `json:"apps"`
+	Error      string            `json:"error,omitempty"`
+}
+
+type AppRetentionRun struct {
+	AppContract     string `json:"appContract"`
+	Reports         int64  `json:"reports"`
+	VoucherPayloads int64  `json:"voucherPayloads"`
+	Inputs          int64  `json:"inputs"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// SyncStatus is the state of the synchronizer loop, as operated by the admin API.
+type SyncStatus struct {
+	Paused bool `json:"paused"`
+	// A cycle was requested and has not started yet
+	TriggerPending bool       `json:"triggerPending"`
+	Cycles         uint64     `json:"cycles"`
+	LastCycleAt    *time.Time `json:"lastCycleAt"`
+	LastCycleTook  string     `json:"lastCycleTook,omitempty"`
+	LastError      string     `json:"lastError,omitempty"`
+}
+
+// SyncCheckpoint is where each synchronizer of an application resumes from.
+// A nil index means the synchronizer has nothing of the application yet.
+type SyncCheckpoint struct {
+	AppID       uint64 `json:"appId"`
+	AppContract string `json:"appContract"`
+	// Last input synced
+	LastInputIndex *uint64 `json:"lastInputIndex"`
+	// First input waiting for its completion status
+	PendingStatusInputIndex *uint64 `json:"pendingStatusInputIndex"`
+	// Last report synced
+	LastReportIndex *uint64 `json:"lastReportIndex"`
+	// Last output synced
+	LastOutputIndex *uint64 `json:"lastOutputIndex"`
+	// First output waiting for its proof
+	PendingProofOutputIndex *uint64 `json:"pendingProofOutputIndex"`
+	// Last voucher execution synced
+	LastExecutedAt *time.Time `json:"lastExecutedAt"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import "time"
+
+// Kinds of rows compared by the verifier.
+const (
+	VERIFY_INPUTS     = "inputs"
+	VERIFY_OUTPUTS    = "outputs"
+	VERIFY_REPORTS    = "reports"
+	VERIFY_MISSING    = "missing"
+	VERIFY_EXTRA      = "extra"
+	VERIFY_DIVERGENT  = "divergent"
+	MAX_VERIFY_ISSUES = 100
+)
+
+// VerifyReport is the result of comparing the node database with the convenience database.
+type VerifyReport struct {
+	StartedAt  time.Time         `json:"startedAt"`
+	FinishedAt time.Time         `json:"finishedAt"`
+	Consistent bool              `json:"consistent"`
+	Apps       []AppVerifyReport `json:"apps"`
+	Repaired   []string          `json:"repaired,omitempty"`
+}
+
+type AppVerifyReport struct {
+	AppID       uint64             `json:"appId"`
+	AppContract string             `json:"appContract"`
+	Kinds       []KindVerifyReport `json:"kinds"`
+	// Issues are truncated at MAX_VERIFY_ISSUES, the counts of each kind are complete
+	Issues []VerifyIssue `json:"issues"`
+}
+
+// KindVerifyReport sums up the rows of a kind up to the last synced index.
+// The hashes cover the compared content of every row in index order.
+type KindVerifyReport struct {
+	Kind             string `json:"kind"`
+	NodeCount        uint64 `json:"nodeCount"`
+	ConvenienceCount uint64 `json:"convenienceCount"`
+	NodeHash         string `json:"nodeHash"`
+	ConvenienceHash  string `json:"convenienceHash"`
+	Missing          uint64 `json:"missing"`
+	Extra            uint64 `json:"extra"`
+	Divergent        uint64 `json:"divergent"`
+	// Node rows that fail to convert and are kept as dead letters
+	Quarantined uint64 `json:"quarantined"`
+}
+
+type VerifyIssue struct {
+	Kind       string            `json:"kind"`
+	Problem    string            `json:"problem"`
+	Index      uint64            `json:"index"`
+	InputIndex uint64            `json:"inputIndex"`
+	Fields     []VerifyFieldDiff `json:"fields,omitempty"`
+}
+
+type VerifyFieldDiff struct {
+	Name        string `json:"name"`
+	Node        string `json:"node"`
+	Convenience string `json:"convenience"`
+}
+
+// HasIssues reports whether any kind of the application diverges.
+func (r AppVerifyReport) HasIssues() bool {
+	for _, kind := range r.Kinds {
+		if kind.Missing+kind.Extra+kind.Divergent > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
 	"github.com/ethereum/go-ethereum/common"
@@ -45,6 +46,7 @@ func (s *ExecListenerSuite) SetupTest() {
 	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
 	commons.ConfigureLog(slog.LevelDebug)
 	s.db = sqlx.MustConnect("sqlite3", ":memory:")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.repository = &repository.VoucherRepository{
 		Db: s.db,
 	}
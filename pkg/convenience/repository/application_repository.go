@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -20,8 +21,9 @@ type ApplicationRepository struct {
 }
 
 func (a *ApplicationRepository) FindAppByAppContract(ctx context.Context, appContract *common.Address) (*model.ConvenienceApplication, error) {
+	exec := DBExecutor{a.Db}
 	query := `SELECT id, name, app_contract FROM convenience_application WHERE app_contract = $1`
-	stmt, err := a.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -37,29 +39,53 @@ func (a *ApplicationRepository) FindAppByAppContract(ctx context.Context, appCon
 	return &app, nil
 }
 
+// BatchFindAppsByAppContract finds the applications of the addresses, in their order,
+// with nil for the unknown ones.
+func (a *ApplicationRepository) BatchFindAppsByAppContract(
+	ctx context.Context,
+	appContracts []string,
+) ([]*model.ConvenienceApplication, []error) {
+	exec := DBExecutor{a.Db}
+	slog.DebugContext(ctx, "BatchFindAppsByAppContract", "len", len(appContracts))
+	args := []any{}
+	where := []string{}
+	for i, appContract := range appContracts {
+		where = append(where, fmt.Sprintf("$%d", i+1))
+		args = append(args, common.HexToAddress(appContract).Hex())
+	}
+	query := fmt.Sprintf(`SELECT id, name, app_contract FROM convenience_application
+		WHERE app_contract IN (%s)`, strings.Join(where, ", "))
+	var apps []model.ConvenienceApplication
+	if err := exec.SelectContext(ctx, &apps, query, args...); err != nil {
+		slog.ErrorContext(ctx, "BatchFind", "error", err)
+		return nil, []error{err}
+	}
+	appMap := make(map[string]*model.ConvenienceApplication, len(apps))
+	for i := range apps {
+		appMap[common.HexToAddress(apps[i].ApplicationAddress).Hex()] = &apps[i]
+	}
+	results := make([]*model.ConvenienceApplication, len(appContracts))
+	for i, appContract := range appContracts {
+		results[i] = appMap[common.HexToAddress(appContract).Hex()]
+	}
+	return results, nil
+}
+
 func (a *ApplicationRepository) CreateTables(ctx context.Context) error {
-	schema := `CREATE TABLE IF NOT EXISTS convenience_application (
-		id INTEGER NOT NULL,
-		name text NOT NULL,
-		app_contract text NOT NULL
-	);
-	CREATE INDEX IF NOT EXISTS convenience_application_id ON convenience_application (id);
-	CREATE INDEX IF NOT EXISTS convenience_application_app_contract ON convenience_application (app_contract);
-	CREATE INDEX IF NOT EXISTS convenience_application_name ON convenience_application (name);
-	`
-
-	_, err := a.Db.ExecContext(ctx, schema)
-	if err == nil {
-		slog.DebugContext(ctx, "Application table created")
-	} else {
+	// the tables are versioned by the migrations package
+	err := migrations.CheckSchema(ctx, a.Db)
+	if err != nil {
 		slog.ErrorContext(ctx, "Create table error", "error", err)
+		return err
 	}
-	return err
+	slog.DebugContext(ctx, "Application table created")
+	return nil
 }
 
 func (a *ApplicationRepository) GetLatestApp(ctx context.Context) (*model.ConvenienceApplication, error) {
+	exec := DBExecutor{a.Db}
 	query := `SELECT * FROM convenience_application ORDER BY id DESC LIMIT 1`
-	stmt, err := a.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +102,17 @@ func (a *ApplicationRepository) GetLatestApp(ctx context.Context) (*model.Conven
 	return &app, nil
 }
 
+// ListAll returns every application ordered by id.
+func (a *ApplicationRepository) ListAll(ctx context.Context) ([]model.ConvenienceApplication, error) {
+	exec := DBExecutor{a.Db}
+	apps := []model.ConvenienceApplication{}
+	err := exec.SelectContext(ctx, &apps, `SELECT id, name, app_contract FROM convenience_application ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
 func (a *ApplicationRepository) Create(ctx context.Context, rawApp *model.ConvenienceApplication) (*model.ConvenienceApplication, error) {
 	insertSql := `INSERT INTO convenience_application (
 		id,
@@ -161,6 +198,7 @@ func transformToApplicationQuery(filter []*model.ConvenienceFilter) (string, []a
 }
 
 func (a *ApplicationRepository) FindAll(ctx context.Context, first *int, last *int, after *string, before *string, filter []*model.ConvenienceFilter) (*commons.PageResult[model.ConvenienceApplication], error) {
+	exec := DBExecutor{a.Db}
 	total, err := a.Count(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -185,7 +223,7 @@ func (a *ApplicationRepository) FindAll(ctx context.Context, first *int, last *i
 	args = append(args, offset)
 
 	slog.DebugContext(ctx, "Query", "query", query, "args", args)
-	stmt, err := a.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "query error")
 		return nil, err
@@ -206,6 +244,7 @@ func (a *ApplicationRepository) FindAll(ctx context.Context, first *int, last *i
 }
 
 func (a *ApplicationRepository) Count(ctx context.Context, filter []*model.ConvenienceFilter) (uint64, error) {
+	exec := DBExecutor{a.Db}
 	query := `SELECT COUNT(*) FROM convenience_application `
 	where, args, _, err := transformToApplicationQuery(filter)
 	if err != nil {
@@ -213,7 +252,7 @@ func (a *ApplicationRepository) Count(ctx context.Context, filter []*model.Conve
 	}
 	query += where
 	slog.DebugContext(ctx, "Query", "query", query, "args", args)
-	stmt, err := a.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "query error")
 		return 0, err
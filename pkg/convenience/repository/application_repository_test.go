@@ -6,10 +6,12 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	configtest "github.com/cartesi/rollups-graphql/v2/pkg/convenience/config_test"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
@@ -37,6 +39,7 @@ func (a *ApplicationRepositorySuite) SetupTest() {
 	sqliteFileName := filepath.Join(tempDir, "application.sqlite3")
 
 	a.db = sqlx.MustConnect("sqlite3", sqliteFileName)
+	a.Require().NoError(migrations.Migrate(a.ctx, a.db))
 
 	a.repository = &ApplicationRepository{
 		Db: a.db,
@@ -119,3 +122,18 @@ func (s *ApplicationRepositorySuite) TestFindApplication() {
 	s.NoError(err)
 	s.Equal(counter, int(count))
 }
+
+func (s *ApplicationRepositorySuite) TestBatchFindApps() {
+	ctx := context.Background()
+	app := newApp()
+	_, err := s.repository.Create(ctx, app)
+	s.Require().NoError(err)
+
+	unknown := "0x544a3B76B84b1E98c13437A1591E713Dd314387F"
+	apps, errs := s.repository.BatchFindAppsByAppContract(ctx, []string{unknown, strings.ToLower(app.ApplicationAddress)})
+	s.Require().Empty(errs)
+	s.Require().Len(apps, 2)
+	s.Nil(apps[0])
+	s.Require().NotNil(apps[1])
+	s.Equal(app.Name, apps[1].Name)
+}
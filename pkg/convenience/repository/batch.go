@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+)
+
+// BatchPage selects the rows of an item of a batch: Limit rows from Offset,
+// or all of them when Limit is nil.
+type BatchPage struct {
+	Offset int
+	Limit  *int
+}
+
+// Key appends the page to the key of a batch item, leaving the key of the
+// whole list unchanged.
+func (p BatchPage) Key(key string) string {
+	if p.Offset == 0 && p.Limit == nil {
+		return key
+	}
+	limit := "all"
+	if p.Limit != nil {
+		limit = strconv.Itoa(*p.Limit)
+	}
+	return fmt.Sprintf("%s|%d|%s", key, p.Offset, limit)
+}
+
+// ParseBatchKey parses the application, the input index and the page of a batch key.
+func ParseBatchKey(key string) (string, int, BatchPage, error) {
+	parts := strings.Split(key, "|")
+	if len(parts) != 2 && len(parts) != 4 {
+		return "", 0, BatchPage{}, fmt.Errorf("invalid batch key: %q", key)
+	}
+	inputIndex, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", 0, BatchPage{}, fmt.Errorf("invalid batch key: %q", key)
+	}
+	var page BatchPage
+	if len(parts) == 4 {
+		page.Offset, err = strconv.Atoi(parts[2])
+		if err != nil || page.Offset < 0 {
+			return "", 0, BatchPage{}, fmt.Errorf("invalid batch key: %q", key)
+		}
+		if parts[3] != "all" {
+			limit, err := strconv.Atoi(parts[3])
+			if err != nil || limit < 0 {
+				return "", 0, BatchPage{}, fmt.Errorf("invalid batch key: %q", key)
+			}
+			page.Limit = &limit
+		}
+	}
+	return parts[0], inputIndex, page, nil
+}
+
+// contains reports whether the row, numbered from 1 within its item, is in the page.
+func (p BatchPage) contains(row int) bool {
+	return row > p.Offset && (p.Limit == nil || row <= p.Offset+*p.Limit)
+}
+
+// emptyMeansNone reports whether the page comes out empty only when the item
+// has no rows at all.
+func (p BatchPage) emptyMeansNone() bool {
+	return p.Offset == 0 && (p.Limit == nil || *p.Limit > 0)
+}
+
+// batchItem is an item of a batch, the rows of an input of an application.
+type batchItem struct {
+	appContract string
+	inputIndex  int
+	page        BatchPage
+}
+
+func (i batchItem) key() string {
+	return fmt.Sprintf("%s|%d", i.appContract, i.inputIndex)
+}
+
+// batchColumns are the columns added to each row by batchPageQuery.
+type batchColumns struct {
+	// Position of the row within its item, from 1
+	BatchRow int `db:"batch_row"`
+	// Number of rows of the item
+	BatchTotal uint64 `db:"batch_total"`
+}
+
+// batchPageQuery selects the rows of the table of the items ordered by output index,
+// numbering them within their item and keeping only the rows of the page of each item.
+func batchPageQuery(table string, items []batchItem) (string, []any) {
+	// the parameters are numbered in the order they first appear in the query,
+	// as sqlite requires: the items of the inner query first, then their pages
+	args := []any{}
+	inner := []string{}
+	for _, item := range items {
+		args = append(args, item.appContract, item.inputIndex)
+		// nolint
+		inner = append(inner, fmt.Sprintf("(app_contract = $%d AND input_index = $%d)", len(args)-1, len(args)))
+	}
+	outer := []string{}
+	for i, item := range items {
+		args = append(args, item.page.Offset)
+		cond := fmt.Sprintf("(app_contract = $%d AND input_index = $%d AND batch_row > $%d", i*2+1, i*2+2, len(args))
+		if item.page.Limit != nil {
+			args = append(args, item.page.Offset+*item.page.Limit)
+			cond += fmt.Sprintf(" AND batch_row <= $%d", len(args))
+		}
+		outer = append(outer, cond+")")
+	}
+	query := fmt.Sprintf(`SELECT * FROM (
+		SELECT t.*,
+			ROW_NUMBER() OVER (PARTITION BY app_contract, input_index ORDER BY output_index) AS batch_row,
+			COUNT(*) OVER (PARTITION BY app_contract, input_index) AS batch_total
+		FROM %s t WHERE %s
+	) AS batch WHERE %s ORDER BY app_contract, input_index, batch_row`,
+		table, strings.Join(inner, " OR "), strings.Join(outer, " OR "))
+	return query, args
+}
+
+// batchPages distributes the rows to the pages of the items, in the order of the items.
+// The items whose page came out empty are counted by a second query, unless they
+// have no rows at all.
+func batchPages[T any](
+	ctx context.Context,
+	exec *DBExecutor,
+	table string,
+	items []batchItem,
+	rows []T,
+	row func(T) (string, batchColumns),
+) ([]*commons.PageResult[T], error) {
+	type group struct {
+		total uint64
+		rows  []T
+		cols  []batchColumns
+	}
+	groups := map[string]*group{}
+	for _, r := range rows {
+		key, cols := row(r)
+		g := groups[key]
+		if g == nil {
+			g = &group{total: cols.BatchTotal}
+			groups[key] = g
+		}
+		g.rows = append(g.rows, r)
+		g.cols = append(g.cols, cols)
+	}
+	results := make([]*commons.PageResult[T], len(items))
+	uncounted := []batchItem{}
+	for i, item := range items {
+		result := &commons.PageResult[T]{Offset: uint64(item.page.Offset)}
+		if g := groups[item.key()]; g != nil {
+			result.Total = g.total
+			for j, r := range g.rows {
+				if item.page.contains(g.cols[j].BatchRow) {
+					result.Rows = append(result.Rows, r)
+				}
+			}
+		}
+		if len(result.Rows) == 0 && !item.page.emptyMeansNone() {
+			uncounted = append(uncounted, item)
+		}
+		results[i] = result
+	}
+	if len(uncounted) == 0 {
+		return results, nil
+	}
+	totals, err := batchCount(ctx, exec, table, uncounted)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if total, ok := totals[item.key()]; ok {
+			results[i].Total = total
+		}
+	}
+	return results, nil
+}
+
+// batchCount counts the rows of each item, by item key.
+func batchCount(
+	ctx context.Context,
+	exec *DBExecutor,
+	table string,
+	items []batchItem,
+) (map[string]uint64, error) {
+	args := []any{}
+	where := []string{}
+	for _, item := range items {
+		args = append(args, item.appContract, item.inputIndex)
+		// nolint
+		where = append(where, fmt.Sprintf("(app_contract = $%d AND input_index = $%d)", len(args)-1, len(args)))
+	}
+	query := fmt.Sprintf(`SELECT app_contract, input_index, COUNT(*) FROM %s t WHERE %s
+		GROUP BY app_contract, input_index`, table, strings.Join(where, " OR "))
+	rows, err := exec.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	totals := map[string]uint64{}
+	for rows.Next() {
+		var item batchItem
+		var total uint64
+		if err := rows.Scan(&item.appContract, &item.inputIndex, &total); err != nil {
+			return nil, err
+		}
+		totals[item.key()] = total
+	}
+	return totals, rows.Err()
+}
@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+const DEAD_LETTER_INPUT = "input"
+const DEAD_LETTER_OUTPUT = "output"
+
+// DeadLetterRepository keeps the raw rows from the node that failed to convert.
+// The synchronizers skip past them, so one malformed row does not stop the sync.
+type DeadLetterRepository struct {
+	Db *sqlx.DB
+}
+
+type DeadLetter struct {
+	Kind        string    `db:"kind" json:"kind"`
+	AppID       uint64    `db:"app_id" json:"appId"`
+	AppContract string    `db:"app_contract" json:"appContract"`
+	RawIndex    uint64    `db:"raw_index" json:"index"`
+	InputIndex  uint64    `db:"input_index" json:"inputIndex"`
+	Error       string    `db:"error" json:"error"`
+	RetryCount  uint64    `db:"retry_count" json:"retryCount"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+func (r *DeadLetterRepository) CreateTables(ctx context.Context) error {
+	// the tables are versioned by the migrations package
+	err := migrations.CheckSchema(ctx, r.Db)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create dead letters table", "error", err)
+		return err
+	}
+	slog.DebugContext(ctx, "Dead letters table created successfully")
+	return nil
+}
+
+// Create stores a dead letter and reports whether it was new.
+// A row that is already quarantined is kept untouched.
+func (r *DeadLetterRepository) Create(ctx context.Context, deadLetter DeadLetter) (bool, error) {
+	exec := DBExecutor{r.Db}
+	now := time.Now()
+	result, err := exec.ExecContext(ctx, `INSERT INTO convenience_dead_letters (
+		kind, app_id, app_contract, raw_index, input_index, error, retry_count, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 0, $7, $8)
+		ON CONFLICT (kind, app_id, raw_index) DO NOTHING`,
+		deadLetter.Kind,
+		deadLetter.AppID,
+		deadLetter.AppContract,
+		deadLetter.RawIndex,
+		deadLetter.InputIndex,
+		deadLetter.Error,
+		now,
+		now,
+	)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to insert dead letter", "error", err,
+			"kind", deadLetter.Kind,
+			"app_id", deadLetter.AppID,
+			"raw_index", deadLetter.RawIndex,
+		)
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *DeadLetterRepository) FindByKey(ctx context.Context, kind string, appID uint64, rawIndex uint64) (*DeadLetter, error) {
+	exec := DBExecutor{r.Db}
+	var deadLetter DeadLetter
+	err := exec.GetContext(ctx, &deadLetter, `
+		SELECT * FROM convenience_dead_letters
+		WHERE kind = $1 AND app_id = $2 AND raw_index = $3`, kind, appID, rawIndex)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Failed to find dead letter", "error", err)
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// FindAll lists the dead letters, optionally only the ones of an application contract.
+func (r *DeadLetterRepository) FindAll(ctx context.Context, appContract string) ([]DeadLetter, error) {
+	exec := DBExecutor{r.Db}
+	deadLetters := []DeadLetter{}
+	err := exec.SelectContext(ctx, &deadLetters, `
+		SELECT * FROM convenience_dead_letters
+		WHERE $1 = '' OR app_contract = $1
+		ORDER BY created_at ASC, app_id ASC, kind ASC, raw_index ASC`, appContract)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list dead letters", "error", err)
+		return nil, err
+	}
+	return deadLetters, nil
+}
+
+// FindLatest returns the last quarantined row of the kind in the order of the sync,
+// which moves the checkpoint of the single pass sync past the quarantined rows.
+func (r *DeadLetterRepository) FindLatest(ctx context.Context, kind string) (*DeadLetter, error) {
+	exec := DBExecutor{r.Db}
+	var deadLetter DeadLetter
+	err := exec.GetContext(ctx, &deadLetter, `
+		SELECT * FROM convenience_dead_letters
+		WHERE kind = $1
+		ORDER BY raw_index DESC, app_id DESC
+		LIMIT 1`, kind)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Failed to find the latest dead letter", "kind", kind, "error", err)
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// FindLatestByAppID returns the last quarantined row of the kind of one application.
+func (r *DeadLetterRepository) FindLatestByAppID(ctx context.Context, kind string, appID uint64) (*DeadLetter, error) {
+	exec := DBExecutor{r.Db}
+	var deadLetter DeadLetter
+	err := exec.GetContext(ctx, &deadLetter, `
+		SELECT * FROM convenience_dead_letters
+		WHERE kind = $1 AND app_id = $2
+		ORDER BY raw_index DESC
+		LIMIT 1`, kind, appID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Failed to find the latest dead letter by app", "kind", kind, "app_id", appID, "error", err)
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// RegisterRetry stores the error of a failed retry and increments the retry count.
+func (r *DeadLetterRepository) RegisterRetry(ctx context.Context, deadLetter *DeadLetter, errMsg string) error {
+	exec := DBExecutor{r.Db}
+	now := time.Now()
+	result, err := exec.ExecContext(ctx, `
+		UPDATE convenience_dead_letters
+		SET error = $1, retry_count = retry_count + 1, updated_at = $2
+		WHERE kind = $3 AND app_id = $4 AND raw_index = $5`,
+		errMsg, now, deadLetter.Kind, deadLetter.AppID, deadLetter.RawIndex)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to update dead letter", "error", err)
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected != 1 {
+		return fmt.Errorf("unexpected number of dead letters updated: %d", affected)
+	}
+	deadLetter.Error = errMsg
+	deadLetter.RetryCount++
+	deadLetter.UpdatedAt = now
+	return nil
+}
+
+func (r *DeadLetterRepository) Delete(ctx context.Context, deadLetter *DeadLetter) error {
+	exec := DBExecutor{r.Db}
+	_, err := exec.ExecContext(ctx, `
+		DELETE FROM convenience_dead_letters
+		WHERE kind = $1 AND app_id = $2 AND raw_index = $3`,
+		deadLetter.Kind, deadLetter.AppID, deadLetter.RawIndex)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to delete dead letter", "error", err)
+	}
+	return err
+}
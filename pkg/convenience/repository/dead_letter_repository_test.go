@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	configtest "github.com/cartesi/rollups-graphql/v2/pkg/convenience/config_test"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeadLetterRepositorySuite struct {
+	suite.Suite
+	repository *DeadLetterRepository
+	tempDir    string
+	db         *sqlx.DB
+	ctx        context.Context
+	ctxCancel  context.CancelFunc
+}
+
+func (s *DeadLetterRepositorySuite) SetupTest() {
+	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.NoError(err)
+	s.tempDir = tempDir
+	sqliteFileName := filepath.Join(tempDir, "dead_letters.sqlite3")
+	s.db = sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	s.repository = &DeadLetterRepository{Db: s.db}
+	err = s.repository.CreateTables(s.ctx)
+	s.NoError(err)
+}
+
+func (s *DeadLetterRepositorySuite) TearDownTest() {
+	s.ctxCancel()
+	s.db.Close()
+	err := os.RemoveAll(s.tempDir)
+	s.NoError(err)
+}
+
+func TestDeadLetterRepositorySuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterRepositorySuite))
+}
+
+func newDeadLetter(appContract string, index uint64) DeadLetter {
+	return DeadLetter{
+		Kind:        DEAD_LETTER_OUTPUT,
+		AppID:       1,
+		AppContract: appContract,
+		RawIndex:    index,
+		InputIndex:  index,
+		Error:       "unsupported output selector type: deadbeef",
+	}
+}
+
+func (s *DeadLetterRepositorySuite) TestCreateIsIdempotent() {
+	created, err := s.repository.Create(s.ctx, newDeadLetter(configtest.DEFAULT_TEST_APP_CONTRACT, 3))
+	s.Require().NoError(err)
+	s.True(created)
+	created, err = s.repository.Create(s.ctx, newDeadLetter(configtest.DEFAULT_TEST_APP_CONTRACT, 3))
+	s.Require().NoError(err)
+	s.False(created)
+
+	deadLetter, err := s.repository.FindByKey(s.ctx, DEAD_LETTER_OUTPUT, 1, 3)
+	s.Require().NoError(err)
+	s.Require().NotNil(deadLetter)
+	s.Equal(uint64(0), deadLetter.RetryCount)
+	s.Equal("unsupported output selector type: deadbeef", deadLetter.Error)
+}
+
+func (s *DeadLetterRepositorySuite) TestFindAllByAppContract() {
+	otherApp := "0x0000000000000000000000000000000000000001"
+	_, err := s.repository.Create(s.ctx, newDeadLetter(configtest.DEFAULT_TEST_APP_CONTRACT, 1))
+	s.Require().NoError(err)
+	other := newDeadLetter(otherApp, 2)
+	other.AppID = 2
+	_, err = s.repository.Create(s.ctx, other)
+	s.Require().NoError(err)
+
+	all, err := s.repository.FindAll(s.ctx, "")
+	s.Require().NoError(err)
+	s.Len(all, 2)
+
+	filtered, err := s.repository.FindAll(s.ctx, otherApp)
+	s.Require().NoError(err)
+	s.Require().Len(filtered, 1)
+	s.Equal(uint64(2), filtered[0].RawIndex)
+}
+
+func (s *DeadLetterRepositorySuite) TestRegisterRetryAndDelete() {
+	_, err := s.repository.Create(s.ctx, newDeadLetter(configtest.DEFAULT_TEST_APP_CONTRACT, 5))
+	s.Require().NoError(err)
+	deadLetter, err := s.repository.FindByKey(s.ctx, DEAD_LETTER_OUTPUT, 1, 5)
+	s.Require().NoError(err)
+
+	err = s.repository.RegisterRetry(s.ctx, deadLetter, "value not found")
+	s.Require().NoError(err)
+	stored, err := s.repository.FindByKey(s.ctx, DEAD_LETTER_OUTPUT, 1, 5)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), stored.RetryCount)
+	s.Equal("value not found", stored.Error)
+
+	err = s.repository.Delete(s.ctx, stored)
+	s.Require().NoError(err)
+	stored, err = s.repository.FindByKey(s.ctx, DEAD_LETTER_OUTPUT, 1, 5)
+	s.Require().NoError(err)
+	s.Nil(stored)
+}
+
+func (s *DeadLetterRepositorySuite) TestFindLatest() {
+	latest, err := s.repository.FindLatest(s.ctx, DEAD_LETTER_OUTPUT)
+	s.Require().NoError(err)
+	s.Nil(latest)
+
+	for _, rawIndex := range []uint64{7, 3} {
+		_, err = s.repository.Create(s.ctx, newDeadLetter(configtest.DEFAULT_TEST_APP_CONTRACT, rawIndex))
+		s.Require().NoError(err)
+	}
+	other := newDeadLetter("0x0000000000000000000000000000000000000001", 5)
+	other.AppID = 2
+	_, err = s.repository.Create(s.ctx, other)
+	s.Require().NoError(err)
+
+	latest, err = s.repository.FindLatest(s.ctx, DEAD_LETTER_OUTPUT)
+	s.Require().NoError(err)
+	s.Require().NotNil(latest)
+	s.Equal(uint64(7), latest.RawIndex)
+
+	latest, err = s.repository.FindLatestByAppID(s.ctx, DEAD_LETTER_OUTPUT, 2)
+	s.Require().NoError(err)
+	s.Require().NotNil(latest)
+	s.Equal(uint64(5), latest.RawIndex)
+
+	latest, err = s.repository.FindLatestByAppID(s.ctx, DEAD_LETTER_INPUT, 1)
+	s.Require().NoError(err)
+	s.Nil(latest)
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "errors"
+
+// ErrNotFound is wrapped by the errors of the reads of a single missing row, e.g. "voucher not found".
+var ErrNotFound = errors.New("not found")
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+var ErrApplicationNotFound = errors.New("application not found")
+
+// ErrReplicaNotStreaming is returned by ReplicaLag for a replica that is not streaming from the primary.
+var ErrReplicaNotStreaming = errors.New("replica not streaming from the primary")
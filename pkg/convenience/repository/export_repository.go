@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"math"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ExportRepository reads the rows of an application in pages ordered by index,
+// so a full dump never holds more than a page in memory.
+type ExportRepository struct {
+	Db *sqlx.DB
+}
+
+// ExportRange selects the rows of the inputs from FromInput to ToInput, both inclusive.
+type ExportRange struct {
+	AppContract string
+	FromInput   uint64
+	// nil exports up to the last input
+	ToInput *uint64
+}
+
+func (r ExportRange) toInput() int64 {
+	if r.ToInput == nil {
+		return math.MaxInt64
+	}
+	return int64(*r.ToInput)
+}
+
+func (r *ExportRepository) FindInputs(
+	ctx context.Context, exportRange ExportRange, after *uint64, limit uint64,
+) ([]model.AdvanceInput, error) {
+	exec := DBExecutor{r.Db}
+	rows := []inputRow{}
+	err := exec.SelectContext(ctx, &rows, `
+		SELECT
+			id,
+			input_index,
+			status,
+			msg_sender,
+			payload,
+			block_number,
+			block_timestamp,
+			prev_randao,
+			exception,
+			app_contract,
+			espresso_block_number,
+			espresso_block_timestamp,
+			input_box_index,
+			avail_block_number,
+			avail_block_timestamp,
+			type,
+			chain_id
+		FROM convenience_inputs
+		WHERE app_contract = $1 AND input_index >= $2 AND input_index <= $3 AND input_index > $4
+		ORDER BY input_index ASC
+		LIMIT $5`,
+		exportRange.AppContract, exportRange.FromInput, exportRange.toInput(), afterIndex(after), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]model.AdvanceInput, len(rows))
+	for i, row := range rows {
+		inputs[i] = parseRowInput(row)
+	}
+	return inputs, nil
+}
+
+// FindVouchers returns the vouchers and delegate call vouchers in output index order.
+func (r *ExportRepository) FindVouchers(
+	ctx context.Context, exportRange ExportRange, after *uint64, limit uint64,
+) ([]model.ConvenienceVoucher, error) {
+	exec := DBExecutor{r.Db}
+	rows := []voucherRow{}
+	err := exec.SelectContext(ctx, &rows, `
+		SELECT
+			COALESCE(destination, '') AS destination,
+			COALESCE(payload, '') AS payload,
+			input_index,
+			output_index,
+			COALESCE(executed, false) AS executed,
+			COALESCE(value, '') AS value,
+			COALESCE(output_hashes_siblings, '') AS output_hashes_siblings,
+			app_contract,
+			COALESCE(transaction_hash, '') AS transaction_hash,
+			COALESCE(proof_output_index, 0) AS proof_output_index,
+			COALESCE(is_delegated_call, false) AS is_delegated_call
+		FROM convenience_vouchers
+		WHERE app_contract = $1 AND input_index >= $2 AND input_index <= $3 AND output_index > $4
+		ORDER BY output_index ASC
+		LIMIT $5`,
+		exportRange.AppContract, exportRange.FromInput, exportRange.toInput(), afterIndex(after), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	vouchers := make([]model.ConvenienceVoucher, len(rows))
+	for i, row := range rows {
+		vouchers[i] = convertToConvenienceVoucher(row)
+	}
+	return vouchers, nil
+}
+
+func (r *ExportRepository) FindNotices(
+	ctx context.Context, exportRange ExportRange, after *uint64, limit uint64,
+) ([]model.ConvenienceNotice, error) {
+	exec := DBExecutor{r.Db}
+	notices := []model.ConvenienceNotice{}
+	err := exec.SelectContext(ctx, &notices, `
+		SELECT
+			app_contract,
+			COALESCE(payload, '') AS payload,
+			input_index,
+			output_index,
+			COALESCE(output_hashes_siblings, '') AS output_hashes_siblings,
+			COALESCE(proof_output_index, 0) AS proof_output_index
+		FROM convenience_notices
+		WHERE app_contract = $1 AND input_index >= $2 AND input_index <= $3 AND output_index > $4
+		ORDER BY output_index ASC
+		LIMIT $5`,
+		exportRange.AppContract, exportRange.FromInput, exportRange.toInput(), afterIndex(after), limit,
+	)
+	return notices, err
+}
+
+func (r *ExportRepository) FindReports(
+	ctx context.Context, exportRange ExportRange, after *uint64, limit uint64,
+) ([]model.Report, error) {
+	exec := DBExecutor{r.Db}
+	reports := []model.Report{}
+	err := exec.SelectContext(ctx, &reports, `
+		SELECT
+			output_index,
+			input_index,
+			COALESCE(payload, '') AS payload
+		FROM convenience_reports
+		WHERE app_contract = $1 AND input_index >= $2 AND input_index <= $3 AND output_index > $4
+		ORDER BY output_index ASC
+		LIMIT $5`,
+		exportRange.AppContract, exportRange.FromInput, exportRange.toInput(), afterIndex(after), limit,
+	)
+	return reports, err
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
 )
@@ -29,20 +30,8 @@ type RawInputRef struct {
 }
 
 func (r *RawInputRefRepository) CreateTables(ctx context.Context) error {
-	schema := `CREATE TABLE IF NOT EXISTS convenience_input_raw_references (
-		id 				text NOT NULL,
-		app_id 			integer NOT NULL,
-		input_index		integer NOT NULL,
-		app_contract    text NOT NULL,
-		status	 		text,
-		chain_id        text,
-		created_at		TIMESTAMP NOT NULL);
-	CREATE INDEX IF NOT EXISTS idx_input_index ON convenience_input_raw_references(input_index, app_contract);
-	CREATE INDEX IF NOT EXISTS idx_app_id_input_index ON convenience_input_raw_references(app_id, input_index);
-	CREATE INDEX IF NOT EXISTS idx_convenience_input_raw_references_status_raw_id ON convenience_input_raw_references(status, app_id);
-	CREATE INDEX IF NOT EXISTS idx_status ON convenience_input_raw_references(status);`
-
-	_, err := r.Db.ExecContext(ctx, schema)
+	// the tables are versioned by the migrations package
+	err := migrations.CheckSchema(ctx, r.Db)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create tables", "error", err)
 		return err
@@ -101,8 +90,9 @@ func (r *RawInputRefRepository) Create(ctx context.Context, rawInput RawInputRef
 }
 
 func (r *RawInputRefRepository) GetLatestInputRef(ctx context.Context) (*RawInputRef, error) {
+	exec := DBExecutor{r.Db}
 	var inputRef RawInputRef
-	err := r.Db.GetContext(ctx, &inputRef, `
+	err := exec.GetContext(ctx, &inputRef, `
 		SELECT * FROM convenience_input_raw_references
 		ORDER BY
 			created_at DESC, input_index DESC, app_id DESC
@@ -125,12 +115,53 @@ func (r *RawInputRefRepository) GetLatestInputRef(ctx context.Context) (*RawInpu
 	return &inputRef, nil
 }
 
+// GetLatestInputRefByAppID returns the checkpoint of the input sync for one application.
+func (r *RawInputRefRepository) GetLatestInputRefByAppID(ctx context.Context, appID uint64) (*RawInputRef, error) {
+	exec := DBExecutor{r.Db}
+	var inputRef RawInputRef
+	err := exec.GetContext(ctx, &inputRef, `
+		SELECT * FROM convenience_input_raw_references
+		WHERE app_id = $1
+		ORDER BY input_index DESC
+		LIMIT 1
+	`, appID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Failed to get latest raw input ref by app", "app_id", appID, "err", err)
+		return nil, err
+	}
+	return &inputRef, nil
+}
+
+// FindFirstInputByStatusNoneByAppID returns the first input of the application waiting for a status.
+func (r *RawInputRefRepository) FindFirstInputByStatusNoneByAppID(ctx context.Context, appID uint64) (*RawInputRef, error) {
+	exec := DBExecutor{r.Db}
+	var row RawInputRef
+	err := exec.GetContext(ctx, &row, `
+		SELECT * FROM convenience_input_raw_references
+		WHERE status = 'NONE' AND app_id = $1
+		ORDER BY input_index ASC
+		LIMIT 1`, appID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Failed to find input with status NONE by app", "app_id", appID, "error", err)
+		return nil, err
+	}
+	return &row, nil
+}
+
 func (r *RawInputRefRepository) FindFirstInputByStatusNone(ctx context.Context) (*RawInputRef, error) {
+	exec := DBExecutor{r.Db}
 	query := `SELECT * FROM convenience_input_raw_references
 			  WHERE status = 'NONE'
 			  ORDER BY created_at ASC, input_index ASC, app_id ASC LIMIT 1`
 
-	stmt, err := r.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to prepare query for status NONE", "query", query, "error", err)
 		return nil, err
@@ -155,8 +186,9 @@ func (r *RawInputRefRepository) FindFirstInputByStatusNone(ctx context.Context)
 }
 
 func (r *RawInputRefRepository) FindByInputIndexAndAppContract(ctx context.Context, inputIndex uint64, appContract *common.Address) (*RawInputRef, error) {
+	exec := DBExecutor{r.Db}
 	var inputRef RawInputRef
-	err := r.Db.GetContext(ctx, &inputRef, `
+	err := exec.GetContext(ctx, &inputRef, `
 		SELECT * FROM convenience_input_raw_references
 		WHERE input_index = $1 and app_contract = $2
 		LIMIT 1`, inputIndex, appContract.Hex())
@@ -8,6 +8,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	configtest "github.com/cartesi/rollups-graphql/v2/pkg/convenience/config_test"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -43,6 +44,7 @@ func (s *RawInputRefSuite) SetupTest() {
 	s.dbFactory, err = commons.NewDbFactory()
 	s.Require().NoError(err)
 	s.db = s.dbFactory.CreateDb(s.ctx, "input.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.inputRepository = &InputRepository{
 		Db: s.db,
 	}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -41,40 +42,14 @@ type inputRow struct {
 }
 
 func (r *InputRepository) CreateTables(ctx context.Context) error {
-
-	// the ID is not unique anymore in a multi-dapp environment
-	schema := `CREATE TABLE IF NOT EXISTS convenience_inputs (
-		id 				text NOT NULL,
-		input_index		integer,
-		app_contract    text,
-		status	 		text,
-		msg_sender	 	text,
-		payload			text,
-		block_number	integer,
-		block_timestamp	NUMERIC,
-		prev_randao		text,
-		exception		text,
-		espresso_block_number	integer,
-		espresso_block_timestamp	NUMERIC,
-		input_box_index integer,
-		avail_block_number integer,
-		avail_block_timestamp NUMERIC,
-		type text,
-		cartesi_transaction_id text,
-		chain_id text);
-
-	CREATE INDEX IF NOT EXISTS idx_input_index ON convenience_inputs(input_index);
-	CREATE INDEX IF NOT EXISTS idx_status ON convenience_inputs(status);
-	CREATE INDEX IF NOT EXISTS idx_input_id ON convenience_inputs(app_contract, id);
-	CREATE INDEX IF NOT EXISTS idx_status_app_contract ON convenience_inputs(status, app_contract);
-	CREATE INDEX IF NOT EXISTS idx_input_index_app_contract ON convenience_inputs(input_index, app_contract);`
-	_, err := r.Db.ExecContext(ctx, schema)
-	if err == nil {
-		slog.DebugContext(ctx, "Inputs table created")
-	} else {
+	// the tables are versioned by the migrations package
+	err := migrations.CheckSchema(ctx, r.Db)
+	if err != nil {
 		slog.ErrorContext(ctx, "Create table error", "error", err)
+		return err
 	}
-	return err
+	slog.DebugContext(ctx, "Inputs table created")
+	return nil
 }
 
 func (r *InputRepository) Create(ctx context.Context, input model.AdvanceInput) (*model.AdvanceInput, error) {
@@ -221,6 +196,7 @@ func (r *InputRepository) Update(ctx context.Context, input model.AdvanceInput)
 }
 
 func (r *InputRepository) FindByStatusNeDesc(ctx context.Context, status model.CompletionStatus) (*model.AdvanceInput, error) {
+	exec := DBExecutor{r.Db}
 	sql := `SELECT
 		id,
 		input_index,
@@ -239,7 +215,7 @@ func (r *InputRepository) FindByStatusNeDesc(ctx context.Context, status model.C
 		type,
 		chain_id FROM convenience_inputs WHERE status <> $1
 		ORDER BY input_index DESC`
-	res, err := r.Db.QueryxContext(
+	res, err := exec.QueryxContext(
 		ctx,
 		sql,
 		status,
@@ -259,6 +235,7 @@ func (r *InputRepository) FindByStatusNeDesc(ctx context.Context, status model.C
 }
 
 func (r *InputRepository) FindByStatus(ctx context.Context, status model.CompletionStatus) (*model.AdvanceInput, error) {
+	exec := DBExecutor{r.Db}
 	sql := `SELECT
 			id,
 			input_index,
@@ -279,7 +256,7 @@ func (r *InputRepository) FindByStatus(ctx context.Context, status model.Complet
 			chain_id
 		FROM convenience_inputs WHERE status = $1
 		ORDER BY input_index ASC`
-	res, err := r.Db.QueryxContext(
+	res, err := exec.QueryxContext(
 		ctx,
 		sql,
 		status,
@@ -337,9 +314,10 @@ func (r *InputRepository) queryByIdAndAppContract(
 	ctx context.Context,
 	id string,
 	appContract *common.Address,
-) (*sqlx.Rows, error) {
+) (*Rows, error) {
+	exec := DBExecutor{r.Db}
 	if appContract != nil {
-		return r.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -364,7 +342,7 @@ func (r *InputRepository) queryByIdAndAppContract(
 			appContract.Hex(),
 		)
 	} else {
-		return r.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -394,9 +372,10 @@ func (r *InputRepository) queryByIndexAndAppContract(
 	ctx context.Context,
 	id int,
 	appContract *common.Address,
-) (*sqlx.Rows, error) {
+) (*Rows, error) {
+	exec := DBExecutor{r.Db}
 	if appContract != nil {
-		return r.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -421,7 +400,7 @@ func (r *InputRepository) queryByIndexAndAppContract(
 			appContract.Hex(),
 		)
 	} else {
-		return r.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT
 				id,
 				input_index,
@@ -452,9 +431,10 @@ func (c *InputRepository) GetNonce(
 	appContract common.Address,
 	msgSender common.Address,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT count(*) FROM convenience_inputs
 	WHERE app_contract = $1 and msg_sender = $2`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Count execution error")
 		return 0, err
@@ -473,6 +453,7 @@ func (c *InputRepository) Count(
 	ctx context.Context,
 	filter []*model.ConvenienceFilter,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT count(*) FROM convenience_inputs `
 	where, args, _, err := transformToInputQuery(filter)
 	if err != nil {
@@ -481,7 +462,7 @@ func (c *InputRepository) Count(
 	}
 	query += where
 	slog.DebugContext(ctx, "Query", "query", query, "args", args)
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Count execution error")
 		return 0, err
@@ -504,6 +485,7 @@ func (c *InputRepository) FindAll(
 	before *string,
 	filter []*model.ConvenienceFilter,
 ) (*commons.PageResult[model.AdvanceInput], error) {
+	exec := DBExecutor{c.Db}
 	total, err := c.Count(ctx, filter)
 	if err != nil {
 		slog.ErrorContext(ctx, "database error", "err", err)
@@ -548,7 +530,7 @@ func (c *InputRepository) FindAll(
 	args = append(args, offset)
 
 	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Find all error", "error", err)
 		return nil, err
@@ -685,7 +667,7 @@ func parseRowInput(row inputRow) model.AdvanceInput {
 	}
 }
 
-func parseInput(res *sqlx.Rows) (*model.AdvanceInput, error) {
+func parseInput(res *Rows) (*model.AdvanceInput, error) {
 	var (
 		input                  model.AdvanceInput
 		msgSender              string
@@ -734,6 +716,7 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 	ctx context.Context,
 	filters []*BatchFilterItem,
 ) ([]*model.AdvanceInput, []error) {
+	exec := DBExecutor{c.Db}
 	slog.DebugContext(ctx, "BatchFindInputByInputIndexAndAppContract", "len", len(filters))
 
 	query := `SELECT
@@ -770,7 +753,7 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 
 	errors := []error{}
 	results := []*model.AdvanceInput{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "BatchFind prepare context", "error", err)
 		return nil, errors
@@ -806,3 +789,49 @@ func (c *InputRepository) BatchFindInputByInputIndexAndAppContract(
 func GenerateBatchInputKey(appContract string, inputIndex uint64) string {
 	return fmt.Sprintf("%s|%d", appContract, inputIndex)
 }
+
+// StreamByAppContract calls fn with the inputs of the application from the given index,
+// reading them from a single database cursor, so a slow fn slows down the reads.
+func (c *InputRepository) StreamByAppContract(
+	ctx context.Context,
+	appContract string,
+	fromIndex uint64,
+	fn func(input model.AdvanceInput) error,
+) error {
+	exec := DBExecutor{c.Db}
+	rows, err := exec.QueryxContext(ctx, `SELECT
+			id,
+			input_index,
+			status,
+			msg_sender,
+			payload,
+			block_number,
+			block_timestamp,
+			prev_randao,
+			exception,
+			app_contract,
+			espresso_block_number,
+			espresso_block_timestamp,
+			input_box_index,
+			avail_block_number,
+			avail_block_timestamp,
+			type,
+			chain_id
+		FROM convenience_inputs
+		WHERE app_contract = $1 AND input_index >= $2
+		ORDER BY input_index ASC`, appContract, fromIndex)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row inputRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(parseRowInput(row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -11,6 +11,7 @@ import (
 	convenience "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/suite"
 )
@@ -32,6 +33,7 @@ func (s *InputRepositorySuite) SetupTest() {
 	s.dbFactory, err = commons.NewDbFactory()
 	s.Require().NoError(err)
 	db := s.dbFactory.CreateDb(s.ctx, "input.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, db))
 	s.inputRepository = &InputRepository{
 		Db: db,
 	}
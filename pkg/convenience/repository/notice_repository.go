@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -21,22 +22,8 @@ type NoticeRepository struct {
 }
 
 func (c *NoticeRepository) CreateTables(ctx context.Context) error {
-	schema := `CREATE TABLE IF NOT EXISTS convenience_notices (
-		payload 		text,
-		input_index		integer,
-		output_index	integer,
-		app_contract    text,
-		output_hashes_siblings text,
-		proof_output_index integer DEFAULT 0,
-		PRIMARY KEY (input_index, output_index, app_contract)
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_app_contract_input_index ON convenience_notices(app_contract, input_index);
-	CREATE INDEX IF NOT EXISTS idx_app_contract_output_index ON convenience_notices(app_contract, output_index);
-	CREATE INDEX IF NOT EXISTS idx_input_index_output_index ON convenience_notices(input_index, output_index);`
-	// execute a query on the server
-	_, err := c.Db.ExecContext(ctx, schema)
-	return err
+	// the tables are versioned by the migrations package
+	return migrations.CheckSchema(ctx, c.Db)
 }
 
 func (c *NoticeRepository) Create(
@@ -142,6 +129,7 @@ func (c *NoticeRepository) Count(
 	ctx context.Context,
 	filter []*model.ConvenienceFilter,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT count(*) FROM convenience_notices `
 	where, args, _, err := transformToNoticeQuery(filter)
 	if err != nil {
@@ -149,7 +137,7 @@ func (c *NoticeRepository) Count(
 	}
 	query += where
 	slog.DebugContext(ctx, "Query", "query", query, "args", args)
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -170,6 +158,7 @@ func (c *NoticeRepository) FindAllNotices(
 	before *string,
 	filter []*model.ConvenienceFilter,
 ) (*commons.PageResult[model.ConvenienceNotice], error) {
+	exec := DBExecutor{c.Db}
 	total, err := c.Count(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -192,7 +181,7 @@ func (c *NoticeRepository) FindAllNotices(
 	args = append(args, offset)
 
 	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -213,7 +202,8 @@ func (c *NoticeRepository) FindAllNotices(
 func (c *NoticeRepository) FindAllNoticesByBlockNumber(
 	ctx context.Context, startBlockGte uint64, endBlockLt uint64,
 ) ([]*model.ConvenienceNotice, error) {
-	stmt, err := c.Db.Preparex(`
+	exec := DBExecutor{c.Db}
+	stmt, err := exec.PreparexContext(ctx, `
 		SELECT
 			n.payload,
 			n.input_index,
@@ -269,9 +259,10 @@ func (c *NoticeRepository) queryByOutputIndexAndAppContract(
 	ctx context.Context,
 	outputIndex uint64,
 	appContract *common.Address,
-) (*sqlx.Rows, error) {
+) (*Rows, error) {
+	exec := DBExecutor{c.Db}
 	if appContract != nil {
-		return c.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT * FROM convenience_notices
 			WHERE output_index = $1 and app_contract = $2
 			LIMIT 1`,
@@ -279,7 +270,7 @@ func (c *NoticeRepository) queryByOutputIndexAndAppContract(
 			appContract.Hex(),
 		)
 	} else {
-		return c.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT * FROM convenience_notices
 			WHERE output_index = $1
 			LIMIT 1`,
@@ -291,8 +282,9 @@ func (c *NoticeRepository) queryByOutputIndexAndAppContract(
 func (c *NoticeRepository) FindByInputAndOutputIndex(
 	ctx context.Context, inputIndex uint64, outputIndex uint64,
 ) (*model.ConvenienceNotice, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT * FROM convenience_notices WHERE input_index = $1 and output_index = $2 LIMIT 1`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -352,69 +344,81 @@ func transformToNoticeQuery(
 type BatchFilterItemForNotice struct {
 	AppContract string
 	InputIndex  int
+	// Page of the rows of the input, all of them by default
+	BatchPage
 }
 
 func (c *NoticeRepository) BatchFindAllNoticesByInputIndexAndAppContract(
 	ctx context.Context,
 	filters []*BatchFilterItemForNotice,
 ) ([]*commons.PageResult[model.ConvenienceNotice], []error) {
+	exec := DBExecutor{c.Db}
 	slog.DebugContext(ctx, "BatchFindAllNoticesByInputIndexAndAppContract", "len", len(filters))
-
-	query := `SELECT * FROM convenience_notices WHERE `
-
-	args := []interface{}{}
-	where := []string{}
-
+	table := "convenience_notices"
+	items := make([]batchItem, len(filters))
 	for i, filter := range filters {
-		// nolint
-		where = append(where, fmt.Sprintf(" (app_contract = $%d and input_index = $%d) ", i*2+1, i*2+2))
-		args = append(args, filter.AppContract)
-		args = append(args, filter.InputIndex)
+		items[i] = batchItem{filter.AppContract, filter.InputIndex, filter.BatchPage}
 	}
+	query, args := batchPageQuery(table, items)
 
-	query += strings.Join(where, " or ")
-
-	errors := []error{}
-	results := []*commons.PageResult[model.ConvenienceNotice]{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
-	if err != nil {
-		slog.ErrorContext(ctx, "BatchFind prepare context", "error", err)
-		return nil, errors
+	type batchNoticeRow struct {
+		model.ConvenienceNotice
+		batchColumns
 	}
-	defer stmt.Close()
-
-	var notices []model.ConvenienceNotice
-
-	err = stmt.SelectContext(ctx, &notices, args...)
-	if err != nil {
+	var rows []batchNoticeRow
+	if err := exec.SelectContext(ctx, &rows, query, args...); err != nil {
 		slog.ErrorContext(ctx, "BatchFind", "error", err)
-		return nil, errors
+		return nil, []error{err}
 	}
-
-	noticeMap := make(map[string]*commons.PageResult[model.ConvenienceNotice])
-	for _, notice := range notices {
-		key := GenerateBatchNoticeKey(notice.AppContract, notice.InputIndex)
-
-		if noticeMap[key] == nil {
-			noticeMap[key] = &commons.PageResult[model.ConvenienceNotice]{}
+	results, err := batchPages(ctx, &exec, table, items, rows, func(row batchNoticeRow) (string, batchColumns) {
+		return GenerateBatchNoticeKey(row.AppContract, row.InputIndex), row.batchColumns
+	})
+	if err != nil {
+		return nil, []error{err}
+	}
+	notices := make([]*commons.PageResult[model.ConvenienceNotice], len(results))
+	for i, result := range results {
+		notices[i] = &commons.PageResult[model.ConvenienceNotice]{
+			Total:  result.Total,
+			Offset: result.Offset,
+			Rows:   make([]model.ConvenienceNotice, len(result.Rows)),
 		}
-		noticeMap[key].Total += 1
-		noticeMap[key].Rows = append(noticeMap[key].Rows, notice)
-	}
-
-	for _, filter := range filters {
-		key := GenerateBatchNoticeKey(filter.AppContract, uint64(filter.InputIndex))
-		noticesItem := noticeMap[key]
-		if noticesItem == nil {
-			noticesItem = &commons.PageResult[model.ConvenienceNotice]{}
+		for j, row := range result.Rows {
+			notices[i].Rows[j] = row.ConvenienceNotice
 		}
-		results = append(results, noticesItem)
 	}
-
-	slog.DebugContext(ctx, "BatchResult", "results", len(results))
-	return results, nil
+	slog.DebugContext(ctx, "BatchResult", "results", len(notices))
+	return notices, nil
 }
 
 func GenerateBatchNoticeKey(appContract string, inputIndex uint64) string {
 	return fmt.Sprintf("%s|%d", appContract, inputIndex)
 }
+
+// StreamByAppContract calls fn with the notices of the application from the given output index,
+// reading them from a single database cursor.
+func (c *NoticeRepository) StreamByAppContract(
+	ctx context.Context,
+	appContract string,
+	fromIndex uint64,
+	fn func(notice model.ConvenienceNotice) error,
+) error {
+	exec := DBExecutor{c.Db}
+	rows, err := exec.QueryxContext(ctx, `SELECT * FROM convenience_notices
+		WHERE app_contract = $1 AND output_index >= $2
+		ORDER BY output_index ASC`, appContract, fromIndex)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var notice model.ConvenienceNotice
+		if err := rows.StructScan(&notice); err != nil {
+			return err
+		}
+		if err := fn(notice); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
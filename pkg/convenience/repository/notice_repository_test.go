@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -26,6 +27,7 @@ func (s *NoticeRepositorySuite) SetupTest() {
 	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
 	commons.ConfigureLog(slog.LevelDebug)
 	s.db = sqlx.MustConnect("sqlite3", ":memory:")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.repository = &NoticeRepository{
 		Db: s.db,
 	}
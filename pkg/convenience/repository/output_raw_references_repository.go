@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -32,29 +33,14 @@ type RawOutputRef struct {
 }
 
 func (r *RawOutputRefRepository) CreateTable(ctx context.Context) error {
-	schema := `CREATE TABLE IF NOT EXISTS convenience_output_raw_references (
-		app_id 			integer NOT NULL,
-		input_index		integer NOT NULL,
-		app_contract	text NOT NULL,
-		output_index	integer NOT NULL,
-		has_proof		BOOLEAN,
-		type			text NOT NULL CHECK (type IN ('voucher', 'notice')),
-		executed		BOOLEAN,
-		updated_at		TIMESTAMP NOT NULL,
-		created_at		TIMESTAMP NOT NULL,
-		sync_priority	integer   NOT NULL,
-		PRIMARY KEY (input_index, output_index, app_contract));
-
-		CREATE INDEX IF NOT EXISTS idx_input_index ON convenience_output_raw_references(input_index, app_contract);
-		CREATE INDEX IF NOT EXISTS idx_convenience_output_raw_references_app_id ON convenience_output_raw_references(app_id);
-		CREATE INDEX IF NOT EXISTS idx_convenience_output_raw_references_has_proof_app_id ON convenience_output_raw_references(has_proof, app_id);`
-	_, err := r.Db.ExecContext(ctx, schema)
+	// the tables are versioned by the migrations package
+	err := migrations.CheckSchema(ctx, r.Db)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to create Raw Outputs table", "error", err)
-	} else {
-		slog.DebugContext(ctx, "Raw Outputs table created successfully")
+		return err
 	}
-	return err
+	slog.DebugContext(ctx, "Raw Outputs table created successfully")
+	return nil
 }
 
 func (r *RawOutputRefRepository) Create(ctx context.Context, rawOutput RawOutputRef) error {
@@ -111,8 +97,9 @@ func (r *RawOutputRefRepository) Create(ctx context.Context, rawOutput RawOutput
 }
 
 func (r *RawOutputRefRepository) FindLatestRawOutputRef(ctx context.Context) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
 	var outputRef RawOutputRef
-	err := r.Db.GetContext(ctx, &outputRef, `
+	err := exec.GetContext(ctx, &outputRef, `
 		SELECT * FROM convenience_output_raw_references
 		ORDER BY created_at DESC, output_index DESC, app_id DESC
 		LIMIT 1
@@ -220,8 +207,9 @@ func (r *RawOutputRefRepository) SetExecutedToTrue(ctx context.Context, rawOutpu
 }
 
 func (r *RawOutputRefRepository) FindByAppIDAndOutputIndex(ctx context.Context, appID, outputIndex uint64) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
 	var outputRef RawOutputRef
-	err := r.Db.GetContext(ctx, &outputRef, `
+	err := exec.GetContext(ctx, &outputRef, `
 		SELECT * FROM convenience_output_raw_references
 		WHERE app_id = $1 and output_index = $2`, appID, outputIndex)
 
@@ -271,8 +259,9 @@ func (r *RawOutputRefRepository) UpdateSyncPriority(ctx context.Context, rawOutp
 }
 
 func (r *RawOutputRefRepository) GetFirstOutputRefWithoutProof(ctx context.Context) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
 	var outputRef RawOutputRef
-	err := r.Db.GetContext(ctx, &outputRef, `
+	err := exec.GetContext(ctx, &outputRef, `
 		SELECT
 			*
 		FROM
@@ -295,8 +284,9 @@ func (r *RawOutputRefRepository) GetFirstOutputRefWithoutProof(ctx context.Conte
 }
 
 func (r *RawOutputRefRepository) GetLastUpdatedAtExecuted(ctx context.Context) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
 	var outputRef RawOutputRef
-	err := r.Db.GetContext(ctx, &outputRef, `
+	err := exec.GetContext(ctx, &outputRef, `
 		SELECT
 			*
 		FROM
@@ -315,3 +305,69 @@ func (r *RawOutputRefRepository) GetLastUpdatedAtExecuted(ctx context.Context) (
 	}
 	return &outputRef, err
 }
+
+// FindLatestRawOutputRefByAppID returns the checkpoint of the output sync for one application.
+func (r *RawOutputRefRepository) FindLatestRawOutputRefByAppID(ctx context.Context, appID uint64) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
+	var outputRef RawOutputRef
+	err := exec.GetContext(ctx, &outputRef, `
+		SELECT * FROM convenience_output_raw_references
+		WHERE app_id = $1
+		ORDER BY output_index DESC
+		LIMIT 1`, appID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "RollupsGraphql: FindLatestRawOutputRefByAppID Failed to retrieve the latest output", "app_id", appID, "error", err)
+		return nil, err
+	}
+	return &outputRef, nil
+}
+
+func (r *RawOutputRefRepository) GetFirstOutputRefWithoutProofByAppID(ctx context.Context, appID uint64) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
+	var outputRef RawOutputRef
+	err := exec.GetContext(ctx, &outputRef, `
+		SELECT
+			*
+		FROM
+			convenience_output_raw_references
+		WHERE
+			has_proof = false AND app_id = $1
+		ORDER BY
+			sync_priority ASC, output_index ASC
+		LIMIT 1`, appID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "RollupsGraphql: GetFirstOutputRefWithoutProofByAppID Failed to retrieve output without proof", "app_id", appID, "error", err)
+		return nil, err
+	}
+	return &outputRef, nil
+}
+
+func (r *RawOutputRefRepository) GetLastUpdatedAtExecutedByAppID(ctx context.Context, appID uint64) (*RawOutputRef, error) {
+	exec := DBExecutor{r.Db}
+	var outputRef RawOutputRef
+	err := exec.GetContext(ctx, &outputRef, `
+		SELECT
+			*
+		FROM
+			convenience_output_raw_references
+		WHERE
+			executed = true AND type = 'voucher' AND app_id = $1
+		ORDER BY updated_at DESC, output_index DESC LIMIT 1`, appID)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "RollupsGraphql: GetLastUpdatedAtExecutedByAppID Failed to retrieve executed output", "app_id", appID, "error", err)
+		return nil, err
+	}
+	return &outputRef, nil
+}
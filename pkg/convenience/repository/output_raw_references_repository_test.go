@@ -8,6 +8,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	configtest "github.com/cartesi/rollups-graphql/v2/pkg/convenience/config_test"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -36,6 +37,7 @@ func (s *RawOutputRefSuite) SetupTest() {
 	s.dbFactory, err = commons.NewDbFactory()
 	s.Require().NoError(err)
 	db := s.dbFactory.CreateDb(s.ctx, "input.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, db))
 	s.noticeRepository = &NoticeRepository{
 		Db: db,
 	}
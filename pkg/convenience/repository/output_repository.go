@@ -26,13 +26,14 @@ func (c *OutputRepository) CountProofs(ctx context.Context) (uint64, error) {
 }
 
 func (c *OutputRepository) CountNoticeProofs(ctx context.Context) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `
 		SELECT COUNT(*) FROM convenience_notices
 		WHERE
 			output_hashes_siblings is not null
 			and output_hashes_siblings <> ''
 		`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "query error")
 		return 0, err
@@ -47,13 +48,14 @@ func (c *OutputRepository) CountNoticeProofs(ctx context.Context) (uint64, error
 }
 
 func (c *OutputRepository) CountVoucherProofs(ctx context.Context) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `
 		SELECT COUNT(*) FROM convenience_vouchers
 		WHERE
 			output_hashes_siblings is not null
 			and output_hashes_siblings <> ''
 		`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "query error")
 		return 0, err
@@ -86,8 +88,9 @@ func (c *OutputRepository) CountAllOutputs(
 func (c *OutputRepository) CountAllVouchers(
 	ctx context.Context,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT COUNT(*) FROM convenience_vouchers`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "query error")
 		return 0, err
@@ -104,8 +107,9 @@ func (c *OutputRepository) CountAllVouchers(
 func (c *OutputRepository) CountAllNotices(
 	ctx context.Context,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT COUNT(*) FROM convenience_notices`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "query error")
 		return 0, err
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const readDBKey contextKey = "read_db"
+
+// Default interval between two checks of the lag of the replica
+const DEFAULT_LAG_CHECK_INTERVAL = time.Second
+
+// ReadRouter routes the reads of the API to a replica of the database.
+// While the replica lags behind the primary by more than MaxLag, or its lag cannot be read,
+// the reads fall back to the primary. The writes always go to the primary.
+// The lag is checked in the background by Start, so the requests never wait for it.
+type ReadRouter struct {
+	Primary *sqlx.DB
+	Replica *sqlx.DB
+	// Maximum lag of the replica, zero disables the guard
+	MaxLag time.Duration
+	// Interval between two checks of the lag, also the timeout of each check
+	CheckInterval time.Duration
+
+	// the reads go to the primary until the first check
+	lagging atomic.Bool
+	// only used by the checks of Start
+	checked bool
+}
+
+func NewReadRouter(primary *sqlx.DB, replica *sqlx.DB, maxLag time.Duration) *ReadRouter {
+	router := &ReadRouter{
+		Primary:       primary,
+		Replica:       replica,
+		MaxLag:        maxLag,
+		CheckInterval: DEFAULT_LAG_CHECK_INTERVAL,
+	}
+	router.lagging.Store(maxLag > 0)
+	return router
+}
+
+func (r *ReadRouter) String() string {
+	return "ReadRouter"
+}
+
+// Start checks the lag of the replica every CheckInterval while the guard is enabled.
+func (r *ReadRouter) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	if r.MaxLag <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(r.CheckInterval)
+	defer ticker.Stop()
+	for {
+		r.check(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// WithReadDB adds the database chosen for the reads to the context.
+func (r *ReadRouter) WithReadDB(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readDBKey, r.DB(ctx))
+}
+
+// DB returns the database the reads should go to.
+func (r *ReadRouter) DB(ctx context.Context) *sqlx.DB {
+	if r.MaxLag > 0 && r.lagging.Load() {
+		return r.Primary
+	}
+	return r.Replica
+}
+
+// check reads the lag of the replica, logging when the reads move between the databases.
+func (r *ReadRouter) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, r.CheckInterval)
+	defer cancel()
+	lag, err := ReplicaLag(ctx, r.Replica)
+	lagging := err != nil || lag > r.MaxLag
+	if r.lagging.Swap(lagging) == lagging && r.checked {
+		return
+	}
+	r.checked = true
+	switch {
+	case err != nil:
+		slog.WarnContext(ctx, "Failed to read the replica lag, reading from the primary", "error", err)
+	case lagging:
+		slog.WarnContext(ctx, "Replica lagging behind, reading from the primary", "lag", lag, "max_lag", r.MaxLag)
+	default:
+		slog.InfoContext(ctx, "Replica caught up, reading from the replica", "lag", lag)
+	}
+}
+
+// ReplicaLag returns how far behind the primary the replica is. A replica that replayed
+// all it received has no lag, even when the primary has not written for a while,
+// as long as it is streaming from the primary: a replica that lost the primary
+// cannot tell what it did not receive, so its lag cannot be read.
+func ReplicaLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	var seconds sql.NullFloat64
+	err := db.GetContext(ctx, &seconds, `SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN 0
+		WHEN NOT EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming') THEN NULL
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+		END`)
+	if err != nil {
+		return 0, err
+	}
+	if !seconds.Valid {
+		return 0, ErrReplicaNotStreaming
+	}
+	return time.Duration(seconds.Float64 * float64(time.Second)), nil
+}
+
+// ReadDB returns the database chosen for the reads of the context, db when none was.
+func ReadDB(ctx context.Context, db *sqlx.DB) *sqlx.DB {
+	if readDB, ok := ctx.Value(readDBKey).(*sqlx.DB); ok {
+		return readDB
+	}
+	return db
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -20,25 +21,14 @@ type ReportRepository struct {
 }
 
 func (r *ReportRepository) CreateTables(ctx context.Context) error {
-	schema := `CREATE TABLE IF NOT EXISTS convenience_reports (
-    output_index  integer,
-    payload       text,
-    input_index   integer,
-    app_contract  text,
-    app_id        integer,
-    PRIMARY KEY (input_index, output_index, app_contract)
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_input_index_output_index ON convenience_reports(input_index, output_index);
-	CREATE INDEX IF NOT EXISTS idx_input_index_app_contract ON convenience_reports(input_index, app_contract);
-	CREATE INDEX IF NOT EXISTS idx_output_index_app_contract ON convenience_reports(output_index, app_contract);`
-	_, err := r.Db.ExecContext(ctx, schema)
-	if err == nil {
-		slog.DebugContext(ctx, "Reports table created")
-	} else {
+	// the tables are versioned by the migrations package
+	err := migrations.CheckSchema(ctx, r.Db)
+	if err != nil {
 		slog.ErrorContext(ctx, "Create table error", "error", err)
+		return err
 	}
-	return err
+	slog.DebugContext(ctx, "Reports table created")
+	return nil
 }
 
 func (r *ReportRepository) CreateReport(ctx context.Context, report cModel.Report) (cModel.Report, error) {
@@ -119,9 +109,10 @@ func (r *ReportRepository) queryByOutputIndexAndAppContract(
 	ctx context.Context,
 	outputIndex uint64,
 	appContract *common.Address,
-) (*sqlx.Rows, error) {
+) (*Rows, error) {
+	exec := DBExecutor{r.Db}
 	if appContract != nil {
-		return r.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT payload, input_index FROM convenience_reports
 			WHERE output_index = $1 and app_contract = $2
 			LIMIT 1`,
@@ -129,7 +120,7 @@ func (r *ReportRepository) queryByOutputIndexAndAppContract(
 			appContract.Hex(),
 		)
 	} else {
-		return r.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT payload, input_index FROM convenience_reports
 			WHERE output_index = $1
 			LIMIT 1`,
@@ -139,9 +130,10 @@ func (r *ReportRepository) queryByOutputIndexAndAppContract(
 }
 
 func (r *ReportRepository) FindLastReport(ctx context.Context) (*cModel.FastReport, error) {
+	exec := DBExecutor{r.Db}
 	var report cModel.FastReport
-	err := r.Db.GetContext(ctx, &report, `
-		SELECT * FROM convenience_reports
+	err := exec.GetContext(ctx, &report, `
+		SELECT output_index, input_index, payload, app_contract, app_id FROM convenience_reports
 		ORDER BY
 			output_index DESC,
 			app_id DESC
@@ -156,12 +148,32 @@ func (r *ReportRepository) FindLastReport(ctx context.Context) (*cModel.FastRepo
 	return &report, err
 }
 
+// FindLastReportByAppID returns the checkpoint of the report sync for one application.
+func (r *ReportRepository) FindLastReportByAppID(ctx context.Context, appID uint64) (*cModel.FastReport, error) {
+	exec := DBExecutor{r.Db}
+	var report cModel.FastReport
+	err := exec.GetContext(ctx, &report, `
+		SELECT output_index, input_index, payload, app_contract, app_id FROM convenience_reports
+		WHERE app_id = $1
+		ORDER BY output_index DESC
+		LIMIT 1`, appID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Failed to retrieve the last report of the application", "app_id", appID, "error", err)
+		return nil, err
+	}
+	return &report, err
+}
+
 func (r *ReportRepository) FindByInputAndOutputIndex(
 	ctx context.Context,
 	inputIndex uint64,
 	outputIndex uint64,
 ) (*cModel.Report, error) {
-	rows, err := r.Db.QueryxContext(ctx, `
+	exec := DBExecutor{r.Db}
+	rows, err := exec.QueryxContext(ctx, `
 			SELECT payload FROM convenience_reports
 			WHERE input_index = $1 AND output_index = $2
 			LIMIT 1`,
@@ -227,6 +239,7 @@ func (r *ReportRepository) FindByOutputIndexAndAppContract(
 }
 
 func (r *ReportRepository) FindReportByAppContractAndIndex(ctx context.Context, index int, appContract common.Address) (*cModel.Report, error) {
+	exec := DBExecutor{r.Db}
 
 	query := `SELECT
 		input_index,
@@ -234,7 +247,7 @@ func (r *ReportRepository) FindReportByAppContractAndIndex(ctx context.Context,
 		payload,
 		app_contract FROM convenience_reports WHERE input_index = $1 AND app_contract = $2`
 
-	res, err := r.Db.QueryxContext(
+	res, err := exec.QueryxContext(
 		ctx,
 		query,
 		uint64(index),
@@ -260,6 +273,7 @@ func (c *ReportRepository) Count(
 	ctx context.Context,
 	filter []*cModel.ConvenienceFilter,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT count(*) FROM convenience_reports `
 	where, args, _, err := transformToReportQuery(filter)
 	if err != nil {
@@ -268,7 +282,7 @@ func (c *ReportRepository) Count(
 	}
 	query += where
 	slog.DebugContext(ctx, "Query", "query", query, "args", args)
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Count execution error")
 		return 0, err
@@ -318,13 +332,14 @@ func (c *ReportRepository) FindAll(
 	before *string,
 	filter []*cModel.ConvenienceFilter,
 ) (*commons.PageResult[cModel.Report], error) {
+	exec := DBExecutor{c.Db}
 	total, err := c.Count(ctx, filter)
 	if err != nil {
 		slog.ErrorContext(ctx, "database error", "err", err)
 		return nil, err
 	}
 
-	query := `SELECT input_index, output_index, payload FROM convenience_reports `
+	query := `SELECT input_index, output_index, payload, app_contract FROM convenience_reports `
 	where, args, argsCount, err := transformToReportQuery(filter)
 	if err != nil {
 		slog.ErrorContext(ctx, "database error", "err", err)
@@ -344,7 +359,7 @@ func (c *ReportRepository) FindAll(
 	args = append(args, offset)
 
 	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.PreparexContext(ctx, query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -361,13 +376,15 @@ func (c *ReportRepository) FindAll(
 		var payload string
 		var inputIndex int
 		var outputIndex int
-		if err := rows.Scan(&inputIndex, &outputIndex, &payload); err != nil {
+		var appContract string
+		if err := rows.Scan(&inputIndex, &outputIndex, &payload, &appContract); err != nil {
 			return nil, err
 		}
 		report := &cModel.Report{
-			InputIndex: inputIndex,
-			Index:      outputIndex,
-			Payload:    payload,
+			InputIndex:  inputIndex,
+			Index:       outputIndex,
+			Payload:     payload,
+			AppContract: common.HexToAddress(appContract),
 		}
 		reports = append(reports, *report)
 	}
@@ -430,90 +447,67 @@ func transformToReportQuery(
 type BatchFilterItem struct {
 	AppContract *common.Address
 	InputIndex  int
+	// Page of the rows of the input, all of them by default
+	BatchPage
 }
 
 func (c *ReportRepository) BatchFindAllByInputIndexAndAppContract(
 	ctx context.Context,
 	filters []*BatchFilterItem,
 ) ([]*commons.PageResult[cModel.Report], []error) {
+	exec := DBExecutor{c.Db}
 	slog.DebugContext(ctx, "BatchFindAllByInputIndexAndAppContract", "len", len(filters))
-	query := `SELECT
-				input_index, output_index, payload, app_contract FROM convenience_reports
-		WHERE
-	`
-	args := []interface{}{}
-	where := []string{}
+	table := `(SELECT input_index, output_index, payload, app_contract FROM convenience_reports)`
+	items := make([]batchItem, len(filters))
 	for i, filter := range filters {
-		// nolint
-		where = append(where, fmt.Sprintf(" (app_contract = $%d and input_index = $%d) ", i*2+1, i*2+2))
-		args = append(args, filter.AppContract.Hex())
-		args = append(args, filter.InputIndex)
-	}
-	query += strings.Join(where, " or ")
-
-	errors := []error{}
-	results := []*commons.PageResult[cModel.Report]{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
+		items[i] = batchItem{filter.AppContract.Hex(), filter.InputIndex, filter.BatchPage}
+	}
+	query, args := batchPageQuery(table, items)
+
+	type batchReportRow struct {
+		InputIndex  int    `db:"input_index"`
+		OutputIndex int    `db:"output_index"`
+		Payload     string `db:"payload"`
+		AppContract string `db:"app_contract"`
+		batchColumns
+	}
+	var rows []batchReportRow
+	if err := exec.SelectContext(ctx, &rows, query, args...); err != nil {
+		slog.ErrorContext(ctx, "BatchFind", "error", err)
+		return nil, []error{err}
+	}
+	results, err := batchPages(ctx, &exec, table, items, rows, func(row batchReportRow) (string, batchColumns) {
+		appContract := common.HexToAddress(row.AppContract)
+		return GenerateBatchReportKey(&appContract, row.InputIndex), row.batchColumns
+	})
 	if err != nil {
-		slog.ErrorContext(ctx, "BatchFind prepare context", "error", err)
-		return nil, errors
-	}
-	defer stmt.Close()
-
-	var reports []cModel.Report
-	rows, err := stmt.QueryxContext(ctx, args...)
-	if err != nil {
-		slog.ErrorContext(ctx, "BatchFind query context", "error", err)
-		return nil, errors
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var payload string
-		var inputIndex int
-		var outputIndex int
-		var appContract string
-		if err := rows.Scan(&inputIndex, &outputIndex, &payload, &appContract); err != nil {
-			return nil, errors
-		}
-		report := &cModel.Report{
-			InputIndex:  inputIndex,
-			Index:       outputIndex,
-			Payload:     payload,
-			AppContract: common.HexToAddress(appContract),
-		}
-		reports = append(reports, *report)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, errors
-	}
-	reportMap := make(map[string]*commons.PageResult[cModel.Report])
-	for _, report := range reports {
-		key := GenerateBatchReportKey(&report.AppContract, report.InputIndex)
-		if reportMap[key] == nil {
-			reportMap[key] = &commons.PageResult[cModel.Report]{}
+		return nil, []error{err}
+	}
+	reports := make([]*commons.PageResult[cModel.Report], len(results))
+	for i, result := range results {
+		reports[i] = &commons.PageResult[cModel.Report]{
+			Total:  result.Total,
+			Offset: result.Offset,
+			Rows:   make([]cModel.Report, len(result.Rows)),
 		}
-		reportMap[key].Total += 1
-		reportMap[key].Rows = append(reportMap[key].Rows, report)
-	}
-	for _, filter := range filters {
-		key := GenerateBatchReportKey(filter.AppContract, filter.InputIndex)
-		reportsItem := reportMap[key]
-		if reportsItem == nil {
-			reportsItem = &commons.PageResult[cModel.Report]{}
+		for j, row := range result.Rows {
+			reports[i].Rows[j] = cModel.Report{
+				InputIndex:  row.InputIndex,
+				Index:       row.OutputIndex,
+				Payload:     row.Payload,
+				AppContract: common.HexToAddress(row.AppContract),
+			}
 		}
-		results = append(results, reportsItem)
 	}
-	slog.DebugContext(ctx, "BatchResult", "len", len(results))
-	return results, nil
+	slog.DebugContext(ctx, "BatchResult", "len", len(reports))
+	return reports, nil
 }
 
 func GenerateBatchReportKey(appContract *common.Address, inputIndex int) string {
 	return fmt.Sprintf("%s|%d", appContract.Hex(), inputIndex)
 }
 
-func parseReport(res *sqlx.Rows) (*cModel.Report, error) {
+func parseReport(res *Rows) (*cModel.Report, error) {
 	var (
 		report      cModel.Report
 		payload     string
@@ -533,3 +527,32 @@ func parseReport(res *sqlx.Rows) (*cModel.Report, error) {
 	report.AppContract = common.HexToAddress(appContract)
 	return &report, nil
 }
+
+// StreamByAppContract calls fn with the reports of the application from the given index,
+// reading them from a single database cursor.
+func (r *ReportRepository) StreamByAppContract(
+	ctx context.Context,
+	appContract string,
+	fromIndex uint64,
+	fn func(report cModel.Report) error,
+) error {
+	exec := DBExecutor{r.Db}
+	rows, err := exec.QueryxContext(ctx, `SELECT output_index, input_index, payload
+		FROM convenience_reports
+		WHERE app_contract = $1 AND output_index >= $2
+		ORDER BY output_index ASC`, appContract, fromIndex)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		report := cModel.Report{AppContract: common.HexToAddress(appContract)}
+		if err := rows.Scan(&report.Index, &report.InputIndex, &report.Payload); err != nil {
+			return err
+		}
+		if err := fn(report); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	configtest "github.com/cartesi/rollups-graphql/v2/pkg/convenience/config_test"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -31,6 +32,7 @@ func (s *ReportRepositorySuite) SetupTest() {
 	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
 	commons.ConfigureLog(slog.LevelDebug)
 	s.db = sqlx.MustConnect("sqlite3", ":memory:")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.reportRepository = &ReportRepository{
 		Db: s.db,
 	}
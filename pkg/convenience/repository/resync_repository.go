@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+)
+
+// ResyncRepository deletes the synced rows of an application,
+// so the synchronizers fetch them again from the node database.
+type ResyncRepository struct {
+	Db *sqlx.DB
+}
+
+// DeleteAppFromInput deletes the rows and checkpoints of the application
+// from the given input index on, in the transaction of the context.
+func (r *ResyncRepository) DeleteAppFromInput(
+	ctx context.Context, appID uint64, appContract common.Address, fromInput uint64,
+) error {
+	exec := DBExecutor{r.Db}
+	queries := []struct {
+		table string
+		query string
+		key   any
+	}{
+		{"convenience_inputs", `DELETE FROM convenience_inputs WHERE app_contract = $1 AND input_index >= $2`, appContract.Hex()},
+		{"convenience_vouchers", `DELETE FROM convenience_vouchers WHERE app_contract = $1 AND input_index >= $2`, appContract.Hex()},
+		{"convenience_notices", `DELETE FROM convenience_notices WHERE app_contract = $1 AND input_index >= $2`, appContract.Hex()},
+		{"convenience_reports", `DELETE FROM convenience_reports WHERE app_contract = $1 AND input_index >= $2`, appContract.Hex()},
+		{"convenience_input_raw_references", `DELETE FROM convenience_input_raw_references WHERE app_id = $1 AND input_index >= $2`, appID},
+		{"convenience_output_raw_references", `DELETE FROM convenience_output_raw_references WHERE app_id = $1 AND input_index >= $2`, appID},
+		{"convenience_dead_letters", `DELETE FROM convenience_dead_letters WHERE app_id = $1 AND input_index >= $2`, appID},
+	}
+	for _, q := range queries {
+		res, err := exec.ExecContext(ctx, q.query, q.key, fromInput)
+		if err != nil {
+			return fmt.Errorf("failed to delete from %s: %w", q.table, err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		slog.DebugContext(ctx, "Resync deleted rows",
+			"table", q.table,
+			"app_contract", appContract.Hex(),
+			"from_input", fromInput,
+			"rows", affected,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResyncRepositorySuite struct {
+	suite.Suite
+	repository *ResyncRepository
+	tempDir    string
+	db         *sqlx.DB
+	ctx        context.Context
+}
+
+var (
+	resyncApp   = common.HexToAddress("0x5112cf49f2511ac7b13a032c4c62a48410fc28fb")
+	resyncOther = common.HexToAddress("0x75135d8adb7180640d29d822d9ad59e83e8695b2")
+)
+
+func (s *ResyncRepositorySuite) SetupTest() {
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "resync.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	s.repository = &ResyncRepository{Db: s.db}
+	s.Require().NoError((&InputRepository{Db: s.db}).CreateTables(s.ctx))
+	for _, app := range []struct {
+		id       uint64
+		contract common.Address
+	}{{1, resyncApp}, {2, resyncOther}} {
+		for index := uint64(0); index < 4; index++ {
+			s.insertRows(app.id, app.contract, index)
+		}
+	}
+}
+
+func (s *ResyncRepositorySuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestResyncRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ResyncRepositorySuite))
+}
+
+func (s *ResyncRepositorySuite) insertRows(appID uint64, appContract common.Address, index uint64) {
+	now := time.Now()
+	queries := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO convenience_inputs (id, input_index, app_contract) VALUES ($1, $2, $3)`,
+			[]any{index, index, appContract.Hex()}},
+		{`INSERT INTO convenience_vouchers (input_index, output_index, app_contract) VALUES ($1, $2, $3)`,
+			[]any{index, index, appContract.Hex()}},
+		{`INSERT INTO convenience_notices (input_index, output_index, app_contract) VALUES ($1, $2, $3)`,
+			[]any{index, index, appContract.Hex()}},
+		{`INSERT INTO convenience_reports (input_index, output_index, app_contract, app_id) VALUES ($1, $2, $3, $4)`,
+			[]any{index, index, appContract.Hex(), appID}},
+		{`INSERT INTO convenience_input_raw_references (id, app_id, input_index, app_contract, created_at)
+			VALUES ($1, $2, $3, $4, $5)`,
+			[]any{index, appID, index, appContract.Hex(), now}},
+		{`INSERT INTO convenience_output_raw_references
+			(app_id, input_index, app_contract, output_index, type, updated_at, created_at, sync_priority)
+			VALUES ($1, $2, $3, $4, 'voucher', $5, $5, 0)`,
+			[]any{appID, index, appContract.Hex(), index, now}},
+		{`INSERT INTO convenience_dead_letters
+			(kind, app_id, app_contract, raw_index, input_index, error, created_at, updated_at)
+			VALUES ('input', $1, $2, $3, $3, 'invalid', $4, $4)`,
+			[]any{appID, appContract.Hex(), index, now}},
+	}
+	for _, q := range queries {
+		_, err := s.db.Exec(q.query, q.args...)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *ResyncRepositorySuite) count(table string, column string, key any) int {
+	var count int
+	err := s.db.Get(&count, `SELECT count(*) FROM `+table+` WHERE `+column+` = $1`, key)
+	s.Require().NoError(err)
+	return count
+}
+
+func (s *ResyncRepositorySuite) TestDeleteAppFromInput() {
+	ctx, tx, err := StartTransactionContext(s.ctx, s.db)
+	s.Require().NoError(err)
+	err = s.repository.DeleteAppFromInput(ctx, 1, resyncApp, 2)
+	s.Require().NoError(err)
+	s.Require().NoError(tx.Commit())
+
+	for _, table := range []string{
+		"convenience_inputs", "convenience_vouchers", "convenience_notices", "convenience_reports",
+	} {
+		s.Equal(2, s.count(table, "app_contract", resyncApp.Hex()), table)
+		s.Equal(4, s.count(table, "app_contract", resyncOther.Hex()), table)
+	}
+	for _, table := range []string{
+		"convenience_input_raw_references", "convenience_output_raw_references", "convenience_dead_letters",
+	} {
+		s.Equal(2, s.count(table, "app_id", 1), table)
+		s.Equal(4, s.count(table, "app_id", 2), table)
+	}
+}
+
+func (s *ResyncRepositorySuite) TestDeleteAppFromInputRollback() {
+	ctx, tx, err := StartTransactionContext(s.ctx, s.db)
+	s.Require().NoError(err)
+	err = s.repository.DeleteAppFromInput(ctx, 1, resyncApp, 0)
+	s.Require().NoError(err)
+
+	// the transaction sees its own deletes
+	var latest *RawInputRef
+	latest, err = (&RawInputRefRepository{Db: s.db}).GetLatestInputRefByAppID(ctx, 1)
+	s.Require().NoError(err)
+	s.Nil(latest)
+
+	s.Require().NoError(tx.Rollback())
+	s.Equal(4, s.count("convenience_inputs", "app_contract", resyncApp.Hex()))
+}
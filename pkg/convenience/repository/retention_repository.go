@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Payload of the pruned rows
+const PRUNED_PAYLOAD = "0x"
+
+// RetentionRepository prunes rows in batches.
+// The rows are kept as tombstones, with an empty payload and pruned_at set,
+// since the pagination cursors are offsets that deleting rows would shift.
+type RetentionRepository struct {
+	Db *sqlx.DB
+}
+
+func (r *RetentionRepository) prune(ctx context.Context, query string, args ...any) (int64, error) {
+	exec := DBExecutor{r.Db}
+	res, err := exec.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// PruneReports prunes up to limit reports of the inputs with a block older than before.
+func (r *RetentionRepository) PruneReports(
+	ctx context.Context, appContract string, before time.Time, limit uint64,
+) (int64, error) {
+	return r.prune(ctx, `
+		UPDATE convenience_reports SET payload = $1, pruned_at = $2
+		WHERE app_contract = $3 AND output_index IN (
+			SELECT r.output_index FROM convenience_reports r
+			INNER JOIN convenience_inputs i
+				ON i.app_contract = r.app_contract AND i.input_index = r.input_index
+			WHERE r.app_contract = $3 AND r.pruned_at IS NULL AND i.block_timestamp < $4
+			LIMIT $5
+		)`,
+		PRUNED_PAYLOAD, time.Now(), appContract, before.UnixMilli(), limit,
+	)
+}
+
+// PruneExecutedVoucherPayloads prunes up to limit payloads of the executed vouchers
+// of the inputs with a block older than before.
+func (r *RetentionRepository) PruneExecutedVoucherPayloads(
+	ctx context.Context, appContract string, before time.Time, limit uint64,
+) (int64, error) {
+	return r.prune(ctx, `
+		UPDATE convenience_vouchers SET payload = $1, pruned_at = $2
+		WHERE app_contract = $3 AND output_index IN (
+			SELECT v.output_index FROM convenience_vouchers v
+			INNER JOIN convenience_inputs i
+				ON i.app_contract = v.app_contract AND i.input_index = v.input_index
+			WHERE v.app_contract = $3 AND v.executed AND v.pruned_at IS NULL AND i.block_timestamp < $4
+			LIMIT $5
+		)`,
+		PRUNED_PAYLOAD, time.Now(), appContract, before.UnixMilli(), limit,
+	)
+}
+
+// PruneInputs prunes up to limit inputs of the application.
+func (r *RetentionRepository) PruneInputs(ctx context.Context, appContract string, limit uint64) (int64, error) {
+	return r.prune(ctx, `
+		UPDATE convenience_inputs SET payload = $1, pruned_at = $2
+		WHERE app_contract = $3 AND input_index IN (
+			SELECT input_index FROM convenience_inputs
+			WHERE app_contract = $3 AND pruned_at IS NULL
+			LIMIT $4
+		)`,
+		PRUNED_PAYLOAD, time.Now(), appContract, limit,
+	)
+}
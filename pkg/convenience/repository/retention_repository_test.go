@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+type RetentionRepositorySuite struct {
+	suite.Suite
+	repository *RetentionRepository
+	tempDir    string
+	db         *sqlx.DB
+	ctx        context.Context
+	now        time.Time
+}
+
+const retentionApp = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+
+func (s *RetentionRepositorySuite) SetupTest() {
+	s.ctx = context.Background()
+	s.now = time.Now()
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "retention.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	s.repository = &RetentionRepository{Db: s.db}
+	s.Require().NoError((&InputRepository{Db: s.db}).CreateTables(s.ctx))
+	// input 0 is ten days old and input 1 is from today
+	for index, timestamp := range []time.Time{s.now.AddDate(0, 0, -10), s.now} {
+		queries := []struct {
+			query string
+			args  []any
+		}{
+			{`INSERT INTO convenience_inputs (id, input_index, app_contract, payload, block_timestamp)
+				VALUES ($1, $2, $3, '0x01', $4)`,
+				[]any{index, index, retentionApp, timestamp.UnixMilli()}},
+			{`INSERT INTO convenience_reports (input_index, output_index, app_contract, payload)
+				VALUES ($1, $2, $3, '0x02')`,
+				[]any{index, index, retentionApp}},
+			{`INSERT INTO convenience_vouchers (input_index, output_index, app_contract, payload, executed)
+				VALUES ($1, $2, $3, '0x03', true)`,
+				[]any{index, 2 * index, retentionApp}},
+			{`INSERT INTO convenience_vouchers (input_index, output_index, app_contract, payload, executed)
+				VALUES ($1, $2, $3, '0x03', false)`,
+				[]any{index, 2*index + 1, retentionApp}},
+		}
+		for _, q := range queries {
+			_, err := s.db.Exec(q.query, q.args...)
+			s.Require().NoError(err)
+		}
+	}
+}
+
+func (s *RetentionRepositorySuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestRetentionRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RetentionRepositorySuite))
+}
+
+func (s *RetentionRepositorySuite) payloads(table string) []string {
+	payloads := []string{}
+	err := s.db.Select(&payloads, `SELECT payload FROM `+table+` ORDER BY output_index`)
+	s.Require().NoError(err)
+	return payloads
+}
+
+func (s *RetentionRepositorySuite) TestPruneReports() {
+	pruned, err := s.repository.PruneReports(s.ctx, retentionApp, s.now.AddDate(0, 0, -7), 10)
+	s.Require().NoError(err)
+	s.Equal(int64(1), pruned)
+	s.Equal([]string{PRUNED_PAYLOAD, "0x02"}, s.payloads("convenience_reports"))
+
+	// the tombstones are kept and not pruned again
+	pruned, err = s.repository.PruneReports(s.ctx, retentionApp, s.now.AddDate(0, 0, -7), 10)
+	s.Require().NoError(err)
+	s.Zero(pruned)
+	count, err := (&ReportRepository{Db: s.db}).Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+}
+
+func (s *RetentionRepositorySuite) TestPruneExecutedVoucherPayloads() {
+	pruned, err := s.repository.PruneExecutedVoucherPayloads(s.ctx, retentionApp, s.now.Add(time.Minute), 10)
+	s.Require().NoError(err)
+	s.Equal(int64(2), pruned)
+	s.Equal([]string{PRUNED_PAYLOAD, "0x03", PRUNED_PAYLOAD, "0x03"}, s.payloads("convenience_vouchers"))
+}
+
+func (s *RetentionRepositorySuite) TestPruneInputsInBatches() {
+	pruned, err := s.repository.PruneInputs(s.ctx, retentionApp, 1)
+	s.Require().NoError(err)
+	s.Equal(int64(1), pruned)
+	pruned, err = s.repository.PruneInputs(s.ctx, retentionApp, 1)
+	s.Require().NoError(err)
+	s.Equal(int64(1), pruned)
+	pruned, err = s.repository.PruneInputs(s.ctx, "0x75135d8ADb7180640d29d822D9AD59E83E8695b2", 1)
+	s.Require().NoError(err)
+	s.Zero(pruned)
+
+	payloads := []string{}
+	s.Require().NoError(s.db.Select(&payloads, `SELECT payload FROM convenience_inputs`))
+	s.Equal([]string{PRUNED_PAYLOAD, PRUNED_PAYLOAD}, payloads)
+}
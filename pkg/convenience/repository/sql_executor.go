@@ -3,22 +3,201 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
 
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
 	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
+// DBExecutor runs the queries in the transaction of the context, if any,
+// so the reads of a synchronizer see the rows written earlier in its transaction.
+// Outside of a transaction the reads go to the database chosen for the context
+// by the ReadRouter, if any, while the writes always go to db.
+// Each query gets a span named after the repository method running it.
 type DBExecutor struct {
 	db *sqlx.DB
 }
 
 func (c *DBExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, c.db, query)
+	defer lockSnapshot(snapshotLock(ctx))()
 	tx, isTxEnable := GetTransaction(ctx)
 
+	var result sql.Result
+	var err error
 	if !isTxEnable {
 		slog.DebugContext(ctx, "Using ExecContext without transaction.")
-		return c.db.ExecContext(ctx, query, args...)
+		result, err = c.db.ExecContext(ctx, query, args...)
 	} else {
-		return tx.ExecContext(ctx, query, args...)
+		result, err = tx.ExecContext(ctx, query, args...)
 	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func (c *DBExecutor) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	ctx, span := startQuerySpan(ctx, c.db, query)
+	defer lockSnapshot(snapshotLock(ctx))()
+	var err error
+	if tx, ok := ctx.Value(transactionKey).(*sqlx.Tx); ok {
+		err = tx.GetContext(ctx, dest, query, args...)
+	} else {
+		err = ReadDB(ctx, c.db).GetContext(ctx, dest, query, args...)
+	}
+	// a missing row is an expected result
+	tracing.End(span, ignoreNoRows(err))
+	return err
+}
+
+func (c *DBExecutor) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	ctx, span := startQuerySpan(ctx, c.db, query)
+	defer lockSnapshot(snapshotLock(ctx))()
+	var err error
+	if tx, ok := ctx.Value(transactionKey).(*sqlx.Tx); ok {
+		err = tx.SelectContext(ctx, dest, query, args...)
+	} else {
+		err = ReadDB(ctx, c.db).SelectContext(ctx, dest, query, args...)
+	}
+	tracing.End(span, err)
+	return err
+}
+
+// QueryxContext traces the query until its first row, not the iteration of the rows.
+func (c *DBExecutor) QueryxContext(ctx context.Context, query string, args ...any) (*Rows, error) {
+	ctx, span := startQuerySpan(ctx, c.db, query)
+	unlock := lockSnapshot(snapshotLock(ctx))
+	var rows *sqlx.Rows
+	var err error
+	if tx, ok := ctx.Value(transactionKey).(*sqlx.Tx); ok {
+		rows, err = tx.QueryxContext(ctx, query, args...)
+	} else {
+		rows, err = ReadDB(ctx, c.db).QueryxContext(ctx, query, args...)
+	}
+	tracing.End(span, err)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	return &Rows{Rows: rows, unlock: unlock}, nil
+}
+
+// PreparexContext prepares a statement whose queries are traced like the ones of the executor.
+func (c *DBExecutor) PreparexContext(ctx context.Context, query string) (*Stmt, error) {
+	lock := snapshotLock(ctx)
+	defer lockSnapshot(lock)()
+	var stmt *sqlx.Stmt
+	var err error
+	if tx, ok := ctx.Value(transactionKey).(*sqlx.Tx); ok {
+		stmt, err = tx.PreparexContext(ctx, query)
+	} else {
+		stmt, err = ReadDB(ctx, c.db).PreparexContext(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Stmt{Stmt: stmt, db: c.db, query: query, lock: lock}, nil
+}
+
+// Rows are the rows of a query, holding the lock of the snapshot, if any, until closed.
+type Rows struct {
+	*sqlx.Rows
+	unlock func()
+}
+
+func (r *Rows) Close() error {
+	err := r.Rows.Close()
+	r.unlock()
+	return err
+}
+
+// Stmt is a prepared statement of the executor.
+type Stmt struct {
+	*sqlx.Stmt
+	db    *sqlx.DB
+	query string
+	// lock of the snapshot the statement was prepared in, if any
+	lock *sync.Mutex
+}
+
+func (s *Stmt) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, s.db, s.query)
+	defer lockSnapshot(s.lock)()
+	result, err := s.Stmt.ExecContext(ctx, args...)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *Stmt) GetContext(ctx context.Context, dest any, args ...any) error {
+	ctx, span := startQuerySpan(ctx, s.db, s.query)
+	defer lockSnapshot(s.lock)()
+	err := s.Stmt.GetContext(ctx, dest, args...)
+	tracing.End(span, ignoreNoRows(err))
+	return err
+}
+
+func (s *Stmt) SelectContext(ctx context.Context, dest any, args ...any) error {
+	ctx, span := startQuerySpan(ctx, s.db, s.query)
+	defer lockSnapshot(s.lock)()
+	err := s.Stmt.SelectContext(ctx, dest, args...)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *Stmt) QueryxContext(ctx context.Context, args ...any) (*Rows, error) {
+	ctx, span := startQuerySpan(ctx, s.db, s.query)
+	unlock := lockSnapshot(s.lock)
+	rows, err := s.Stmt.QueryxContext(ctx, args...)
+	tracing.End(span, err)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	return &Rows{Rows: rows, unlock: unlock}, nil
+}
+
+func (s *Stmt) Close() error {
+	defer lockSnapshot(s.lock)()
+	return s.Stmt.Close()
+}
+
+// startQuerySpan must be called by the method of the executor or statement
+// called by the repository method. The query is only traced inside a recording span,
+// e.g. of a request or a sync cycle, so the queries cost no lookup of the caller
+// while the tracing is disabled or the trace was not sampled.
+func startQuerySpan(ctx context.Context, db *sqlx.DB, query string) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, noop.Span{}
+	}
+	return tracing.Start(ctx, repositoryMethod(),
+		semconv.DBSystemKey.String(db.DriverName()),
+		semconv.DBQueryText(query),
+	)
+}
+
+// repositoryMethod returns the name of the repository method, e.g. VoucherRepository.FindAllVouchers.
+func repositoryMethod() string {
+	// skip repositoryMethod, startQuerySpan and the executor method
+	pc, _, _, ok := runtime.Caller(3)
+	if !ok {
+		return "repository.query"
+	}
+	name := runtime.FuncForPC(pc).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name, "repository.")
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
+
+func ignoreNoRows(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	return err
 }
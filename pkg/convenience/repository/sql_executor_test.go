@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	configtest "github.com/cartesi/rollups-graphql/v2/pkg/convenience/config_test"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type DBExecutorSuite struct {
+	suite.Suite
+	ctx        context.Context
+	db         *sqlx.DB
+	repository *ApplicationRepository
+	recorder   *tracetest.SpanRecorder
+	provider   trace.TracerProvider
+}
+
+func TestDBExecutorSuite(t *testing.T) {
+	suite.Run(t, new(DBExecutorSuite))
+}
+
+func (s *DBExecutorSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(s.T().TempDir(), "executor.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	s.repository = &ApplicationRepository{Db: s.db}
+	s.Require().NoError(s.repository.CreateTables(s.ctx))
+	s.recorder = tracetest.NewSpanRecorder()
+	s.provider = otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *DBExecutorSuite) TearDownTest() {
+	otel.SetTracerProvider(s.provider)
+	s.NoError(s.db.Close())
+}
+
+func (s *DBExecutorSuite) TestSpansNamedAfterTheRepositoryMethod() {
+	ctx, parent := tracing.Start(s.ctx, "request")
+	_, err := s.repository.Create(ctx, newApp())
+	s.Require().NoError(err)
+	address := common.HexToAddress(configtest.DEFAULT_TEST_APP_CONTRACT)
+	app, err := s.repository.FindAppByAppContract(ctx, &address)
+	s.Require().NoError(err)
+	s.Require().NotNil(app)
+	parent.End()
+
+	names := []string{}
+	for _, span := range s.recorder.Ended() {
+		if span.Name() == "request" {
+			continue
+		}
+		names = append(names, span.Name())
+		s.Contains(span.Attributes(), semconv.DBSystemKey.String("sqlite3"))
+	}
+	s.Contains(names, "ApplicationRepository.Create")
+	// the queries of the prepared statements are traced too
+	s.Contains(names, "ApplicationRepository.FindAppByAppContract")
+}
+
+func (s *DBExecutorSuite) TestNoSpansOutsideOfARecordingSpan() {
+	_, err := s.repository.Create(s.ctx, newApp())
+	s.Require().NoError(err)
+	s.Empty(s.recorder.Ended())
+
+	// a trace that was not sampled is not traced either
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(s.recorder),
+		sdktrace.WithSampler(sdktrace.NeverSample()),
+	))
+	ctx, parent := tracing.Start(s.ctx, "request")
+	defer parent.End()
+	_, err = s.repository.Count(ctx, nil)
+	s.Require().NoError(err)
+	s.Empty(s.recorder.Ended())
+}
+
+func (s *DBExecutorSuite) TestReadSnapshot() {
+	_, err := s.repository.Create(s.ctx, newApp())
+	s.Require().NoError(err)
+	ctx, tx, err := StartReadSnapshot(s.ctx, s.db)
+	s.Require().NoError(err)
+	count, err := s.repository.Count(ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count)
+
+	// a write outside of the snapshot is not seen by it
+	created := make(chan error)
+	go func() {
+		_, err := s.repository.Create(s.ctx, &model.ConvenienceApplication{
+			ID:                 2,
+			Name:               "other",
+			ApplicationAddress: common.HexToAddress("0x02").Hex(),
+		})
+		created <- err
+	}()
+	apps, err := s.repository.FindAll(ctx, nil, nil, nil, nil, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), apps.Total)
+	s.Len(apps.Rows, 1)
+	s.Require().NoError(tx.Rollback())
+
+	s.Require().NoError(<-created)
+	count, err = s.repository.Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+}
+
+func (s *DBExecutorSuite) TestReadSnapshotSerializesConcurrentQueries() {
+	_, err := s.repository.Create(s.ctx, newApp())
+	s.Require().NoError(err)
+	ctx, tx, err := StartReadSnapshot(s.ctx, s.db)
+	s.Require().NoError(err)
+	defer func() { s.NoError(tx.Rollback()) }()
+	address := common.HexToAddress(configtest.DEFAULT_TEST_APP_CONTRACT)
+	var wg sync.WaitGroup
+	errs := make(chan error, 30)
+	for range 10 {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, err := s.repository.FindAppByAppContract(ctx, &address)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := s.repository.FindAll(ctx, nil, nil, nil, nil, nil)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			_, errors := s.repository.BatchFindAppsByAppContract(ctx, []string{address.Hex()})
+			if len(errors) > 0 {
+				errs <- errors[0]
+				return
+			}
+			errs <- nil
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.NoError(err)
+	}
+}
+
+func (s *DBExecutorSuite) TestReadRouter() {
+	replica := sqlx.MustConnect("sqlite3", filepath.Join(s.T().TempDir(), "replica.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, replica))
+	defer replica.Close()
+	replicaRepository := &ApplicationRepository{Db: replica}
+	s.Require().NoError(replicaRepository.CreateTables(s.ctx))
+	_, err := replicaRepository.Create(s.ctx, newApp())
+	s.Require().NoError(err)
+
+	router := NewReadRouter(s.db, replica, 0)
+	ctx := router.WithReadDB(s.ctx)
+	count, err := s.repository.Count(ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count, "the reads go to the replica")
+	_, err = s.repository.Create(ctx, newApp())
+	s.Require().NoError(err)
+	count, err = s.repository.Count(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(1), count, "the writes go to the primary")
+	apps, errs := s.repository.BatchFindAppsByAppContract(ctx, []string{configtest.DEFAULT_TEST_APP_CONTRACT})
+	s.Require().Empty(errs)
+	s.NotNil(apps[0])
+	_, err = s.repository.Create(s.ctx, &model.ConvenienceApplication{
+		ID:                 2,
+		Name:               "other",
+		ApplicationAddress: common.HexToAddress("0x02").Hex(),
+	})
+	s.Require().NoError(err)
+
+	// the reads go to the primary until the lag is checked, and the lag of
+	// a SQLite replica cannot be read, so they keep falling back to it
+	router = NewReadRouter(s.db, replica, time.Second)
+	s.Same(s.db, router.DB(s.ctx))
+	router.CheckInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(s.ctx)
+	ready := make(chan struct{}, 1)
+	stopped := make(chan error)
+	go func() { stopped <- router.Start(ctx, ready) }()
+	<-ready
+	time.Sleep(50 * time.Millisecond)
+	s.Same(s.db, router.DB(s.ctx))
+	cancel()
+	s.NoError(<-stopped)
+	count, err = s.repository.Count(router.WithReadDB(s.ctx), nil)
+	s.Require().NoError(err)
+	s.Equal(uint64(2), count)
+}
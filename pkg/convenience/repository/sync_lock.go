@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Advisory lock keys on Postgres. A sync of all applications takes the global key exclusively,
+// while a sync of a single application shares it and takes the key of the application.
+const (
+	SYNC_LOCK_ALL_APPS = 7368001
+	SYNC_LOCK_APP      = 7368002
+)
+
+// SyncLock serializes the synchronizer and a resync of the same applications,
+// so neither of them reads checkpoints the other one is rewriting.
+// SQLite has a single writer, there the lock does nothing.
+type SyncLock struct {
+	conn *sql.Conn
+}
+
+// LockAllAppsSync holds the lock of all applications until Unlock.
+func LockAllAppsSync(ctx context.Context, db *sqlx.DB) (*SyncLock, error) {
+	return lockSync(ctx, db, `SELECT pg_advisory_lock($1, 0)`, SYNC_LOCK_ALL_APPS)
+}
+
+// LockAppSync holds the lock of the application until Unlock.
+func LockAppSync(ctx context.Context, db *sqlx.DB, appID uint64) (*SyncLock, error) {
+	return lockSync(ctx, db,
+		`SELECT pg_advisory_lock_shared($1, 0), pg_advisory_lock($2, $3)`,
+		SYNC_LOCK_ALL_APPS, SYNC_LOCK_APP, int32(appID),
+	)
+}
+
+// LockAppSyncTx locks the application until the transaction of the context ends.
+func LockAppSyncTx(ctx context.Context, appID uint64) error {
+	tx, ok := GetTransaction(ctx)
+	if !ok || tx.DriverName() != "postgres" {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx,
+		`SELECT pg_advisory_xact_lock_shared($1, 0), pg_advisory_xact_lock($2, $3)`,
+		SYNC_LOCK_ALL_APPS, SYNC_LOCK_APP, int32(appID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lock application %d: %w", appID, err)
+	}
+	return nil
+}
+
+func lockSync(ctx context.Context, db *sqlx.DB, query string, args ...any) (*SyncLock, error) {
+	if db.DriverName() != "postgres" {
+		return &SyncLock{}, nil
+	}
+	// session locks belong to a connection, so it is kept until the unlock
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to take the sync lock: %w", err)
+	}
+	return &SyncLock{conn: conn}, nil
+}
+
+// Unlock releases the lock, closing its connection releases it even if the unlock fails.
+func (l *SyncLock) Unlock(ctx context.Context) {
+	if l == nil || l.conn == nil {
+		return
+	}
+	_, err := l.conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock_all()`)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to release the sync lock", "error", err)
+		// discard the connection, so the session and its locks end
+		_ = l.conn.Raw(func(driverConn any) error { return driver.ErrBadConn })
+	}
+	l.conn.Close()
+}
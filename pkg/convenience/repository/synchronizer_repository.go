@@ -4,9 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"fmt"
 	"log/slog"
 
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/jmoiron/sqlx"
 )
@@ -20,31 +20,8 @@ func (c *SynchronizerRepository) GetDB() *sqlx.DB {
 }
 
 func (c *SynchronizerRepository) CreateTables(ctx context.Context) error {
-	idType := "INTEGER"
-
-	if c.Db.DriverName() == "postgres" {
-		idType = "SERIAL"
-	}
-
-	schema := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS synchronizer_fetch (
-		id %s NOT NULL PRIMARY KEY,
-		timestamp_after bigint,
-		ini_cursor_after text,
-		log_vouchers_ids text,
-		end_cursor_after text,
-		ini_input_cursor_after text,
-		end_input_cursor_after text,
-		ini_report_cursor_after text,
-		end_report_cursor_after text
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_last_fetched_id ON synchronizer_fetch(id DESC);
-		`, idType)
-
-	// execute a query on the server
-	_, err := c.Db.ExecContext(ctx, schema)
-
-	return err
+	// the tables are versioned by the migrations package
+	return migrations.CheckSchema(ctx, &c.Db)
 }
 func (c *SynchronizerRepository) Create(
 	ctx context.Context, data *model.SynchronizerFetch,
@@ -93,8 +70,9 @@ func (c *SynchronizerRepository) Count(
 func (c *SynchronizerRepository) GetLastFetched(
 	ctx context.Context,
 ) (*model.SynchronizerFetch, error) {
+	exec := DBExecutor{&c.Db}
 	query := `SELECT * FROM synchronizer_fetch ORDER BY id DESC LIMIT 1`
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		slog.ErrorContext(ctx, "Error searching for last fetched", "Error", err)
 		return nil, err
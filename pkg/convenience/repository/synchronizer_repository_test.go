@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/ncruces/go-sqlite3/driver"
@@ -25,6 +26,7 @@ func (s *SynchronizerRepositorySuite) SetupTest() {
 	commons.ConfigureLog(slog.LevelDebug)
 	s.ctx, s.ctxCancel = context.WithCancel(context.Background())
 	s.db = sqlx.MustConnect("sqlite3", ":memory:")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.repository = &SynchronizerRepository{
 		Db: *s.db,
 	}
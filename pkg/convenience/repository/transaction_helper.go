@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -39,3 +41,42 @@ func GetTransaction(ctx context.Context) (*sqlx.Tx, bool) {
 	}
 	return tx, true
 }
+
+const snapshotLockKey contextKey = "snapshot_lock"
+
+// StartReadSnapshot starts a transaction for reads whose queries all see the same
+// snapshot of the database, repeatable read on postgres and deferred on sqlite,
+// and adds it to the context. The snapshot is released by rolling the transaction back.
+// The queries of the snapshot are serialized, as the resolvers of a request run
+// them concurrently on its single connection.
+func StartReadSnapshot(ctx context.Context, db *sqlx.DB) (context.Context, *sqlx.Tx, error) {
+	// a deferred SQLite transaction reads from the same snapshot once started;
+	// the read-only option of the driver fails to restore the connection without pragmas
+	txOpts := &sql.TxOptions{}
+	if db.DriverName() != "sqlite3" {
+		txOpts.Isolation = sql.LevelRepeatableRead
+		txOpts.ReadOnly = true
+	}
+	tx, err := db.BeginTxx(ctx, txOpts)
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to begin snapshot: %w", err)
+	}
+	ctx = context.WithValue(ctx, transactionKey, tx)
+	ctx = context.WithValue(ctx, snapshotLockKey, &sync.Mutex{})
+	return ctx, tx, nil
+}
+
+// snapshotLock returns the lock of the snapshot of the context, or nil.
+func snapshotLock(ctx context.Context) *sync.Mutex {
+	lock, _ := ctx.Value(snapshotLockKey).(*sync.Mutex)
+	return lock
+}
+
+// lockSnapshot takes the lock of a snapshot, if any, returning the function releasing it.
+func lockSnapshot(lock *sync.Mutex) func() {
+	if lock == nil {
+		return func() {}
+	}
+	lock.Lock()
+	return sync.OnceFunc(lock.Unlock)
+}
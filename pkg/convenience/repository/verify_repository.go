@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// VerifyRepository pages through the synced rows of an application in index order,
+// with the columns compared against the node database.
+type VerifyRepository struct {
+	Db *sqlx.DB
+}
+
+type VerifyInput struct {
+	Index       uint64 `db:"input_index"`
+	ID          string `db:"id"`
+	Status      string `db:"status"`
+	MsgSender   string `db:"msg_sender"`
+	Payload     string `db:"payload"`
+	BlockNumber uint64 `db:"block_number"`
+	// The payload was emptied by the retention policy
+	Pruned bool `db:"pruned"`
+}
+
+type VerifyOutput struct {
+	Index                uint64 `db:"output_index"`
+	InputIndex           uint64 `db:"input_index"`
+	Type                 string `db:"type"`
+	Payload              string `db:"payload"`
+	OutputHashesSiblings string `db:"output_hashes_siblings"`
+	Executed             bool   `db:"executed"`
+	TransactionHash      string `db:"transaction_hash"`
+	Pruned               bool   `db:"pruned"`
+}
+
+type VerifyReport struct {
+	Index      uint64 `db:"output_index"`
+	InputIndex uint64 `db:"input_index"`
+	Payload    string `db:"payload"`
+	Pruned     bool   `db:"pruned"`
+}
+
+// afterIndex turns an optional index into the lower bound of a query.
+func afterIndex(index *uint64) int64 {
+	if index == nil {
+		return -1
+	}
+	return int64(*index)
+}
+
+func (r *VerifyRepository) FindAppInputsGtIndex(
+	ctx context.Context, appContract string, index *uint64, limit uint64,
+) ([]VerifyInput, error) {
+	exec := DBExecutor{r.Db}
+	inputs := []VerifyInput{}
+	err := exec.SelectContext(ctx, &inputs, `
+		SELECT
+			input_index,
+			id,
+			COALESCE(status, '') AS status,
+			COALESCE(msg_sender, '') AS msg_sender,
+			COALESCE(payload, '') AS payload,
+			COALESCE(block_number, 0) AS block_number,
+			pruned_at IS NOT NULL AS pruned
+		FROM convenience_inputs
+		WHERE app_contract = $1 AND input_index > $2
+		ORDER BY input_index ASC
+		LIMIT $3`,
+		appContract, afterIndex(index), limit,
+	)
+	return inputs, err
+}
+
+// FindAppOutputsGtIndex returns the vouchers and notices of the application in output index order.
+func (r *VerifyRepository) FindAppOutputsGtIndex(
+	ctx context.Context, appContract string, index *uint64, limit uint64,
+) ([]VerifyOutput, error) {
+	exec := DBExecutor{r.Db}
+	outputs := []VerifyOutput{}
+	err := exec.SelectContext(ctx, &outputs, `
+		SELECT * FROM (
+			SELECT
+				output_index,
+				input_index,
+				'voucher' AS type,
+				COALESCE(payload, '') AS payload,
+				COALESCE(output_hashes_siblings, '') AS output_hashes_siblings,
+				COALESCE(executed, false) AS executed,
+				COALESCE(transaction_hash, '') AS transaction_hash,
+				pruned_at IS NOT NULL AS pruned
+			FROM convenience_vouchers
+			WHERE app_contract = $1 AND output_index > $2
+			UNION ALL
+			SELECT
+				output_index,
+				input_index,
+				'notice' AS type,
+				COALESCE(payload, '') AS payload,
+				COALESCE(output_hashes_siblings, '') AS output_hashes_siblings,
+				false AS executed,
+				'' AS transaction_hash,
+				false AS pruned
+			FROM convenience_notices
+			WHERE app_contract = $1 AND output_index > $2
+		) outputs
+		ORDER BY output_index ASC
+		LIMIT $3`,
+		appContract, afterIndex(index), limit,
+	)
+	return outputs, err
+}
+
+func (r *VerifyRepository) FindAppReportsGtIndex(
+	ctx context.Context, appContract string, index *uint64, limit uint64,
+) ([]VerifyReport, error) {
+	exec := DBExecutor{r.Db}
+	reports := []VerifyReport{}
+	err := exec.SelectContext(ctx, &reports, `
+		SELECT
+			output_index,
+			input_index,
+			COALESCE(payload, '') AS payload,
+			pruned_at IS NOT NULL AS pruned
+		FROM convenience_reports
+		WHERE app_contract = $1 AND output_index > $2
+		ORDER BY output_index ASC
+		LIMIT $3`,
+		appContract, afterIndex(index), limit,
+	)
+	return reports, err
+}
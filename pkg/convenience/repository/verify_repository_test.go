@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifyRepositorySuite struct {
+	suite.Suite
+	repository *VerifyRepository
+	tempDir    string
+	db         *sqlx.DB
+	ctx        context.Context
+}
+
+const verifyApp = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+
+func (s *VerifyRepositorySuite) SetupTest() {
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "verify.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	s.repository = &VerifyRepository{Db: s.db}
+	s.Require().NoError((&InputRepository{Db: s.db}).CreateTables(s.ctx))
+	queries := []string{
+		`INSERT INTO convenience_inputs (id, input_index, app_contract, status, msg_sender, payload, block_number)
+			VALUES ('1', 0, '` + verifyApp + `', '1', '0xabc', '0x01', 10)`,
+		`INSERT INTO convenience_inputs (id, input_index, app_contract, pruned_at)
+			VALUES ('2', 1, '` + verifyApp + `', CURRENT_TIMESTAMP)`,
+		`INSERT INTO convenience_vouchers
+			(input_index, output_index, app_contract, payload, executed, output_hashes_siblings, transaction_hash)
+			VALUES (0, 1, '` + verifyApp + `', '0x237a816f', true, '["0x00"]', '0xff')`,
+		`INSERT INTO convenience_notices (input_index, output_index, app_contract, payload)
+			VALUES (0, 0, '` + verifyApp + `', '0xc258d6e5')`,
+		`INSERT INTO convenience_notices (input_index, output_index, app_contract, payload)
+			VALUES (1, 2, '0x75135d8ADb7180640d29d822D9AD59E83E8695b2', '0xc258d6e5')`,
+		`INSERT INTO convenience_reports (input_index, output_index, app_contract, app_id, payload)
+			VALUES (1, 0, '` + verifyApp + `', 1, '0x02')`,
+	}
+	for _, query := range queries {
+		_, err := s.db.Exec(query)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *VerifyRepositorySuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestVerifyRepositorySuite(t *testing.T) {
+	suite.Run(t, new(VerifyRepositorySuite))
+}
+
+func (s *VerifyRepositorySuite) TestFindAppInputsGtIndex() {
+	inputs, err := s.repository.FindAppInputsGtIndex(s.ctx, verifyApp, nil, 10)
+	s.Require().NoError(err)
+	s.Require().Len(inputs, 2)
+	s.Equal(VerifyInput{
+		Index: 0, ID: "1", Status: "1", MsgSender: "0xabc", Payload: "0x01", BlockNumber: 10,
+	}, inputs[0])
+	s.True(inputs[1].Pruned)
+
+	after := uint64(0)
+	inputs, err = s.repository.FindAppInputsGtIndex(s.ctx, verifyApp, &after, 10)
+	s.Require().NoError(err)
+	s.Require().Len(inputs, 1)
+	s.Equal(uint64(1), inputs[0].Index)
+}
+
+func (s *VerifyRepositorySuite) TestFindAppOutputsGtIndex() {
+	outputs, err := s.repository.FindAppOutputsGtIndex(s.ctx, verifyApp, nil, 10)
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 2)
+	s.Equal(RAW_NOTICE_TYPE, outputs[0].Type)
+	s.Equal(VerifyOutput{
+		Index:                1,
+		InputIndex:           0,
+		Type:                 RAW_VOUCHER_TYPE,
+		Payload:              "0x237a816f",
+		OutputHashesSiblings: `["0x00"]`,
+		Executed:             true,
+		TransactionHash:      "0xff",
+	}, outputs[1])
+
+	outputs, err = s.repository.FindAppOutputsGtIndex(s.ctx, verifyApp, nil, 1)
+	s.Require().NoError(err)
+	s.Require().Len(outputs, 1)
+	s.Equal(uint64(0), outputs[0].Index)
+}
+
+func (s *VerifyRepositorySuite) TestFindAppReportsGtIndex() {
+	reports, err := s.repository.FindAppReportsGtIndex(s.ctx, verifyApp, nil, 10)
+	s.Require().NoError(err)
+	s.Equal([]VerifyReport{{Index: 0, InputIndex: 1, Payload: "0x02"}}, reports)
+}
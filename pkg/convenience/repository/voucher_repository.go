@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -35,41 +36,23 @@ type voucherRow struct {
 	TransactionHash      string `db:"transaction_hash"`
 	ProofOutputIndex     uint64 `db:"proof_output_index"`
 	IsDelegatedCall      bool   `db:"is_delegated_call"`
+	// Set when the retention policy emptied the payload
+	PrunedAt sql.NullTime `db:"pruned_at"`
 }
 
 func (c *VoucherRepository) CreateTables(ctx context.Context) error {
-	schema := `CREATE TABLE IF NOT EXISTS convenience_vouchers (
-		destination            text,
-		payload 	           text,
-		executed	           BOOLEAN,
-		input_index            integer,
-		output_index           integer,
-		value		           text,
-		output_hashes_siblings text,
-		app_contract           text,
-		transaction_hash       text DEFAULT '' NOT NULL,
-		proof_output_index     integer DEFAULT 0,
-		is_delegated_call	   BOOLEAN,
-		PRIMARY KEY (input_index, output_index, app_contract)
-	);
-
-	CREATE INDEX IF NOT EXISTS idx_input_index_output_index ON convenience_vouchers(input_index, output_index);
-	CREATE INDEX IF NOT EXISTS idx_app_contract_output_index ON convenience_vouchers(app_contract, output_index);
-	CREATE INDEX IF NOT EXISTS idx_app_contract_input_index ON convenience_vouchers(app_contract, input_index);
-	`
-
-	// execute a query on the server
-	_, err := c.Db.ExecContext(ctx, schema)
-	return err
+	// the tables are versioned by the migrations package
+	return migrations.CheckSchema(ctx, c.Db)
 }
 
 func (c *VoucherRepository) FindVoucherByAppContractAndOutputIndex(
 	ctx context.Context, appContract common.Address, outputIndex uint64,
 ) (*model.ConvenienceVoucher, error) {
+	exec := DBExecutor{c.Db}
 
 	query := `SELECT * FROM convenience_vouchers WHERE app_contract = $1 and output_index = $2 LIMIT 1`
 
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -247,8 +230,9 @@ func (c *VoucherRepository) voucherCount(
 	ctx context.Context,
 	isDelegatedCall bool,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	var count int
-	err := c.Db.GetContext(ctx, &count, "SELECT count(*) FROM convenience_vouchers WHERE is_delegated_call = $1", isDelegatedCall)
+	err := exec.GetContext(ctx, &count, "SELECT count(*) FROM convenience_vouchers WHERE is_delegated_call = $1", isDelegatedCall)
 	if err != nil {
 		return 0, nil
 	}
@@ -260,9 +244,10 @@ func (c *VoucherRepository) queryByOutputIndexAndAppContract(
 	outputIndex uint64,
 	appContract *common.Address,
 	isDelegatedCall bool,
-) (*sqlx.Rows, error) {
+) (*Rows, error) {
+	exec := DBExecutor{c.Db}
 	if appContract != nil {
-		return c.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT * FROM convenience_vouchers
 			WHERE output_index = $1 and app_contract = $2 and is_delegated_call = $3
 			LIMIT 1`,
@@ -271,7 +256,7 @@ func (c *VoucherRepository) queryByOutputIndexAndAppContract(
 			isDelegatedCall,
 		)
 	} else {
-		return c.Db.QueryxContext(ctx, `
+		return exec.QueryxContext(ctx, `
 			SELECT * FROM convenience_vouchers
 			WHERE output_index = $1 and is_delegated_call = $2
 			LIMIT 1`,
@@ -314,7 +299,8 @@ func (c *VoucherRepository) FindVoucherByOutputIndexAndAppContract(
 func (c *VoucherRepository) FindAllVouchersByBlockNumber(
 	ctx context.Context, startBlockGte uint64, endBlockLt uint64, isDelegateCall bool,
 ) ([]*model.ConvenienceVoucher, error) {
-	stmt, err := c.Db.Preparex(`
+	exec := DBExecutor{c.Db}
+	stmt, err := exec.PreparexContext(ctx, `
 		SELECT
 			v.destination,
 			v.payload,
@@ -349,10 +335,11 @@ func (c *VoucherRepository) FindAllVouchersByBlockNumber(
 func (c *VoucherRepository) FindVoucherByInputAndOutputIndex(
 	ctx context.Context, inputIndex uint64, outputIndex uint64,
 ) (*model.ConvenienceVoucher, error) {
+	exec := DBExecutor{c.Db}
 
 	query := `SELECT * FROM convenience_vouchers WHERE input_index = $1 and output_index = $2 LIMIT 1`
 
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -402,6 +389,7 @@ func (c *VoucherRepository) count(
 	filter []*model.ConvenienceFilter,
 	isDelegateCall bool,
 ) (uint64, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT count(*) FROM convenience_vouchers `
 	filter = c.appendFilterDelegate(filter, isDelegateCall)
 	where, args, _, err := transformToQuery(filter)
@@ -410,7 +398,7 @@ func (c *VoucherRepository) count(
 	}
 	query += where
 	slog.DebugContext(ctx, "Query", "query", query, "args", args)
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -426,8 +414,9 @@ func (c *VoucherRepository) count(
 func (c *VoucherRepository) FindAll(
 	ctx context.Context,
 ) ([]model.ConvenienceVoucher, error) {
+	exec := DBExecutor{c.Db}
 	query := `SELECT * FROM convenience_vouchers `
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -492,6 +481,7 @@ func (c *VoucherRepository) findAllVouchers(
 	filter []*model.ConvenienceFilter,
 	isDelegateCall bool,
 ) (*commons.PageResult[model.ConvenienceVoucher], error) {
+	exec := DBExecutor{c.Db}
 	total, err := c.count(ctx, filter, isDelegateCall)
 	if err != nil {
 		return nil, err
@@ -519,7 +509,7 @@ func (c *VoucherRepository) findAllVouchers(
 	args = append(args, offset)
 
 	slog.DebugContext(ctx, "Query", "query", query, "args", args, "total", total)
-	stmt, err := c.Db.Preparex(query)
+	stmt, err := exec.PreparexContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -545,10 +535,11 @@ func (c *VoucherRepository) findAllVouchers(
 }
 
 func (c *VoucherRepository) FindVoucherByAppContractAndIndex(ctx context.Context, index int, appContract common.Address) (*model.ConvenienceVoucher, error) {
+	exec := DBExecutor{c.Db}
 
 	query := `SELECT * FROM convenience_vouchers WHERE input_index = $1 AND app_contract = $2`
 
-	res, err := c.Db.QueryxContext(
+	res, err := exec.QueryxContext(
 		ctx,
 		query,
 		uint64(index),
@@ -668,79 +659,70 @@ func (c *VoucherRepository) BatchFindAllByInputIndexAndAppContract(
 	ctx context.Context,
 	filters []*BatchFilterItem,
 ) ([]*commons.PageResult[model.ConvenienceVoucher], []error) {
-	slog.DebugContext(ctx, "BatchFindAllByInputIndexAndAppContract", "len", len(filters))
-	query := `SELECT * FROM convenience_vouchers WHERE `
-
-	args := []interface{}{}
-	where := []string{}
-	for i, filter := range filters {
-		// nolint
-		where = append(where, fmt.Sprintf(" (app_contract = $%d and input_index = $%d) ", i*2+1, i*2+2))
-		args = append(args, filter.AppContract.Hex())
-		args = append(args, filter.InputIndex)
-	}
-	query += strings.Join(where, " or ")
+	return c.batchFindAll(ctx, filters, false)
+}
 
-	errors := []error{}
-	results := []*commons.PageResult[model.ConvenienceVoucher]{}
-	stmt, err := c.Db.PreparexContext(ctx, query)
-	if err != nil {
-		slog.ErrorContext(ctx, "BatchFind prepare context", "error", err)
-		return nil, errors
-	}
-	defer stmt.Close()
+// BatchFindAllDelegateCallsByInputIndexAndAppContract is the batch of the delegate call vouchers.
+func (c *VoucherRepository) BatchFindAllDelegateCallsByInputIndexAndAppContract(
+	ctx context.Context,
+	filters []*BatchFilterItem,
+) ([]*commons.PageResult[model.ConvenienceVoucher], []error) {
+	return c.batchFindAll(ctx, filters, true)
+}
 
-	rows, err := stmt.QueryxContext(ctx, args...)
-	if err != nil {
-		slog.ErrorContext(ctx, "BatchFind query context", "error", err)
-		return nil, errors
+func (c *VoucherRepository) batchFindAll(
+	ctx context.Context,
+	filters []*BatchFilterItem,
+	delegateCall bool,
+) ([]*commons.PageResult[model.ConvenienceVoucher], []error) {
+	exec := DBExecutor{c.Db}
+	slog.DebugContext(ctx, "BatchFindAllByInputIndexAndAppContract", "len", len(filters), "delegateCall", delegateCall)
+	table := "(SELECT * FROM convenience_vouchers WHERE is_delegated_call = false)"
+	if delegateCall {
+		table = "(SELECT * FROM convenience_vouchers WHERE is_delegated_call = true)"
 	}
-	defer rows.Close()
-
-	var voucherRows []voucherRow
-	err = stmt.SelectContext(ctx, &voucherRows, args...)
-	if err != nil {
-		return nil, errors
+	items := make([]batchItem, len(filters))
+	for i, filter := range filters {
+		items[i] = batchItem{filter.AppContract.Hex(), filter.InputIndex, filter.BatchPage}
 	}
+	query, args := batchPageQuery(table, items)
 
-	vouchers := make([]model.ConvenienceVoucher, len(voucherRows))
-
-	for i, row := range voucherRows {
-		vouchers[i] = convertToConvenienceVoucher(row)
+	type batchVoucherRow struct {
+		voucherRow
+		batchColumns
 	}
-
-	if err := rows.Err(); err != nil {
-		return nil, errors
+	var rows []batchVoucherRow
+	if err := exec.SelectContext(ctx, &rows, query, args...); err != nil {
+		slog.ErrorContext(ctx, "BatchFind", "error", err)
+		return nil, []error{err}
 	}
-
-	voucherMap := make(map[string]*commons.PageResult[model.ConvenienceVoucher])
-
-	for _, voucher := range vouchers {
-		key := GenerateBatchVoucherKey(&voucher.AppContract, int(voucher.InputIndex))
-		if voucherMap[key] == nil {
-			voucherMap[key] = &commons.PageResult[model.ConvenienceVoucher]{}
+	results, err := batchPages(ctx, &exec, table, items, rows, func(row batchVoucherRow) (string, batchColumns) {
+		appContract := common.HexToAddress(row.AppContract)
+		return GenerateBatchVoucherKey(&appContract, int(row.InputIndex)), row.batchColumns
+	})
+	if err != nil {
+		return nil, []error{err}
+	}
+	vouchers := make([]*commons.PageResult[model.ConvenienceVoucher], len(results))
+	for i, result := range results {
+		vouchers[i] = &commons.PageResult[model.ConvenienceVoucher]{
+			Total:  result.Total,
+			Offset: result.Offset,
+			Rows:   make([]model.ConvenienceVoucher, len(result.Rows)),
 		}
-		voucherMap[key].Total += 1
-		voucherMap[key].Rows = append(voucherMap[key].Rows, voucher)
-	}
-
-	for _, filter := range filters {
-		key := GenerateBatchVoucherKey(filter.AppContract, filter.InputIndex)
-		reportsItem := voucherMap[key]
-		if reportsItem == nil {
-			reportsItem = &commons.PageResult[model.ConvenienceVoucher]{}
+		for j, row := range result.Rows {
+			vouchers[i].Rows[j] = convertToConvenienceVoucher(row.voucherRow)
 		}
-		results = append(results, reportsItem)
 	}
-	slog.DebugContext(ctx, "BatchVouchersResult", "len", len(results))
-	return results, nil
+	slog.DebugContext(ctx, "BatchVouchersResult", "len", len(vouchers))
+	return vouchers, nil
 }
 
 func GenerateBatchVoucherKey(appContract *common.Address, inputIndex int) string {
 	return fmt.Sprintf("%s|%d", appContract.Hex(), inputIndex)
 }
 
-func parseVoucher(res *sqlx.Rows) (*model.ConvenienceVoucher, error) {
+func parseVoucher(res *Rows) (*model.ConvenienceVoucher, error) {
 	var (
 		voucher     model.ConvenienceVoucher
 		appContract string
@@ -762,3 +744,31 @@ func parseVoucher(res *sqlx.Rows) (*model.ConvenienceVoucher, error) {
 	voucher.Destination = common.HexToAddress(destination)
 	return &voucher, nil
 }
+
+// StreamByAppContract calls fn with the vouchers and delegate call vouchers of the application
+// from the given output index, reading them from a single database cursor.
+func (c *VoucherRepository) StreamByAppContract(
+	ctx context.Context,
+	appContract string,
+	fromIndex uint64,
+	fn func(voucher model.ConvenienceVoucher) error,
+) error {
+	exec := DBExecutor{c.Db}
+	rows, err := exec.QueryxContext(ctx, `SELECT * FROM convenience_vouchers
+		WHERE app_contract = $1 AND output_index >= $2
+		ORDER BY output_index ASC`, appContract, fromIndex)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row voucherRow
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(convertToConvenienceVoucher(row)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/ethereum/go-ethereum/common"
 	_ "github.com/ncruces/go-sqlite3/driver"
@@ -29,6 +30,7 @@ func (s *VoucherRepositorySuite) SetupTest() {
 	s.dbFactory, err = commons.NewDbFactory()
 	s.Require().NoError(err)
 	db := s.dbFactory.CreateDb(s.ctx, "voucher.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, db))
 	outputRepository := OutputRepository{db}
 	s.voucherRepository = &VoucherRepository{
 		Db: db, OutputRepository: outputRepository,
@@ -261,3 +263,59 @@ func (s *VoucherRepositorySuite) TestBatchFindAllVouchers() {
 	s.Equal(4, len(results[0].Rows))
 	s.Equal(4, int(results[0].Total))
 }
+
+func (s *VoucherRepositorySuite) TestBatchFindAllVouchersPaged() {
+	ctx := context.Background()
+	appContract := common.HexToAddress(ApplicationAddress)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 5; j++ {
+			_, err := s.voucherRepository.CreateVoucher(ctx, &model.ConvenienceVoucher{
+				Destination:     common.HexToAddress("0x26A61aF89053c847B4bd5084E2caFe7211874a29"),
+				Payload:         "0x1122",
+				InputIndex:      uint64(i),
+				OutputIndex:     uint64(i*5 + j),
+				AppContract:     appContract,
+				IsDelegatedCall: j == 4,
+			})
+			s.Require().NoError(err)
+		}
+	}
+	two, zero := 2, 0
+	filters := []*BatchFilterItem{
+		{AppContract: &appContract, InputIndex: 0, BatchPage: BatchPage{Offset: 1, Limit: &two}},
+		{AppContract: &appContract, InputIndex: 1, BatchPage: BatchPage{Offset: 3}},
+		{AppContract: &appContract, InputIndex: 1, BatchPage: BatchPage{Limit: &zero}},
+		{AppContract: &appContract, InputIndex: 2},
+	}
+	results, errs := s.voucherRepository.BatchFindAllByInputIndexAndAppContract(ctx, filters)
+	s.Require().Empty(errs)
+	s.Require().Len(results, 4)
+
+	s.Equal(4, int(results[0].Total))
+	s.Equal(1, int(results[0].Offset))
+	s.Require().Len(results[0].Rows, 2)
+	s.Equal(1, int(results[0].Rows[0].OutputIndex))
+	s.Equal(2, int(results[0].Rows[1].OutputIndex))
+
+	s.Equal(4, int(results[1].Total))
+	s.Require().Len(results[1].Rows, 1)
+	s.Equal(8, int(results[1].Rows[0].OutputIndex))
+
+	// the empty pages still count the vouchers of the input
+	s.Equal(4, int(results[2].Total))
+	s.Empty(results[2].Rows)
+
+	s.Equal(0, int(results[3].Total))
+	s.Empty(results[3].Rows)
+
+	delegateCalls, errs := s.voucherRepository.BatchFindAllDelegateCallsByInputIndexAndAppContract(ctx, filters[3:])
+	s.Require().Empty(errs)
+	s.Equal(0, int(delegateCalls[0].Total))
+	delegateCalls, errs = s.voucherRepository.BatchFindAllDelegateCallsByInputIndexAndAppContract(
+		ctx, []*BatchFilterItem{{AppContract: &appContract, InputIndex: 1}},
+	)
+	s.Require().Empty(errs)
+	s.Require().Len(delegateCalls[0].Rows, 1)
+	s.Equal(9, int(delegateCalls[0].Rows[0].OutputIndex))
+	s.True(delegateCalls[0].Rows[0].IsDelegatedCall)
+}
@@ -0,0 +1,232 @@
+package synchronizer
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Rows pruned per statement
+const RETENTION_BATCH_SIZE = uint64(1000)
+
+// DisabledAppsFinder lists the applications disabled on the node.
+type DisabledAppsFinder interface {
+	FindDisabledAppContracts(ctx context.Context) ([]string, error)
+}
+
+// ChangeListener is told the applications whose rows were pruned, e.g. to invalidate cached responses.
+type ChangeListener interface {
+	AppsChanged(ctx context.Context, apps []common.Address)
+}
+
+// RetentionSynchronizer periodically prunes the rows selected by the retention policy.
+type RetentionSynchronizer struct {
+	ApplicationRepository *repository.ApplicationRepository
+	RetentionRepository   *repository.RetentionRepository
+	// Without it the inputs of disabled applications are kept
+	DisabledApps DisabledAppsFinder
+	// Told the applications pruned by each run, if set
+	ChangeListener ChangeListener
+	Policy         model.RetentionPolicy
+	Period         time.Duration
+	lastRun        *lastRetentionRun
+}
+
+type lastRetentionRun struct {
+	mu  sync.Mutex
+	run *model.RetentionRun
+}
+
+func NewRetentionSynchronizer(
+	applicationRepository *repository.ApplicationRepository,
+	retentionRepository *repository.RetentionRepository,
+	disabledApps DisabledAppsFinder,
+	policy model.RetentionPolicy,
+	period time.Duration,
+) *RetentionSynchronizer {
+	return &RetentionSynchronizer{
+		ApplicationRepository: applicationRepository,
+		RetentionRepository:   retentionRepository,
+		DisabledApps:          disabledApps,
+		Policy:                policy,
+		Period:                period,
+		lastRun:               &lastRetentionRun{},
+	}
+}
+
+func (x RetentionSynchronizer) String() string {
+	return "RetentionSynchronizer"
+}
+
+func (x RetentionSynchronizer) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(x.Period):
+			run := x.Prune(ctx)
+			if run.Error != "" {
+				slog.ErrorContext(ctx, "Error pruning data", "Error", run.Error)
+				continue
+			}
+			for _, app := range run.Apps {
+				slog.InfoContext(ctx, "Pruned data",
+					"app_contract", app.AppContract,
+					"reports", app.Reports,
+					"voucher_payloads", app.VoucherPayloads,
+					"inputs", app.Inputs,
+				)
+			}
+		}
+	}
+}
+
+// LastRun returns the latest pruning run, nil before the first one.
+func (x *RetentionSynchronizer) LastRun() *model.RetentionRun {
+	x.lastRun.mu.Lock()
+	defer x.lastRun.mu.Unlock()
+	return x.lastRun.run
+}
+
+func (x *RetentionSynchronizer) Interval() time.Duration {
+	return x.Period
+}
+
+func (x *RetentionSynchronizer) RetentionPolicy() model.RetentionPolicy {
+	return x.Policy
+}
+
+// Prune applies the policy to every application, the run keeps the first error.
+func (x *RetentionSynchronizer) Prune(ctx context.Context) *model.RetentionRun {
+	run := &model.RetentionRun{StartedAt: time.Now(), Apps: []model.AppRetentionRun{}}
+	err := x.prune(ctx, run)
+	if err != nil {
+		run.Error = err.Error()
+	}
+	// the batches are committed as they run, so the pruned apps changed even after an error
+	x.notifyPruned(ctx, run)
+	run.FinishedAt = time.Now()
+	x.lastRun.mu.Lock()
+	defer x.lastRun.mu.Unlock()
+	x.lastRun.run = run
+	return run
+}
+
+func (x *RetentionSynchronizer) prune(ctx context.Context, run *model.RetentionRun) error {
+	apps, err := x.ApplicationRepository.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	disabled, err := x.findDisabledApps(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, app := range apps {
+		appRun, err := x.pruneApp(ctx, app.ApplicationAddress, disabled[app.ApplicationAddress], now)
+		// an app pruned before an error is reported too
+		if appRun.Reports+appRun.VoucherPayloads+appRun.Inputs > 0 {
+			run.Apps = append(run.Apps, appRun)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *RetentionSynchronizer) pruneApp(
+	ctx context.Context, appContract string, disabled bool, now time.Time,
+) (model.AppRetentionRun, error) {
+	var err error
+	rule := x.Policy.Rule(appContract)
+	appRun := model.AppRetentionRun{AppContract: appContract}
+	if rule.ReportsDays > 0 {
+		before := now.AddDate(0, 0, -rule.ReportsDays)
+		appRun.Reports, err = pruneAll(ctx, func(ctx context.Context) (int64, error) {
+			return x.RetentionRepository.PruneReports(ctx, appContract, before, RETENTION_BATCH_SIZE)
+		})
+		if err != nil {
+			return appRun, err
+		}
+	}
+	if rule.ExecutedVoucherPayloadsDays > 0 {
+		before := now.AddDate(0, 0, -rule.ExecutedVoucherPayloadsDays)
+		appRun.VoucherPayloads, err = pruneAll(ctx, func(ctx context.Context) (int64, error) {
+			return x.RetentionRepository.PruneExecutedVoucherPayloads(
+				ctx, appContract, before, RETENTION_BATCH_SIZE,
+			)
+		})
+		if err != nil {
+			return appRun, err
+		}
+	}
+	if rule.DisabledAppInputs && disabled {
+		appRun.Inputs, err = pruneAll(ctx, func(ctx context.Context) (int64, error) {
+			return x.RetentionRepository.PruneInputs(ctx, appContract, RETENTION_BATCH_SIZE)
+		})
+		if err != nil {
+			return appRun, err
+		}
+	}
+	return appRun, nil
+}
+
+func (x *RetentionSynchronizer) notifyPruned(ctx context.Context, run *model.RetentionRun) {
+	if x.ChangeListener == nil || len(run.Apps) == 0 {
+		return
+	}
+	apps := make([]common.Address, 0, len(run.Apps))
+	for _, app := range run.Apps {
+		apps = append(apps, common.HexToAddress(app.AppContract))
+	}
+	x.ChangeListener.AppsChanged(ctx, apps)
+}
+
+func (x *RetentionSynchronizer) findDisabledApps(ctx context.Context) (map[string]bool, error) {
+	disabled := map[string]bool{}
+	if x.DisabledApps == nil || !x.usesDisabledApps() {
+		return disabled, nil
+	}
+	appContracts, err := x.DisabledApps.FindDisabledAppContracts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, appContract := range appContracts {
+		disabled[appContract] = true
+	}
+	return disabled, nil
+}
+
+func (x *RetentionSynchronizer) usesDisabledApps() bool {
+	if x.Policy.Default.DisabledAppInputs {
+		return true
+	}
+	for _, rule := range x.Policy.Apps {
+		if rule.DisabledAppInputs {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneAll runs batches until one prunes less than a full batch.
+func pruneAll(ctx context.Context, pruneBatch func(ctx context.Context) (int64, error)) (int64, error) {
+	total := int64(0)
+	for {
+		pruned, err := pruneBatch(ctx)
+		if err != nil {
+			return total, err
+		}
+		total += pruned
+		if pruned < int64(RETENTION_BATCH_SIZE) || ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+}
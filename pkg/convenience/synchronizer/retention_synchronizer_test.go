@@ -0,0 +1,127 @@
+package synchronizer
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	enabledApp  = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+	disabledApp = "0x75135d8ADb7180640d29d822D9AD59E83E8695b2"
+)
+
+type fakeDisabledApps []string
+
+func (f fakeDisabledApps) FindDisabledAppContracts(ctx context.Context) ([]string, error) {
+	return f, nil
+}
+
+type fakeChangeListener struct {
+	calls [][]common.Address
+}
+
+func (f *fakeChangeListener) AppsChanged(ctx context.Context, apps []common.Address) {
+	f.calls = append(f.calls, apps)
+}
+
+type RetentionSynchronizerSuite struct {
+	suite.Suite
+	ctx     context.Context
+	tempDir string
+	db      *sqlx.DB
+	pruner  *RetentionSynchronizer
+}
+
+func (s *RetentionSynchronizerSuite) SetupTest() {
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "retention.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	appRepository := &repository.ApplicationRepository{Db: s.db}
+	s.Require().NoError(appRepository.CreateTables(s.ctx))
+	old := time.Now().AddDate(0, 0, -10).UnixMilli()
+	for id, app := range []string{enabledApp, disabledApp} {
+		_, err := appRepository.Create(s.ctx, &model.ConvenienceApplication{
+			ID: uint64(id + 1), Name: app, ApplicationAddress: app,
+		})
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`INSERT INTO convenience_inputs (id, input_index, app_contract, payload, block_timestamp)
+			VALUES ('0', 0, $1, '0x01', $2)`, app, old)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(`INSERT INTO convenience_reports (input_index, output_index, app_contract, payload)
+			VALUES (0, 0, $1, '0x02')`, app)
+		s.Require().NoError(err)
+	}
+	s.pruner = NewRetentionSynchronizer(
+		appRepository,
+		&repository.RetentionRepository{Db: s.db},
+		fakeDisabledApps{disabledApp},
+		model.RetentionPolicy{
+			Default: model.RetentionRule{ReportsDays: 7},
+			Apps: map[string]model.RetentionRule{
+				disabledApp: {DisabledAppInputs: true},
+			},
+		},
+		time.Hour,
+	)
+}
+
+func (s *RetentionSynchronizerSuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestRetentionSynchronizerSuite(t *testing.T) {
+	suite.Run(t, new(RetentionSynchronizerSuite))
+}
+
+func (s *RetentionSynchronizerSuite) TestPrune() {
+	s.Nil(s.pruner.LastRun())
+	run := s.pruner.Prune(s.ctx)
+	s.Empty(run.Error)
+	s.Equal([]model.AppRetentionRun{
+		{AppContract: enabledApp, Reports: 1},
+		{AppContract: disabledApp, Inputs: 1},
+	}, run.Apps)
+	s.Equal(run, s.pruner.LastRun())
+
+	// the pruned rows are kept as tombstones
+	var count int
+	s.Require().NoError(s.db.Get(&count, `SELECT count(*) FROM convenience_reports WHERE pruned_at IS NOT NULL`))
+	s.Equal(1, count)
+	s.Require().NoError(s.db.Get(&count, `SELECT count(*) FROM convenience_inputs`))
+	s.Equal(2, count)
+
+	run = s.pruner.Prune(s.ctx)
+	s.Empty(run.Apps)
+}
+
+func (s *RetentionSynchronizerSuite) TestPruneNotifiesThePrunedApps() {
+	listener := &fakeChangeListener{}
+	s.pruner.ChangeListener = listener
+	s.Empty(s.pruner.Prune(s.ctx).Error)
+	s.Equal([][]common.Address{
+		{common.HexToAddress(enabledApp), common.HexToAddress(disabledApp)},
+	}, listener.calls)
+
+	// a run pruning nothing changes nothing
+	s.pruner.Prune(s.ctx)
+	s.Len(listener.calls, 1)
+}
@@ -1,6 +1,8 @@
 package synchronizernode
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/accounts/abi"
 )
 
@@ -14,6 +16,9 @@ func NewAbiDecoder(abi *abi.ABI) *AbiDecoder {
 
 func (s AbiDecoder) GetMapRaw(rawData []byte) (map[string]any, error) {
 	data := make(map[string]any)
+	if len(rawData) < 4 {
+		return nil, fmt.Errorf("raw data too short to hold a method id: %d bytes", len(rawData))
+	}
 	methodId := rawData[:4]
 	method, err := s.abi.MethodById(methodId)
 	if err != nil {
@@ -0,0 +1,72 @@
+package synchronizernode
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChangeListener is told the applications whose data was written by a sync cycle,
+// once their transactions are over, e.g. to invalidate cached responses.
+type ChangeListener interface {
+	AppsChanged(ctx context.Context, apps []common.Address)
+}
+
+type changedAppsKey struct{}
+
+// changedApps collects the applications written by the synchronizers of a cycle.
+// A rolled back write is collected too, which only costs a spurious change.
+type changedApps struct {
+	mu   sync.Mutex
+	apps map[common.Address]struct{}
+}
+
+func withChangedApps(ctx context.Context) (context.Context, *changedApps) {
+	changed := &changedApps{apps: make(map[common.Address]struct{})}
+	return context.WithValue(ctx, changedAppsKey{}, changed), changed
+}
+
+// markAppChanged records a write of the application when the cycle collects them.
+func markAppChanged(ctx context.Context, app common.Address) {
+	changed, ok := ctx.Value(changedAppsKey{}).(*changedApps)
+	if !ok {
+		return
+	}
+	changed.mu.Lock()
+	defer changed.mu.Unlock()
+	changed.apps[app] = struct{}{}
+}
+
+func (c *changedApps) list() []common.Address {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	apps := make([]common.Address, 0, len(c.apps))
+	for app := range c.apps {
+		apps = append(apps, app)
+	}
+	return apps
+}
+
+// trackChanges starts collecting the changed applications when there is a listener.
+// The returned function reports them and must run after the transactions are over.
+func (s SynchronizerCreateWorker) trackChanges(ctx context.Context) (context.Context, func()) {
+	if s.ChangeListener == nil {
+		return ctx, func() {}
+	}
+	ctx, changed := withChangedApps(ctx)
+	return ctx, func() {
+		if apps := changed.list(); len(apps) > 0 {
+			s.ChangeListener.AppsChanged(ctx, apps)
+		}
+	}
+}
+
+// notifyAppChanged reports the application to the listener, if any,
+// for the writes made outside of a sync cycle once their transaction is committed.
+func notifyAppChanged(ctx context.Context, listener ChangeListener, app common.Address) {
+	if listener == nil {
+		return
+	}
+	listener.AppsChanged(ctx, []common.Address{app})
+}
@@ -0,0 +1,59 @@
+package synchronizernode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type fakeChangeListener struct {
+	calls [][]common.Address
+}
+
+func (f *fakeChangeListener) AppsChanged(ctx context.Context, apps []common.Address) {
+	f.calls = append(f.calls, apps)
+}
+
+type ChangedAppsSuite struct {
+	suite.Suite
+}
+
+func TestChangedAppsSuite(t *testing.T) {
+	suite.Run(t, new(ChangedAppsSuite))
+}
+
+func (s *ChangedAppsSuite) TestNotifiesEachAppOnce() {
+	listener := &fakeChangeListener{}
+	worker := SynchronizerCreateWorker{ChangeListener: listener}
+	ctx, notify := worker.trackChanges(context.Background())
+	app := common.HexToAddress("0x01")
+	markAppChanged(ctx, app)
+	markAppChanged(ctx, app)
+	s.Empty(listener.calls)
+	notify()
+	s.Equal([][]common.Address{{app}}, listener.calls)
+}
+
+func (s *ChangedAppsSuite) TestNothingChanged() {
+	listener := &fakeChangeListener{}
+	worker := SynchronizerCreateWorker{ChangeListener: listener}
+	_, notify := worker.trackChanges(context.Background())
+	notify()
+	s.Empty(listener.calls)
+}
+
+func (s *ChangedAppsSuite) TestWithoutListener() {
+	ctx, notify := SynchronizerCreateWorker{}.trackChanges(context.Background())
+	markAppChanged(ctx, common.HexToAddress("0x01"))
+	notify()
+}
+
+func (s *ChangedAppsSuite) TestNotifyAppChanged() {
+	listener := &fakeChangeListener{}
+	app := common.HexToAddress("0x01")
+	notifyAppChanged(context.Background(), listener, app)
+	s.Equal([][]common.Address{{app}}, listener.calls)
+	notifyAppChanged(context.Background(), nil, app)
+}
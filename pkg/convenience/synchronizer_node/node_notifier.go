@@ -0,0 +1,207 @@
+package synchronizernode
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Channel used by the node database triggers to notify new data.
+const NODE_NOTIFY_CHANNEL = "rollups_graphql_sync"
+
+// Tables from the node database watched by the triggers.
+const (
+	NODE_TABLE_APPLICATION = "application"
+	NODE_TABLE_INPUT       = "input"
+	NODE_TABLE_OUTPUT      = "output"
+	NODE_TABLE_REPORT      = "report"
+)
+
+const (
+	listenerMinReconnect = 1 * time.Second
+	listenerMaxReconnect = 1 * time.Minute
+)
+
+const notifyFunctionSQL = `
+CREATE OR REPLACE FUNCTION rollups_graphql_notify() RETURNS trigger AS $$
+DECLARE
+	app_id bigint;
+BEGIN
+	IF TG_TABLE_NAME = 'application' THEN
+		app_id := NEW.id;
+	ELSIF TG_TABLE_NAME = 'input' THEN
+		app_id := NEW.epoch_application_id;
+	ELSE
+		app_id := NEW.input_epoch_application_id;
+	END IF;
+	PERFORM pg_notify('` + NODE_NOTIFY_CHANNEL + `', TG_TABLE_NAME || ':' || app_id);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+`
+
+const notifyTriggerSQL = `
+DROP TRIGGER IF EXISTS rollups_graphql_notify_%[1]s ON %[1]s;
+CREATE TRIGGER rollups_graphql_notify_%[1]s
+	AFTER INSERT OR UPDATE ON %[1]s
+	FOR EACH ROW EXECUTE FUNCTION rollups_graphql_notify();
+`
+
+// A change notified by the node database.
+type NodeChange struct {
+	Table string
+	AppID uint64
+}
+
+// NodeNotifier listens for changes on the node database using LISTEN/NOTIFY.
+type NodeNotifier struct {
+	connectionURL string
+	Db            *sqlx.DB
+	// Install the triggers on Prepare instead of only checking for them
+	InstallTriggersOnPrepare bool
+}
+
+func NewNodeNotifier(connectionURL string, db *sqlx.DB) *NodeNotifier {
+	return &NodeNotifier{
+		connectionURL: connectionURL,
+		Db:            db,
+	}
+}
+
+func watchedNodeTables() []string {
+	return []string{NODE_TABLE_APPLICATION, NODE_TABLE_INPUT, NODE_TABLE_OUTPUT, NODE_TABLE_REPORT}
+}
+
+// InstallTriggers creates the notify function and the triggers on the node tables.
+// The database user needs the privileges to create triggers on those tables.
+func (n *NodeNotifier) InstallTriggers(ctx context.Context) error {
+	tx, err := n.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, notifyFunctionSQL)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to create notify function: %w", err)
+	}
+	for _, table := range watchedNodeTables() {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(notifyTriggerSQL, table))
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to create notify trigger on %s: %w", table, err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "Node notify triggers installed", "tables", watchedNodeTables())
+	return nil
+}
+
+// MissingTriggers returns the watched tables without the notify trigger.
+func (n *NodeNotifier) MissingTriggers(ctx context.Context) ([]string, error) {
+	missing := []string{}
+	for _, table := range watchedNodeTables() {
+		var count int
+		// the trigger must be on the table the unqualified name resolves to,
+		// as the one created by InstallTriggers
+		err := n.Db.GetContext(ctx, &count, `
+			SELECT count(*) FROM pg_trigger t
+			JOIN pg_class c ON c.oid = t.tgrelid
+			WHERE t.tgname = $1 AND c.relname = $2
+				AND pg_table_is_visible(c.oid) AND NOT t.tgisinternal`,
+			"rollups_graphql_notify_"+table,
+			table,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			missing = append(missing, table)
+		}
+	}
+	return missing, nil
+}
+
+// Prepare installs the triggers or warns about the missing ones.
+// It runs once the node database is reachable.
+func (n *NodeNotifier) Prepare(ctx context.Context) error {
+	if n.InstallTriggersOnPrepare {
+		return n.InstallTriggers(ctx)
+	}
+	missing, err := n.MissingTriggers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		slog.WarnContext(ctx, "Node notify triggers not found, relying on the polling fallback for those tables",
+			"tables", missing)
+	}
+	return nil
+}
+
+// Listen starts listening on the notify channel.
+// The returned channel is closed when the context is done.
+// After a reconnection a change without table is sent,
+// because notifications may have been lost in between.
+func (n *NodeNotifier) Listen(ctx context.Context) (<-chan NodeChange, error) {
+	listener := pq.NewListener(n.connectionURL, listenerMinReconnect, listenerMaxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				slog.WarnContext(ctx, "Node listener event", "event", event, "error", err)
+			}
+		},
+	)
+	err := listener.Listen(NODE_NOTIFY_CHANNEL)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	changes := make(chan NodeChange)
+	go func() {
+		defer close(changes)
+		defer listener.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case notification := <-listener.Notify:
+				change := NodeChange{}
+				if notification != nil {
+					change, err = ParseNodeChange(notification.Extra)
+					if err != nil {
+						slog.WarnContext(ctx, "Ignoring malformed node notification",
+							"payload", notification.Extra, "error", err)
+						continue
+					}
+				}
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// ParseNodeChange parses the payload sent by the notify triggers: "<table>:<app id>".
+func ParseNodeChange(payload string) (NodeChange, error) {
+	table, rawAppID, found := strings.Cut(payload, ":")
+	if !found {
+		return NodeChange{}, fmt.Errorf("unexpected payload format")
+	}
+	appID, err := strconv.ParseUint(rawAppID, 10, 64)
+	if err != nil {
+		return NodeChange{}, err
+	}
+	return NodeChange{Table: table, AppID: appID}, nil
+}
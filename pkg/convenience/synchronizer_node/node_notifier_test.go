@@ -0,0 +1,91 @@
+package synchronizernode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type NodeNotifierSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (s *NodeNotifierSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func TestNodeNotifierSuite(t *testing.T) {
+	suite.Run(t, new(NodeNotifierSuite))
+}
+
+func (s *NodeNotifierSuite) TestParseNodeChange() {
+	change, err := ParseNodeChange("output:7")
+	s.Require().NoError(err)
+	s.Equal(NODE_TABLE_OUTPUT, change.Table)
+	s.Equal(uint64(7), change.AppID)
+}
+
+func (s *NodeNotifierSuite) TestParseMalformedNodeChange() {
+	_, err := ParseNodeChange("output")
+	s.Error(err)
+	_, err = ParseNodeChange("output:abc")
+	s.Error(err)
+}
+
+func (s *NodeNotifierSuite) TestSyncStepsForEachTable() {
+	appSteps := syncStepsFor(NodeChange{Table: NODE_TABLE_APPLICATION})
+	s.Equal(syncSteps{apps: true}, appSteps)
+
+	inputSteps := syncStepsFor(NodeChange{Table: NODE_TABLE_INPUT})
+	s.Equal(syncSteps{inputs: true, inputState: true, apps: true}, inputSteps)
+
+	outputSteps := syncStepsFor(NodeChange{Table: NODE_TABLE_OUTPUT})
+	s.Equal(syncSteps{outputs: true, proofs: true, executions: true}, outputSteps)
+
+	reportSteps := syncStepsFor(NodeChange{Table: NODE_TABLE_REPORT})
+	s.Equal(syncSteps{reports: true}, reportSteps)
+
+	// the empty change is sent after a listener reconnection
+	s.Equal(allSyncSteps(), syncStepsFor(NodeChange{}))
+}
+
+func (s *NodeNotifierSuite) TestWaitForChangesMergesPendingNotifications() {
+	changes := make(chan NodeChange, 2)
+	changes <- NodeChange{Table: NODE_TABLE_REPORT, AppID: 1}
+	changes <- NodeChange{Table: NODE_TABLE_INPUT, AppID: 2}
+	worker := SynchronizerCreateWorker{}
+	ctx, cancel := context.WithTimeout(s.ctx, time.Second)
+	defer cancel()
+	steps, err := worker.waitForChanges(ctx, changes)
+	s.Require().NoError(err)
+	s.Equal(syncSteps{
+		inputs:     true,
+		inputState: true,
+		reports:    true,
+		apps:       true,
+		appIDs:     map[uint64]bool{1: true, 2: true},
+	}, steps)
+}
+
+func (s *NodeNotifierSuite) TestSyncStepsOnlyAffectTheNotifiedApps() {
+	steps := syncStepsFor(NodeChange{Table: NODE_TABLE_OUTPUT, AppID: 3})
+	s.True(steps.affects(3))
+	s.False(steps.affects(4))
+
+	// a change for all the applications wins over the ones of a single application
+	steps = steps.merge(syncStepsFor(NodeChange{}))
+	s.True(steps.affects(4))
+	s.True(allSyncSteps().affects(4))
+}
+
+func (s *NodeNotifierSuite) TestFallbackDelay() {
+	worker := SynchronizerCreateWorker{}
+	s.Equal(DEFAULT_DELAY, worker.fallbackDelay())
+	worker.Notifier = &NodeNotifier{}
+	s.Equal(DEFAULT_NOTIFY_FALLBACK, worker.fallbackDelay())
+	worker.NotifyFallback = 10 * time.Minute
+	s.Equal(10*time.Minute, worker.fallbackDelay())
+}
@@ -175,6 +175,29 @@ func (s *RawRepository) FindAllAppsRef(ctx context.Context) ([]RawApplication, e
 	return apps, nil
 }
 
+// FindDisabledAppContracts returns the addresses of the applications disabled on the node.
+func (s *RawRepository) FindDisabledAppContracts(ctx context.Context) ([]string, error) {
+	apps := []RawApplication{}
+	err := s.Db.SelectContext(ctx, &apps, `
+		SELECT
+			id,
+			name,
+			iapplication_address as application_address
+		FROM
+			application
+		WHERE
+			state = 'DISABLED'
+		ORDER BY id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	appContracts := make([]string, 0, len(apps))
+	for _, app := range apps {
+		appContracts = append(appContracts, app.ApplicationAddress.Hex())
+	}
+	return appContracts, nil
+}
+
 func (s *RawRepository) First50RawInputsGteRefWithStatus(ctx context.Context, inputRef repository.RawInputRef, status string) ([]RawInput, error) {
 	query := `
 		SELECT
@@ -598,3 +621,250 @@ func (s *RawRepository) FindAllOutputsExecutedAfter(ctx context.Context, outputR
 
 	return outputs, nil
 }
+
+const RAW_INPUT_COLUMNS = `
+		i.index,
+		i.raw_data,
+		i.block_number,
+		i.status,
+		i.machine_hash,
+		i.outputs_hash,
+		i.epoch_index,
+		i.epoch_application_id,
+		i.transaction_reference,
+		i.created_at,
+		i.updated_at,
+		i.snapshot_uri,
+		a.iapplication_address as application_address
+`
+
+const RAW_OUTPUT_COLUMNS = `
+		o.index,
+		o.input_index,
+		o.raw_data,
+		o.hash,
+		o.output_hashes_siblings,
+		o.execution_transaction_hash,
+		o.created_at,
+		o.updated_at,
+		o.input_epoch_application_id,
+		a.iapplication_address as app_contract
+`
+
+func (s *RawRepository) selectInputs(ctx context.Context, query string, args ...any) ([]RawInput, error) {
+	result, err := s.Db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to execute input query", "query", query, "error", err)
+		return nil, err
+	}
+	defer result.Close()
+	inputs := []RawInput{}
+	for result.Next() {
+		var input RawInput
+		err := result.StructScan(&input)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan row into RawInput struct", "error", err)
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, result.Err()
+}
+
+func (s *RawRepository) selectOutputs(ctx context.Context, query string, args ...any) ([]Output, error) {
+	result, err := s.Db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to execute output query", "query", query, "error", err)
+		return nil, err
+	}
+	defer result.Close()
+	outputs := []Output{}
+	for result.Next() {
+		var output Output
+		err := result.StructScan(&output)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan row into Output struct", "error", err)
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, result.Err()
+}
+
+// FindAppInputsGtIndex returns the inputs of one application after the given index.
+// A nil index returns the inputs from the beginning.
+func (s *RawRepository) FindAppInputsGtIndex(ctx context.Context, appID uint64, inputIndex *uint64, limit uint64) ([]RawInput, error) {
+	query := `SELECT ` + RAW_INPUT_COLUMNS + `
+	FROM
+		input i
+	INNER JOIN
+		application a
+	ON
+		a.id = i.epoch_application_id
+	WHERE
+		i.epoch_application_id = $1 AND ($2::numeric IS NULL OR i.index > $2)
+	ORDER BY
+		i.index ASC
+	LIMIT $3`
+	return s.selectInputs(ctx, query, appID, inputIndex, limit)
+}
+
+// FindAppInputsGteIndexWithStatus returns the inputs of one application with the given status.
+func (s *RawRepository) FindAppInputsGteIndexWithStatus(ctx context.Context, appID uint64, inputIndex uint64, status string, limit uint64) ([]RawInput, error) {
+	query := `SELECT ` + RAW_INPUT_COLUMNS + `
+	FROM
+		input i
+	INNER JOIN
+		application a
+	ON
+		a.id = i.epoch_application_id
+	WHERE
+		i.epoch_application_id = $1 AND i.index >= $2 AND i.status = $3
+	ORDER BY
+		i.index ASC
+	LIMIT $4`
+	return s.selectInputs(ctx, query, appID, inputIndex, status, limit)
+}
+
+// FindAppReportsGtIndex returns the reports of one application after the given index.
+// Use a negative index to start from the beginning.
+func (s *RawRepository) FindAppReportsGtIndex(ctx context.Context, appID uint64, reportIndex int64, limit uint64) ([]RawReport, error) {
+	reports := []RawReport{}
+	result, err := s.Db.QueryxContext(ctx, `
+		SELECT
+			r.index,
+			r.input_index,
+			r.input_epoch_application_id,
+			r.raw_data,
+			a.iapplication_address as app_contract
+		FROM
+			report r
+		INNER JOIN
+			application a
+		ON
+			a.id = r.input_epoch_application_id
+		WHERE
+			r.input_epoch_application_id = $1 AND r.index > $2
+		ORDER BY
+			r.index ASC
+		LIMIT $3
+	`, appID, reportIndex, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to execute query in FindAppReportsGtIndex", "error", err)
+		return nil, err
+	}
+	defer result.Close()
+
+	for result.Next() {
+		var report RawReport
+		err := result.StructScan(&report)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to scan row into Report struct", "error", err)
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, result.Err()
+}
+
+// FindAppOutputsGtIndex returns the outputs of one application after the given index.
+// A nil index returns the outputs from the beginning.
+func (s *RawRepository) FindAppOutputsGtIndex(ctx context.Context, appID uint64, outputIndex *uint64, limit uint64) ([]Output, error) {
+	query := `SELECT ` + RAW_OUTPUT_COLUMNS + `
+		FROM
+			output o
+		INNER JOIN application a
+			ON a.id = o.input_epoch_application_id
+		WHERE
+			o.input_epoch_application_id = $1 AND ($2::numeric IS NULL OR o.index > $2)
+		ORDER BY
+			o.index ASC
+		LIMIT $3`
+	return s.selectOutputs(ctx, query, appID, outputIndex, limit)
+}
+
+// FindAppOutputsWithProofGteIndex returns the outputs of one application with an accepted claim.
+func (s *RawRepository) FindAppOutputsWithProofGteIndex(ctx context.Context, appID uint64, outputIndex uint64, limit uint64) ([]Output, error) {
+	query := `SELECT ` + RAW_OUTPUT_COLUMNS + `
+		FROM
+			output o
+		INNER JOIN application a
+			ON a.id = o.input_epoch_application_id
+		INNER JOIN input i on
+			o.input_index = i.index
+			and a.id = i.epoch_application_id
+		INNER JOIN epoch e on
+			i.epoch_index = e.index
+			and i.epoch_application_id = e.application_id
+		WHERE
+			o.input_epoch_application_id = $1
+				AND
+			o.output_hashes_siblings IS NOT NULL
+				AND
+			o.index >= $2
+				AND
+			e.status = 'CLAIM_ACCEPTED'
+		ORDER BY
+			o.index ASC
+		LIMIT $3`
+	return s.selectOutputs(ctx, query, appID, outputIndex, limit)
+}
+
+// FindAppOutputsExecutedAfter returns the executed outputs of one application updated after the reference.
+func (s *RawRepository) FindAppOutputsExecutedAfter(ctx context.Context, appID uint64, outputRef *repository.RawOutputRef, limit uint64) ([]Output, error) {
+	query := `SELECT ` + RAW_OUTPUT_COLUMNS + `
+		FROM
+			output o
+		INNER JOIN application a
+		ON
+			a.id = o.input_epoch_application_id
+		WHERE
+			o.input_epoch_application_id = $1
+				AND
+			o.execution_transaction_hash IS NOT NULL
+				AND
+			(
+				(o.updated_at > $2)
+					OR
+				(o.updated_at = $2 AND o.index > $3)
+			)
+		ORDER BY
+			o.updated_at ASC,
+			o.index ASC
+		LIMIT $4`
+	return s.selectOutputs(ctx, query, appID, outputRef.UpdatedAt, outputRef.OutputIndex, limit)
+}
+
+// FindAppInputByIndex returns one input of an application or nil when it does not exist.
+func (s *RawRepository) FindAppInputByIndex(ctx context.Context, appID uint64, inputIndex uint64) (*RawInput, error) {
+	query := `SELECT ` + RAW_INPUT_COLUMNS + `
+	FROM
+		input i
+	INNER JOIN
+		application a
+	ON
+		a.id = i.epoch_application_id
+	WHERE
+		i.epoch_application_id = $1 AND i.index = $2`
+	inputs, err := s.selectInputs(ctx, query, appID, inputIndex)
+	if err != nil || len(inputs) == 0 {
+		return nil, err
+	}
+	return &inputs[0], nil
+}
+
+// FindAppOutputByIndex returns one output of an application or nil when it does not exist.
+func (s *RawRepository) FindAppOutputByIndex(ctx context.Context, appID uint64, outputIndex uint64) (*Output, error) {
+	query := `SELECT ` + RAW_OUTPUT_COLUMNS + `
+		FROM
+			output o
+		INNER JOIN application a
+			ON a.id = o.input_epoch_application_id
+		WHERE
+			o.input_epoch_application_id = $1 AND o.index = $2`
+	outputs, err := s.selectOutputs(ctx, query, appID, outputIndex)
+	if err != nil || len(outputs) == 0 {
+		return nil, err
+	}
+	return &outputs[0], nil
+}
@@ -82,6 +82,7 @@ func (s *SynchronizerAppCreator) syncApps(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		markAppChanged(ctx, rawApp.ApplicationAddress)
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
 	"github.com/jmoiron/sqlx"
@@ -48,6 +49,7 @@ func (s *SynchronizerAppCreate) SetupTest() {
 	sqliteFileName := filepath.Join(tempDir, "application.sqlite3")
 
 	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, db))
 	s.container = convenience.NewContainer(db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -0,0 +1,148 @@
+package synchronizernode
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Upper bound of batches an application syncs in a single cycle,
+// so a busy application returns its worker to the pool from time to time.
+const MAX_APP_BATCHES_PER_CYCLE = 20
+
+// syncCyclePerApp runs the synchronizers of each affected application on a bounded worker pool,
+// in a transaction per batch of each application with the atomic cycles.
+// Each application uses its own checkpoints, so a failure is logged
+// and only delays the application where it happened.
+func (s SynchronizerCreateWorker) syncCyclePerApp(ctx context.Context, steps syncSteps) error {
+	if steps.apps {
+		// new applications are synced first to be part of this cycle
+		err := traceStep(ctx, "apps", s.SynchronizerAppCreate.SyncApps)
+		if err != nil {
+			return err
+		}
+	}
+	apps, err := s.SynchronizerAppCreate.AppRepository.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the atomic cycles of a single pass sync the applications one at a time
+	pool := make(chan struct{}, max(s.AppWorkers, 1))
+	var wg sync.WaitGroup
+Loop:
+	for _, app := range apps {
+		if !steps.affects(app.ID) {
+			continue
+		}
+		select {
+		case pool <- struct{}{}:
+		case <-ctx.Done():
+			break Loop
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-pool }()
+			err := s.syncApplication(ctx, app.ID, steps)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Failed to sync application",
+					"app_id", app.ID,
+					"app_contract", app.ApplicationAddress,
+					"error", err,
+				)
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// syncApplication runs the affected synchronizers for one application.
+// The creation steps keep fetching while they receive full batches.
+func (s SynchronizerCreateWorker) syncApplication(ctx context.Context, appID uint64, steps syncSteps) (err error) {
+	ctx, span := tracing.Start(ctx, "sync.app", attribute.Int64("app.id", int64(appID)))
+	defer func() { tracing.End(span, err) }()
+	lock, err := repository.LockAppSync(ctx, s.inputRepository.Db, appID)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock(ctx)
+	ctx, notifyChanges := s.trackChanges(ctx)
+	defer notifyChanges()
+	if s.Atomic != nil {
+		return s.syncApplicationAtomic(ctx, appID, s.atomicSteps(steps))
+	}
+	if steps.inputs {
+		err := traceStep(ctx, "inputs", func(ctx context.Context) error {
+			return drainBatches(ctx, func(ctx context.Context) (int, error) {
+				return s.SynchronizerCreateInput.SyncAppInputs(ctx, appID)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if steps.inputState {
+		err := traceStep(ctx, "inputState", func(ctx context.Context) error {
+			return s.SynchronizerUpdate.SyncAppInputStatus(ctx, appID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if steps.reports {
+		err := traceStep(ctx, "reports", func(ctx context.Context) error {
+			return drainBatches(ctx, func(ctx context.Context) (int, error) {
+				return s.SynchronizerReport.SyncAppReports(ctx, appID)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if steps.outputs {
+		err := traceStep(ctx, "outputs", func(ctx context.Context) error {
+			return drainBatches(ctx, func(ctx context.Context) (int, error) {
+				return s.SynchronizerOutputCreate.SyncAppOutputs(ctx, appID)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if steps.proofs {
+		err := traceStep(ctx, "proofs", func(ctx context.Context) error {
+			return s.SynchronizerOutputUpdate.SyncAppOutputsProofs(ctx, appID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if steps.executions {
+		err := traceStep(ctx, "executions", func(ctx context.Context) error {
+			return s.SynchronizerOutputExecuted.SyncAppOutputsExecution(ctx, appID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func drainBatches(ctx context.Context, syncBatch func(ctx context.Context) (int, error)) error {
+	for i := 0; i < MAX_APP_BATCHES_PER_CYCLE; i++ {
+		total, err := syncBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if total < int(LIMIT) || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package synchronizernode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AppPoolSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (s *AppPoolSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func TestAppPoolSuite(t *testing.T) {
+	suite.Run(t, new(AppPoolSuite))
+}
+
+func (s *AppPoolSuite) TestDrainBatchesStopsOnPartialBatch() {
+	calls := 0
+	err := drainBatches(s.ctx, func(ctx context.Context) (int, error) {
+		calls++
+		if calls < 3 {
+			return int(LIMIT), nil
+		}
+		return 1, nil
+	})
+	s.Require().NoError(err)
+	s.Equal(3, calls)
+}
+
+func (s *AppPoolSuite) TestDrainBatchesIsBounded() {
+	calls := 0
+	err := drainBatches(s.ctx, func(ctx context.Context) (int, error) {
+		calls++
+		return int(LIMIT), nil
+	})
+	s.Require().NoError(err)
+	s.Equal(MAX_APP_BATCHES_PER_CYCLE, calls)
+}
+
+func (s *AppPoolSuite) TestDrainBatchesReturnsError() {
+	expected := errors.New("node unavailable")
+	err := drainBatches(s.ctx, func(ctx context.Context) (int, error) {
+		return 0, expected
+	})
+	s.ErrorIs(err, expected)
+}
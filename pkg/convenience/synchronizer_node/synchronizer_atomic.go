@@ -0,0 +1,240 @@
+package synchronizernode
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+)
+
+// Default duration aimed at by the transactions of the atomic cycles
+const DEFAULT_ATOMIC_TARGET = time.Second
+
+// Bounds of the batch size of the atomic cycles
+const MIN_ATOMIC_BATCH = uint64(5)
+const MAX_ATOMIC_BATCH = LIMIT * MAX_APP_BATCHES_PER_CYCLE
+
+// AtomicCycles commits the steps of a cycle of an application in a single transaction,
+// so the readers never see, e.g., the outputs of an input not yet marked as processed
+// or the proofs of outputs not synced yet.
+// Each transaction first syncs a batch of the inputs and of their status, which fixes
+// the inputs the other steps may sync rows of: the reports, outputs, proofs and executions
+// stop at the first input still waiting for its status, or not synced yet.
+// The applications step is left out, it syncs all the applications at once
+// before their cycles, and a new application has no rows to be consistent with.
+// The batch size of each application adapts to keep its transactions around the target
+// duration: it halves after a slower transaction and doubles after a fast one that left
+// rows to sync.
+type AtomicCycles struct {
+	Target time.Duration
+	mu     sync.Mutex
+	sizes  map[uint64]uint64
+}
+
+func NewAtomicCycles(target time.Duration) *AtomicCycles {
+	if target <= 0 {
+		target = DEFAULT_ATOMIC_TARGET
+	}
+	return &AtomicCycles{
+		Target: target,
+		sizes:  map[uint64]uint64{},
+	}
+}
+
+// BatchSize returns the current batch size of the application.
+func (a *AtomicCycles) BatchSize(appID uint64) uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if size, ok := a.sizes[appID]; ok {
+		return size
+	}
+	return LIMIT
+}
+
+// record adapts the batch size of the application to the duration of a transaction
+// that synced batches of the given size.
+func (a *AtomicCycles) record(appID uint64, size uint64, elapsed time.Duration, full bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case elapsed > a.Target:
+		size = max(size/2, MIN_ATOMIC_BATCH)
+	case full && elapsed < a.Target/2:
+		size = min(size*2, MAX_ATOMIC_BATCH)
+	}
+	a.sizes[appID] = size
+}
+
+// atomicStep syncs a batch of a step, returning the number of rows created.
+type atomicStep struct {
+	name string
+	sync func(ctx context.Context, appID uint64, limit uint64) (int, error)
+}
+
+// atomicSteps are the steps of a transaction of the atomic cycles.
+type atomicSteps struct {
+	// Sync the inputs and their status
+	inputs []atomicStep
+	// Sync the rows of the inputs before the bound fixed by the input steps
+	outputs []atomicStep
+}
+
+// atomicSteps returns the affected steps of the cycle.
+func (s SynchronizerCreateWorker) atomicSteps(steps syncSteps) atomicSteps {
+	updated := func(err error) (int, error) {
+		return 0, err
+	}
+	var atomic atomicSteps
+	if steps.inputs {
+		atomic.inputs = append(atomic.inputs, atomicStep{"inputs", s.SynchronizerCreateInput.syncAppInputs})
+	}
+	if steps.inputState {
+		atomic.inputs = append(atomic.inputs, atomicStep{"inputState",
+			func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+				return updated(s.SynchronizerUpdate.syncAppInputStatus(ctx, appID, limit))
+			},
+		})
+	}
+	if steps.reports {
+		atomic.outputs = append(atomic.outputs, atomicStep{"reports", s.SynchronizerReport.syncAppReports})
+	}
+	if steps.outputs {
+		atomic.outputs = append(atomic.outputs, atomicStep{"outputs", s.SynchronizerOutputCreate.syncAppOutputs})
+	}
+	if steps.proofs {
+		atomic.outputs = append(atomic.outputs, atomicStep{"proofs",
+			func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+				return updated(s.SynchronizerOutputUpdate.syncAppOutputsProofs(ctx, appID, limit))
+			},
+		})
+	}
+	if steps.executions {
+		atomic.outputs = append(atomic.outputs, atomicStep{"executions",
+			func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+				return updated(s.SynchronizerOutputExecuted.syncAppOutputsExecution(ctx, appID, limit))
+			},
+		})
+	}
+	return atomic
+}
+
+// syncApplicationAtomic runs the steps of the application in transactions
+// holding all of them, while the creation steps receive full batches.
+func (s SynchronizerCreateWorker) syncApplicationAtomic(ctx context.Context, appID uint64, steps atomicSteps) error {
+	for i := 0; i < MAX_APP_BATCHES_PER_CYCLE; i++ {
+		size := s.Atomic.BatchSize(appID)
+		start := time.Now()
+		full, err := s.syncAtomicBatch(ctx, appID, steps, size)
+		if err != nil {
+			return err
+		}
+		s.Atomic.record(appID, size, time.Since(start), full)
+		if !full || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// syncAtomicBatch runs a batch of each step in a single transaction,
+// reporting whether a creation step received a full batch.
+func (s SynchronizerCreateWorker) syncAtomicBatch(ctx context.Context, appID uint64, steps atomicSteps, limit uint64) (bool, error) {
+	txCtx, tx, err := repository.StartTransactionContext(ctx, s.inputRepository.Db)
+	if err != nil {
+		return false, err
+	}
+	full, err := s.syncAppSteps(txCtx, appID, steps, limit)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			slog.ErrorContext(ctx, "transaction rollback error", "err", rollbackErr)
+		}
+		return false, err
+	}
+	return full, tx.Commit()
+}
+
+// syncAppSteps runs a batch of each step in the transaction of the context,
+// bounding the rows of the output steps to the inputs synced by the input steps.
+func (s SynchronizerCreateWorker) syncAppSteps(ctx context.Context, appID uint64, steps atomicSteps, limit uint64) (bool, error) {
+	full := false
+	run := func(ctx context.Context, steps []atomicStep) error {
+		for _, step := range steps {
+			err := traceStep(ctx, step.name, func(ctx context.Context) error {
+				total, err := step.sync(ctx, appID, limit)
+				full = full || total >= int(limit)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err := run(ctx, steps.inputs)
+	if err != nil {
+		return false, err
+	}
+	if len(steps.outputs) == 0 {
+		return full, nil
+	}
+	ctx, err = s.withSyncedInputs(ctx, appID)
+	if err != nil {
+		return false, err
+	}
+	err = run(ctx, steps.outputs)
+	if err != nil {
+		return false, err
+	}
+	return full, nil
+}
+
+type inputBoundKey struct{}
+
+// withSyncedInputs bounds the rows synced with the context to the inputs before
+// the first one waiting for its status, or after the last synced input when none is.
+func (s SynchronizerCreateWorker) withSyncedInputs(ctx context.Context, appID uint64) (context.Context, error) {
+	pending, err := s.inputRefRepository.FindFirstInputByStatusNoneByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		return context.WithValue(ctx, inputBoundKey{}, pending.InputIndex), nil
+	}
+	// a quarantined input counts as synced, so it does not hold back the rows after it
+	last, err := s.SynchronizerCreateInput.appCheckpoint(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	bound := uint64(0)
+	if last != nil {
+		bound = *last + 1
+	}
+	return context.WithValue(ctx, inputBoundKey{}, bound), nil
+}
+
+// boundToSyncedInputs keeps the rows before the first one of an input past the bound
+// of the context, if any. The rows after it are left for a later transaction,
+// as are the ones of the other inputs, so the cursors of the steps do not skip them.
+func boundToSyncedInputs[T any](ctx context.Context, rows []T, inputIndex func(T) uint64) []T {
+	bound, ok := ctx.Value(inputBoundKey{}).(uint64)
+	if !ok {
+		return rows
+	}
+	for i, row := range rows {
+		if inputIndex(row) >= bound {
+			return rows[:i]
+		}
+	}
+	return rows
+}
+
+func reportInputIndex(report RawReport) uint64 {
+	return report.InputIndex
+}
+
+func outputInputIndex(output Output) uint64 {
+	return output.InputIndex
+}
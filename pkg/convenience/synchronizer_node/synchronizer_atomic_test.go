@@ -0,0 +1,202 @@
+package synchronizernode
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+)
+
+type AtomicCyclesSuite struct {
+	suite.Suite
+}
+
+func TestAtomicCyclesSuite(t *testing.T) {
+	suite.Run(t, new(AtomicCyclesSuite))
+}
+
+func (s *AtomicCyclesSuite) TestDefaultTarget() {
+	s.Equal(DEFAULT_ATOMIC_TARGET, NewAtomicCycles(0).Target)
+	s.Equal(LIMIT, NewAtomicCycles(time.Second).BatchSize(1))
+}
+
+func (s *AtomicCyclesSuite) TestShrinksSlowTransactions() {
+	atomic := NewAtomicCycles(time.Second)
+	atomic.record(1, LIMIT, 2*time.Second, true)
+	s.Equal(LIMIT/2, atomic.BatchSize(1))
+	for range 10 {
+		atomic.record(1, atomic.BatchSize(1), 2*time.Second, false)
+	}
+	s.Equal(MIN_ATOMIC_BATCH, atomic.BatchSize(1))
+	// the other applications keep their own size
+	s.Equal(LIMIT, atomic.BatchSize(2))
+}
+
+func (s *AtomicCyclesSuite) TestGrowsFastTransactionsWithRowsLeft() {
+	atomic := NewAtomicCycles(time.Second)
+	atomic.record(1, LIMIT, time.Millisecond, false)
+	// an application that caught up keeps its size
+	s.Equal(LIMIT, atomic.BatchSize(1))
+	atomic.record(1, LIMIT, time.Millisecond, true)
+	s.Equal(2*LIMIT, atomic.BatchSize(1))
+	for range 10 {
+		atomic.record(1, atomic.BatchSize(1), time.Millisecond, true)
+	}
+	s.Equal(MAX_ATOMIC_BATCH, atomic.BatchSize(1))
+	// a transaction between half and the whole target keeps the size
+	atomic.record(1, LIMIT, 700*time.Millisecond, true)
+	s.Equal(LIMIT, atomic.BatchSize(1))
+}
+
+const atomicApp = "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+
+type AtomicSyncSuite struct {
+	suite.Suite
+	ctx       context.Context
+	cancel    context.CancelFunc
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	worker    SynchronizerCreateWorker
+}
+
+func TestAtomicSyncSuite(t *testing.T) {
+	suite.Run(t, new(AtomicSyncSuite))
+}
+
+func (s *AtomicSyncSuite) SetupTest() {
+	var err error
+	commons.ConfigureLog(slog.LevelDebug)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "atomic.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &repository.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	inputRefRepository := &repository.RawInputRefRepository{Db: s.db}
+	s.worker = SynchronizerCreateWorker{
+		inputRepository:    inputRepository,
+		inputRefRepository: inputRefRepository,
+		SynchronizerCreateInput: &SynchronizerInputCreator{
+			InputRepository:       inputRepository,
+			RawInputRefRepository: inputRefRepository,
+			DeadLetterRepository:  &repository.DeadLetterRepository{Db: s.db},
+		},
+		Atomic: NewAtomicCycles(time.Minute),
+	}
+}
+
+func (s *AtomicSyncSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+	s.cancel()
+}
+
+// inputSteps create two inputs and give a status to the first one.
+func (s *AtomicSyncSuite) inputSteps() []atomicStep {
+	app := common.HexToAddress(atomicApp)
+	return []atomicStep{
+		{"inputs", func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+			for i := range 2 {
+				_, err := s.worker.inputRepository.Create(ctx, model.AdvanceInput{
+					ID:             strconv.Itoa(i),
+					Index:          i,
+					Status:         model.CompletionStatusUnprocessed,
+					Payload:        "0x",
+					AppContract:    app,
+					BlockTimestamp: time.Now(),
+				})
+				if err != nil {
+					return 0, err
+				}
+				err = s.worker.inputRefRepository.Create(ctx, repository.RawInputRef{
+					ID:          strconv.Itoa(i),
+					AppID:       appID,
+					InputIndex:  uint64(i),
+					AppContract: app.Hex(),
+					Status:      "NONE",
+					ChainID:     "31337",
+					CreatedAt:   time.Now(),
+				})
+				if err != nil {
+					return 0, err
+				}
+			}
+			return 2, nil
+		}},
+		{"inputState", func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+			err := s.worker.inputRefRepository.UpdateStatus(ctx, []repository.RawInputRef{
+				{AppID: appID, InputIndex: 0},
+			}, "ACCEPTED")
+			if err != nil {
+				return 0, err
+			}
+			return 0, s.worker.inputRepository.UpdateStatus(ctx, app, 0, model.CompletionStatusAccepted)
+		}},
+	}
+}
+
+func (s *AtomicSyncSuite) count(table string) int {
+	var count int
+	s.Require().NoError(s.db.Get(&count, "SELECT count(*) FROM "+table))
+	return count
+}
+
+func (s *AtomicSyncSuite) TestFailedStepRollsBackTheInputs() {
+	err := s.worker.syncApplicationAtomic(s.ctx, 1, atomicSteps{
+		inputs: s.inputSteps(),
+		outputs: []atomicStep{{"outputs", func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+			return 0, errors.New("outputs failed")
+		}}},
+	})
+	s.ErrorContains(err, "outputs failed")
+	s.Equal(0, s.count("convenience_inputs"))
+	s.Equal(0, s.count("convenience_input_raw_references"))
+}
+
+func (s *AtomicSyncSuite) TestOutputsBoundedToTheInputsWithStatus() {
+	var synced []Output
+	err := s.worker.syncApplicationAtomic(s.ctx, 1, atomicSteps{
+		inputs: s.inputSteps(),
+		outputs: []atomicStep{{"outputs", func(ctx context.Context, appID uint64, limit uint64) (int, error) {
+			// the second input is still waiting for its status
+			synced = boundToSyncedInputs(ctx, []Output{
+				{Index: 0, InputIndex: 0},
+				{Index: 1, InputIndex: 1},
+				{Index: 2, InputIndex: 1},
+			}, outputInputIndex)
+			return len(synced), nil
+		}}},
+	})
+	s.Require().NoError(err)
+	s.Equal([]Output{{Index: 0, InputIndex: 0}}, synced)
+	s.Equal(2, s.count("convenience_inputs"))
+
+	// once every input has its status, the rows up to the last synced input are kept
+	err = s.worker.inputRefRepository.UpdateStatus(s.ctx, []repository.RawInputRef{
+		{AppID: 1, InputIndex: 1},
+	}, "ACCEPTED")
+	s.Require().NoError(err)
+	ctx, err := s.worker.withSyncedInputs(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Len(boundToSyncedInputs(ctx, []Output{{InputIndex: 1}, {InputIndex: 2}}, outputInputIndex), 1)
+
+	// a quarantined input does not hold back the rows after it
+	_, err = s.worker.SynchronizerCreateInput.DeadLetterRepository.Create(s.ctx, repository.DeadLetter{
+		Kind: repository.DEAD_LETTER_INPUT, AppID: 1, RawIndex: 2, InputIndex: 2,
+	})
+	s.Require().NoError(err)
+	ctx, err = s.worker.withSyncedInputs(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Len(boundToSyncedInputs(ctx, []Output{{InputIndex: 1}, {InputIndex: 2}}, outputInputIndex), 2)
+}
@@ -0,0 +1,213 @@
+package synchronizernode
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SyncControl pauses, resumes and triggers the cycles of the synchronizer worker.
+// It outlives the restarts of the worker, so a paused synchronizer stays paused.
+// A nil *SyncControl is valid and never pauses.
+type SyncControl struct {
+	mu        sync.Mutex
+	paused    bool
+	triggered bool
+	status    model.SyncStatus
+	// wakes the worker up after any change
+	wake chan struct{}
+}
+
+func NewSyncControl() *SyncControl {
+	return &SyncControl{wake: make(chan struct{}, 1)}
+}
+
+// Pause stops the synchronizer after its current cycle.
+func (c *SyncControl) Pause() {
+	c.update(func() { c.paused = true })
+}
+
+func (c *SyncControl) Resume() {
+	c.update(func() { c.paused = false })
+}
+
+// Trigger runs a cycle of all synchronizers as soon as possible, even when paused.
+func (c *SyncControl) Trigger() {
+	c.update(func() { c.triggered = true })
+}
+
+func (c *SyncControl) Status() model.SyncStatus {
+	if c == nil {
+		return model.SyncStatus{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := c.status
+	status.Paused = c.paused
+	status.TriggerPending = c.triggered
+	return status
+}
+
+func (c *SyncControl) update(fn func()) {
+	c.mu.Lock()
+	fn()
+	c.mu.Unlock()
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (c *SyncControl) isPaused() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// takeTrigger consumes the pending trigger, if any.
+func (c *SyncControl) takeTrigger() bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	triggered := c.triggered
+	c.triggered = false
+	return triggered
+}
+
+// woken returns the channel signaled after any change, nil blocks forever.
+func (c *SyncControl) woken() <-chan struct{} {
+	if c == nil {
+		return nil
+	}
+	return c.wake
+}
+
+func (c *SyncControl) recordCycle(start time.Time, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.Cycles++
+	c.status.LastCycleAt = &start
+	c.status.LastCycleTook = time.Since(start).String()
+	c.status.LastError = ""
+	if err != nil {
+		c.status.LastError = err.Error()
+	}
+}
+
+// waitWhilePaused blocks until the synchronizer is resumed or a cycle is triggered.
+func (c *SyncControl) waitWhilePaused(ctx context.Context) error {
+	for c.isPaused() {
+		if c.takeTrigger() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.woken():
+		}
+	}
+	return nil
+}
+
+// SyncAdmin operates the synchronizer worker from the admin API.
+type SyncAdmin struct {
+	*SyncControl
+	worker SynchronizerCreateWorker
+}
+
+// NewSyncAdmin creates the admin of the worker, which must have its control set.
+func NewSyncAdmin(worker SynchronizerCreateWorker) SyncAdmin {
+	return SyncAdmin{SyncControl: worker.Control, worker: worker}
+}
+
+// Checkpoints returns the checkpoints of one or all applications.
+func (a SyncAdmin) Checkpoints(ctx context.Context, appContract *common.Address) ([]model.SyncCheckpoint, error) {
+	apps, err := a.worker.SynchronizerAppCreate.AppRepository.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := []model.SyncCheckpoint{}
+	for _, app := range apps {
+		if appContract != nil && common.HexToAddress(app.ApplicationAddress) != *appContract {
+			continue
+		}
+		checkpoint, err := a.worker.checkpoint(ctx, app)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, *checkpoint)
+	}
+	if appContract != nil && len(checkpoints) == 0 {
+		return nil, fmt.Errorf("%w: %s", repository.ErrApplicationNotFound, appContract.Hex())
+	}
+	return checkpoints, nil
+}
+
+func (s SynchronizerCreateWorker) checkpoint(
+	ctx context.Context, app model.ConvenienceApplication,
+) (*model.SyncCheckpoint, error) {
+	checkpoint := model.SyncCheckpoint{AppID: app.ID, AppContract: app.ApplicationAddress}
+	lastInput, err := s.inputRefRepository.GetLatestInputRefByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.LastInputIndex = inputIndex(lastInput)
+	pendingInput, err := s.inputRefRepository.FindFirstInputByStatusNoneByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.PendingStatusInputIndex = inputIndex(pendingInput)
+	lastReport, err := s.SynchronizerReport.ReportRepository.FindLastReportByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if lastReport != nil {
+		index := uint64(lastReport.Index)
+		checkpoint.LastReportIndex = &index
+	}
+	lastOutput, err := s.outputRefRepository.FindLatestRawOutputRefByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.LastOutputIndex = outputIndex(lastOutput)
+	pendingProof, err := s.outputRefRepository.GetFirstOutputRefWithoutProofByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.PendingProofOutputIndex = outputIndex(pendingProof)
+	lastExecuted, err := s.outputRefRepository.GetLastUpdatedAtExecutedByAppID(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if lastExecuted != nil {
+		checkpoint.LastExecutedAt = &lastExecuted.UpdatedAt
+	}
+	return &checkpoint, nil
+}
+
+func inputIndex(ref *repository.RawInputRef) *uint64 {
+	if ref == nil {
+		return nil
+	}
+	return &ref.InputIndex
+}
+
+func outputIndex(ref *repository.RawOutputRef) *uint64 {
+	if ref == nil {
+		return nil
+	}
+	return &ref.OutputIndex
+}
@@ -0,0 +1,165 @@
+package synchronizernode
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+)
+
+const controlApp = "0x5112cf49f2511ac7b13a032c4c62a48410fc28fb"
+
+type SyncControlSuite struct {
+	suite.Suite
+	ctx       context.Context
+	cancel    context.CancelFunc
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	worker    SynchronizerCreateWorker
+}
+
+func TestSyncControlSuite(t *testing.T) {
+	suite.Run(t, new(SyncControlSuite))
+}
+
+func (s *SyncControlSuite) SetupTest() {
+	var err error
+	commons.ConfigureLog(slog.LevelDebug)
+	s.ctx, s.cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "control.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &repository.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	s.worker = SynchronizerCreateWorker{
+		inputRepository:       inputRepository,
+		inputRefRepository:    &repository.RawInputRefRepository{Db: s.db},
+		outputRefRepository:   &repository.RawOutputRefRepository{Db: s.db},
+		SynchronizerReport:    &SynchronizerReport{ReportRepository: &repository.ReportRepository{Db: s.db}},
+		SynchronizerAppCreate: &SynchronizerAppCreator{AppRepository: &repository.ApplicationRepository{Db: s.db}},
+		Control:               NewSyncControl(),
+	}
+}
+
+func (s *SyncControlSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+	s.cancel()
+}
+
+func (s *SyncControlSuite) TestNilControl() {
+	var control *SyncControl
+	s.NoError(control.waitWhilePaused(s.ctx))
+	s.False(control.takeTrigger())
+	control.recordCycle(time.Now(), nil)
+	s.Equal(model.SyncStatus{}, control.Status())
+}
+
+func (s *SyncControlSuite) TestPauseUntilResumed() {
+	control := s.worker.Control
+	control.Pause()
+	s.True(control.Status().Paused)
+	done := make(chan error)
+	go func() {
+		done <- control.waitWhilePaused(s.ctx)
+	}()
+	select {
+	case <-done:
+		s.Fail("the paused synchronizer ran a cycle")
+	case <-time.After(50 * time.Millisecond):
+	}
+	control.Resume()
+	s.NoError(<-done)
+	s.False(control.Status().Paused)
+}
+
+func (s *SyncControlSuite) TestTriggerWhilePausedRunsOneCycle() {
+	control := s.worker.Control
+	control.Pause()
+	control.Trigger()
+	s.True(control.Status().TriggerPending)
+	s.NoError(control.waitWhilePaused(s.ctx))
+	s.False(control.Status().TriggerPending)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 50*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(control.waitWhilePaused(ctx), context.DeadlineExceeded)
+}
+
+func (s *SyncControlSuite) TestTriggerEndsTheWaitForChanges() {
+	go s.worker.Control.Trigger()
+	start := time.Now()
+	steps, err := s.worker.waitForChanges(s.ctx, nil)
+	s.NoError(err)
+	s.Equal(allSyncSteps(), steps)
+	s.Less(time.Since(start), DEFAULT_DELAY)
+}
+
+func (s *SyncControlSuite) TestRecordCycle() {
+	control := s.worker.Control
+	control.recordCycle(time.Now(), nil)
+	control.recordCycle(time.Now(), context.Canceled)
+	status := control.Status()
+	s.Equal(uint64(2), status.Cycles)
+	s.NotNil(status.LastCycleAt)
+	s.Equal(context.Canceled.Error(), status.LastError)
+}
+
+func (s *SyncControlSuite) TestCheckpoints() {
+	_, err := s.worker.SynchronizerAppCreate.AppRepository.Create(s.ctx, &model.ConvenienceApplication{
+		ID: 1, Name: "app", ApplicationAddress: common.HexToAddress(controlApp).Hex(),
+	})
+	s.Require().NoError(err)
+	syncAdmin := NewSyncAdmin(s.worker)
+
+	checkpoints, err := syncAdmin.Checkpoints(s.ctx, nil)
+	s.Require().NoError(err)
+	s.Require().Len(checkpoints, 1)
+	s.Nil(checkpoints[0].LastInputIndex)
+	s.Nil(checkpoints[0].LastOutputIndex)
+
+	for i := range 3 {
+		s.Require().NoError(s.worker.inputRefRepository.Create(s.ctx, repository.RawInputRef{
+			ID:          common.Bytes2Hex([]byte{byte(i)}),
+			AppID:       1,
+			InputIndex:  uint64(i),
+			AppContract: common.HexToAddress(controlApp).Hex(),
+			Status:      "NONE",
+			ChainID:     "31337",
+			CreatedAt:   time.Now(),
+		}))
+	}
+	s.Require().NoError(s.worker.outputRefRepository.Create(s.ctx, repository.RawOutputRef{
+		AppID:       1,
+		OutputIndex: 4,
+		InputIndex:  2,
+		AppContract: common.HexToAddress(controlApp).Hex(),
+		Type:        repository.RAW_NOTICE_TYPE,
+		UpdatedAt:   time.Now(),
+		CreatedAt:   time.Now(),
+	}))
+
+	address := common.HexToAddress(controlApp)
+	checkpoints, err = syncAdmin.Checkpoints(s.ctx, &address)
+	s.Require().NoError(err)
+	s.Require().Len(checkpoints, 1)
+	s.Equal(uint64(2), *checkpoints[0].LastInputIndex)
+	s.Equal(uint64(0), *checkpoints[0].PendingStatusInputIndex)
+	s.Equal(uint64(4), *checkpoints[0].LastOutputIndex)
+	s.Equal(uint64(4), *checkpoints[0].PendingProofOutputIndex)
+	s.Nil(checkpoints[0].LastReportIndex)
+	s.Nil(checkpoints[0].LastExecutedAt)
+
+	other := common.HexToAddress("0x01")
+	_, err = syncAdmin.Checkpoints(s.ctx, &other)
+	s.ErrorIs(err, repository.ErrApplicationNotFound)
+}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/decoder"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
-	"github.com/cartesi/rollups-graphql/v2/pkg/supervisor"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
 	"github.com/ethereum/go-ethereum/common"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
@@ -29,10 +29,28 @@ type SynchronizerCreateWorker struct {
 	SynchronizerOutputCreate   *SynchronizerOutputCreate
 	SynchronizerCreateInput    *SynchronizerInputCreator
 	SynchronizerOutputExecuted *SynchronizerOutputExecuted
+	Notifier                   *NodeNotifier
+	// Polling interval kept as a fallback of the notifier, DEFAULT_NOTIFY_FALLBACK when zero
+	NotifyFallback time.Duration
+	// Number of applications synced in parallel. Zero syncs all of them in a single pass.
+	AppWorkers int
+	// Optional listener of the applications changed by each cycle
+	ChangeListener ChangeListener
+	// Optional control of the cycles by the admin API
+	Control *SyncControl
+	// Optional atomic cycles, committing all the steps of an application together
+	Atomic *AtomicCycles
 }
 
 const DEFAULT_DELAY = 3 * time.Second
 
+// Polling interval of the notify mode, which only covers the lost notifications
+const DEFAULT_NOTIFY_FALLBACK = 1 * time.Minute
+
+// Bounds of the backoff while waiting for the node database
+const NODE_DB_MIN_BACKOFF = time.Second
+const NODE_DB_MAX_BACKOFF = 30 * time.Second
+
 // Start implements supervisor.Worker.
 func (s SynchronizerCreateWorker) Start(ctx context.Context, ready chan<- struct{}) error {
 	ready <- struct{}{}
@@ -65,73 +83,261 @@ func FormatTransactionId(txId []byte) string {
 	}
 }
 
+// Synchronizers affected by a sync cycle.
+type syncSteps struct {
+	inputs     bool
+	inputState bool
+	reports    bool
+	outputs    bool
+	proofs     bool
+	executions bool
+	apps       bool
+	// Applications affected, nil when all of them are.
+	// Only the cycles per application sync a part of them.
+	appIDs map[uint64]bool
+}
+
+func allSyncSteps() syncSteps {
+	return syncSteps{
+		inputs:     true,
+		inputState: true,
+		reports:    true,
+		outputs:    true,
+		proofs:     true,
+		executions: true,
+		apps:       true,
+	}
+}
+
+func (s syncSteps) merge(other syncSteps) syncSteps {
+	return syncSteps{
+		inputs:     s.inputs || other.inputs,
+		inputState: s.inputState || other.inputState,
+		reports:    s.reports || other.reports,
+		outputs:    s.outputs || other.outputs,
+		proofs:     s.proofs || other.proofs,
+		executions: s.executions || other.executions,
+		apps:       s.apps || other.apps,
+		appIDs:     mergeAppIDs(s.appIDs, other.appIDs),
+	}
+}
+
+func mergeAppIDs(a, b map[uint64]bool) map[uint64]bool {
+	if a == nil || b == nil {
+		return nil
+	}
+	merged := make(map[uint64]bool, len(a)+len(b))
+	for appID := range a {
+		merged[appID] = true
+	}
+	for appID := range b {
+		merged[appID] = true
+	}
+	return merged
+}
+
+// affects tells whether the cycle syncs the application.
+func (s syncSteps) affects(appID uint64) bool {
+	return s.appIDs == nil || s.appIDs[appID]
+}
+
+// syncStepsFor returns the synchronizers affected by a change on a node table,
+// for the application of the change.
+// An unknown table, like the empty one sent after a reconnection, affects all of them.
+func syncStepsFor(change NodeChange) syncSteps {
+	var steps syncSteps
+	switch change.Table {
+	case NODE_TABLE_APPLICATION:
+		steps = syncSteps{apps: true}
+	case NODE_TABLE_INPUT:
+		steps = syncSteps{inputs: true, inputState: true, apps: true}
+	case NODE_TABLE_OUTPUT:
+		steps = syncSteps{outputs: true, proofs: true, executions: true}
+	case NODE_TABLE_REPORT:
+		steps = syncSteps{reports: true}
+	default:
+		return allSyncSteps()
+	}
+	if change.AppID != 0 {
+		steps.appIDs = map[uint64]bool{change.AppID: true}
+	}
+	return steps
+}
+
 func (s SynchronizerCreateWorker) WatchNewInputs(stdCtx context.Context) error {
 	ctx, cancel := context.WithCancel(stdCtx)
 	defer cancel()
 
+	err := s.waitForNodeDb(ctx)
+	if err != nil {
+		return err
+	}
+
+	var changes <-chan NodeChange
+	if s.Notifier != nil {
+		err = s.Notifier.Prepare(ctx)
+		if err != nil {
+			return err
+		}
+		changes, err = s.Notifier.Listen(ctx)
+		if err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "Listening for node changes", "channel", NODE_NOTIFY_CHANNEL)
+	}
+
+	steps := allSyncSteps()
 	for {
-		errCh := make(chan error)
-
-		go func() {
-			for {
-				select {
-				case <-ctx.Done():
-					errCh <- ctx.Err()
-					return
-				default:
-					err := s.SynchronizerCreateInput.SyncInputs(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-					err = s.SynchronizerUpdate.SyncInputStatus(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-					err = s.SynchronizerReport.SyncReports(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-
-					err = s.SynchronizerOutputCreate.SyncOutputs(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-
-					err = s.SynchronizerOutputUpdate.SyncOutputsProofs(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-
-					err = s.SynchronizerOutputExecuted.SyncOutputsExecution(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-
-					err = s.SynchronizerAppCreate.SyncApps(ctx)
-					if err != nil {
-						errCh <- err
-						return
-					}
-
-					<-time.After(DEFAULT_DELAY)
+		err = s.Control.waitWhilePaused(ctx)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		err = traceStep(ctx, "cycle", func(ctx context.Context) error {
+			return s.syncCycle(ctx, steps)
+		})
+		s.Control.recordCycle(start, err)
+		if err != nil {
+			return err
+		}
+		steps, err = s.waitForChanges(ctx, changes)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// waitForNodeDb pings the node database with backoff until it is reachable.
+// The API keeps serving the synced data in the meantime.
+func (s SynchronizerCreateWorker) waitForNodeDb(ctx context.Context) error {
+	backoff := NODE_DB_MIN_BACKOFF
+	for {
+		err := s.RawRepository.Db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slog.WarnContext(ctx, "Node database unavailable, retrying", "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, NODE_DB_MAX_BACKOFF)
+	}
+}
+
+// waitForChanges blocks until a node change is notified or the fallback delay expires.
+// Without a notifier every cycle runs all synchronizers, like a plain timer.
+// With one the fallback only covers the lost notifications, so it is much longer.
+// A pause or a triggered cycle from the admin API ends the wait with all synchronizers.
+func (s SynchronizerCreateWorker) waitForChanges(ctx context.Context, changes <-chan NodeChange) (syncSteps, error) {
+	if s.Control.takeTrigger() {
+		return allSyncSteps(), nil
+	}
+	select {
+	case <-ctx.Done():
+		return syncSteps{}, ctx.Err()
+	case <-time.After(s.fallbackDelay()):
+		return allSyncSteps(), nil
+	case <-s.Control.woken():
+		return allSyncSteps(), nil
+	case change, ok := <-changes:
+		if !ok {
+			return syncSteps{}, ctx.Err()
+		}
+		steps := syncStepsFor(change)
+		// drain the pending notifications to run a single cycle for all of them
+		for {
+			select {
+			case change, ok := <-changes:
+				if !ok {
+					return syncSteps{}, ctx.Err()
 				}
+				steps = steps.merge(syncStepsFor(change))
+			default:
+				slog.DebugContext(ctx, "Node changes notified", "steps", steps)
+				return steps, nil
 			}
-		}()
+		}
+	}
+}
 
-		wrong := <-errCh
+func (s SynchronizerCreateWorker) fallbackDelay() time.Duration {
+	if s.Notifier == nil {
+		return DEFAULT_DELAY
+	}
+	if s.NotifyFallback > 0 {
+		return s.NotifyFallback
+	}
+	return DEFAULT_NOTIFY_FALLBACK
+}
 
-		if wrong != nil {
-			return wrong
+func (s SynchronizerCreateWorker) syncCycle(ctx context.Context, steps syncSteps) error {
+	if s.AppWorkers > 0 || s.Atomic != nil {
+		return s.syncCyclePerApp(ctx, steps)
+	}
+	// a resync of any application waits for the cycle to finish
+	lock, err := repository.LockAllAppsSync(ctx, s.inputRepository.Db)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock(ctx)
+	ctx, notifyChanges := s.trackChanges(ctx)
+	defer notifyChanges()
+	if steps.inputs {
+		err := traceStep(ctx, "inputs", s.SynchronizerCreateInput.SyncInputs)
+		if err != nil {
+			return err
+		}
+	}
+	if steps.inputState {
+		err := traceStep(ctx, "inputState", s.SynchronizerUpdate.SyncInputStatus)
+		if err != nil {
+			return err
+		}
+	}
+	if steps.reports {
+		err := traceStep(ctx, "reports", s.SynchronizerReport.SyncReports)
+		if err != nil {
+			return err
+		}
+	}
+	if steps.outputs {
+		err := traceStep(ctx, "outputs", s.SynchronizerOutputCreate.SyncOutputs)
+		if err != nil {
+			return err
 		}
-
-		slog.DebugContext(ctx, "Retrying to fetch new inputs")
 	}
+	if steps.proofs {
+		err := traceStep(ctx, "proofs", s.SynchronizerOutputUpdate.SyncOutputsProofs)
+		if err != nil {
+			return err
+		}
+	}
+	if steps.executions {
+		err := traceStep(ctx, "executions", s.SynchronizerOutputExecuted.SyncOutputsExecution)
+		if err != nil {
+			return err
+		}
+	}
+	if steps.apps {
+		err := traceStep(ctx, "apps", s.SynchronizerAppCreate.SyncApps)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// traceStep runs a step of the sync cycle in its own span.
+func traceStep(ctx context.Context, name string, step func(context.Context) error) error {
+	ctx, span := tracing.Start(ctx, "sync."+name)
+	err := step(ctx)
+	tracing.End(span, err)
+	return err
 }
 
 // String implements supervisor.Worker.
@@ -153,7 +359,7 @@ func NewSynchronizerCreateWorker(
 	synchronizerOutputCreate *SynchronizerOutputCreate,
 	synchronizerCreateInput *SynchronizerInputCreator,
 	synchronizerOutputExecuted *SynchronizerOutputExecuted,
-) supervisor.Worker {
+) SynchronizerCreateWorker {
 	return SynchronizerCreateWorker{
 		inputRepository:            inputRepository,
 		inputRefRepository:         inputRefRepository,
@@ -9,6 +9,7 @@ import (
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/contracts"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
 	"github.com/ethereum/go-ethereum/common"
@@ -55,6 +56,7 @@ func (s *SynchronizerNodeSuite) SetupTest() {
 	s.Require().NoError(err)
 	s.db, err = s.dbFactory.CreateDbCtx(s.ctx, "input.sqlite3")
 	s.Require().NoError(err)
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	container := convenience.NewContainer(s.db, false)
 	s.inputRepository = container.GetInputRepository(s.ctx)
 	s.inputRefRepository = &repository.RawInputRefRepository{Db: s.db}
@@ -0,0 +1,207 @@
+package synchronizernode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ConversionError reports a raw row from the node that cannot be converted.
+// Syncing the same row again gives the same result,
+// so the row is quarantined as a dead letter instead of stopping the sync.
+type ConversionError struct {
+	Err error
+}
+
+func (e *ConversionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+func isConversionError(err error) bool {
+	var conversionErr *ConversionError
+	return errors.As(err, &conversionErr)
+}
+
+// quarantine stores the row as a dead letter when the error is a conversion error.
+// Any other error is returned unchanged, as is every error when no repository is set.
+func quarantine(ctx context.Context, deadLetterRepository *repository.DeadLetterRepository, deadLetter repository.DeadLetter, err error) error {
+	if deadLetterRepository == nil || !isConversionError(err) {
+		return err
+	}
+	deadLetter.Error = err.Error()
+	created, createErr := deadLetterRepository.Create(ctx, deadLetter)
+	if createErr != nil {
+		return createErr
+	}
+	if created {
+		slog.WarnContext(ctx, "Quarantined raw row that failed to convert",
+			"kind", deadLetter.Kind,
+			"app_id", deadLetter.AppID,
+			"app_contract", deadLetter.AppContract,
+			"index", deadLetter.RawIndex,
+			"error", err,
+		)
+	}
+	return nil
+}
+
+// quarantinedAfter tells whether the dead letter comes after the row of the application
+// at the index in the order of the single pass sync.
+func quarantinedAfter(deadLetter *repository.DeadLetter, index uint64, appID uint64) bool {
+	if deadLetter.RawIndex != index {
+		return deadLetter.RawIndex > index
+	}
+	return deadLetter.AppID > appID
+}
+
+// lastQuarantinedIndex moves the index of the last synced row of the application
+// past its quarantined rows, so the fetch after it does not return them again.
+func lastQuarantinedIndex(
+	ctx context.Context,
+	deadLetterRepository *repository.DeadLetterRepository,
+	kind string,
+	appID uint64,
+	lastIndex *uint64,
+) (*uint64, error) {
+	if deadLetterRepository == nil {
+		return lastIndex, nil
+	}
+	deadLetter, err := deadLetterRepository.FindLatestByAppID(ctx, kind, appID)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil || lastIndex != nil && *lastIndex >= deadLetter.RawIndex {
+		return lastIndex, nil
+	}
+	return &deadLetter.RawIndex, nil
+}
+
+func inputDeadLetter(rawInput RawInput) repository.DeadLetter {
+	return repository.DeadLetter{
+		Kind:        repository.DEAD_LETTER_INPUT,
+		AppID:       uint64(rawInput.ApplicationId),
+		AppContract: common.BytesToAddress(rawInput.ApplicationAddress).Hex(),
+		RawIndex:    rawInput.Index,
+		InputIndex:  rawInput.Index,
+	}
+}
+
+func outputDeadLetter(rawOutput Output) repository.DeadLetter {
+	return repository.DeadLetter{
+		Kind:        repository.DEAD_LETTER_OUTPUT,
+		AppID:       rawOutput.ApplicationId,
+		AppContract: common.BytesToAddress(rawOutput.AppContract).Hex(),
+		RawIndex:    rawOutput.Index,
+		InputIndex:  rawOutput.InputIndex,
+	}
+}
+
+// SynchronizerDeadLetter retries the conversion of quarantined rows.
+type SynchronizerDeadLetter struct {
+	DeadLetterRepository *repository.DeadLetterRepository
+	RawRepository        *RawRepository
+	InputCreator         *SynchronizerInputCreator
+	OutputCreator        *SynchronizerOutputCreate
+	// Told the application of each resolved dead letter, if set
+	ChangeListener ChangeListener
+}
+
+func NewSynchronizerDeadLetter(
+	deadLetterRepository *repository.DeadLetterRepository,
+	rawRepository *RawRepository,
+	inputCreator *SynchronizerInputCreator,
+	outputCreator *SynchronizerOutputCreate,
+) *SynchronizerDeadLetter {
+	return &SynchronizerDeadLetter{
+		DeadLetterRepository: deadLetterRepository,
+		RawRepository:        rawRepository,
+		InputCreator:         inputCreator,
+		OutputCreator:        outputCreator,
+	}
+}
+
+func (s *SynchronizerDeadLetter) FindAll(ctx context.Context, appContract string) ([]repository.DeadLetter, error) {
+	return s.DeadLetterRepository.FindAll(ctx, appContract)
+}
+
+// Retry converts the quarantined row again.
+// It returns nil when the row was synced and the updated dead letter when it failed again.
+func (s *SynchronizerDeadLetter) Retry(ctx context.Context, kind string, appID uint64, rawIndex uint64) (*repository.DeadLetter, error) {
+	deadLetter, err := s.DeadLetterRepository.FindByKey(ctx, kind, appID, rawIndex)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil {
+		return nil, repository.ErrDeadLetterNotFound
+	}
+	txCtx, err := repository.StartTransaction(ctx, s.DeadLetterRepository.Db)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := s.retry(txCtx, deadLetter)
+	if err != nil {
+		s.rollbackTransaction(txCtx)
+		return nil, err
+	}
+	tx, _ := repository.GetTransaction(txCtx)
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	if resolved {
+		notifyAppChanged(ctx, s.ChangeListener, common.HexToAddress(deadLetter.AppContract))
+		return nil, nil
+	}
+	return deadLetter, nil
+}
+
+func (s *SynchronizerDeadLetter) retry(ctx context.Context, deadLetter *repository.DeadLetter) (bool, error) {
+	var err error
+	switch deadLetter.Kind {
+	case repository.DEAD_LETTER_INPUT:
+		rawInput, findErr := s.RawRepository.FindAppInputByIndex(ctx, deadLetter.AppID, deadLetter.RawIndex)
+		if findErr != nil {
+			return false, findErr
+		}
+		if rawInput == nil {
+			return false, fmt.Errorf("input %d of app %d not found in the node database", deadLetter.RawIndex, deadLetter.AppID)
+		}
+		err = s.InputCreator.CreateInput(ctx, *rawInput)
+	case repository.DEAD_LETTER_OUTPUT:
+		rawOutput, findErr := s.RawRepository.FindAppOutputByIndex(ctx, deadLetter.AppID, deadLetter.RawIndex)
+		if findErr != nil {
+			return false, findErr
+		}
+		if rawOutput == nil {
+			return false, fmt.Errorf("output %d of app %d not found in the node database", deadLetter.RawIndex, deadLetter.AppID)
+		}
+		err = s.OutputCreator.createOutput(ctx, *rawOutput)
+	default:
+		return false, fmt.Errorf("unexpected dead letter kind %s", deadLetter.Kind)
+	}
+	if err == nil {
+		return true, s.DeadLetterRepository.Delete(ctx, deadLetter)
+	}
+	if !isConversionError(err) {
+		return false, err
+	}
+	return false, s.DeadLetterRepository.RegisterRetry(ctx, deadLetter, err.Error())
+}
+
+func (s *SynchronizerDeadLetter) rollbackTransaction(ctx context.Context) {
+	tx, hasTx := repository.GetTransaction(ctx)
+	if hasTx && tx != nil {
+		err := tx.Rollback()
+		if err != nil {
+			slog.ErrorContext(ctx, "transaction rollback error", "err", err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package synchronizernode
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/contracts"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+)
+
+// EvmAdvance input of the application 0x5112cf49f2511ac7b13a032c4c62a48410fc28fb
+const validAdvanceInput = "0x415bf3630000000000000000000000000000000000000000000000000000000000007a690000000000000000000000005112cf49f2511ac7b13a032c4c62a48410fc28fb000000000000000000000000f39fd6e51aad88f6f4ce6ab8827279cfffb92266000000000000000000000000000000000000000000000000000000000000046900000000000000000000000000000000000000000000000000000000670931c70a06511d13afecb37c88e47c1a7357e42205ac4b8e49fcd4632373e036261e26000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000005deadbeef11000000000000000000000000000000000000000000000000000000" // nolint
+
+type DeadLetterSuite struct {
+	suite.Suite
+	ctx        context.Context
+	tempDir    string
+	db         *sqlx.DB
+	repository *repository.DeadLetterRepository
+}
+
+func (s *DeadLetterSuite) SetupTest() {
+	s.ctx = context.Background()
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "dead_letters.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	s.repository = &repository.DeadLetterRepository{Db: s.db}
+	s.Require().NoError(s.repository.CreateTables(s.ctx))
+}
+
+func (s *DeadLetterSuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestDeadLetterSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterSuite))
+}
+
+func (s *DeadLetterSuite) TestQuarantineMalformedOutput() {
+	rawOutput := Output{Index: 4, InputIndex: 2, ApplicationId: 1, RawData: []byte{0x01}}
+	_, err := getOutputType(rawOutput.RawData)
+	s.Require().Error(err)
+
+	err = quarantine(s.ctx, s.repository, outputDeadLetter(rawOutput), &ConversionError{Err: err})
+	s.Require().NoError(err)
+	deadLetter, err := s.repository.FindByKey(s.ctx, repository.DEAD_LETTER_OUTPUT, 1, 4)
+	s.Require().NoError(err)
+	s.Require().NotNil(deadLetter)
+	s.Equal(uint64(2), deadLetter.InputIndex)
+	s.Contains(deadLetter.Error, "too short")
+}
+
+func (s *DeadLetterSuite) TestQuarantineKeepsOtherErrors() {
+	dbErr := errors.New("connection reset")
+	err := quarantine(s.ctx, s.repository, outputDeadLetter(Output{Index: 1}), dbErr)
+	s.ErrorIs(err, dbErr)
+
+	conversionErr := &ConversionError{Err: errors.New("value not found")}
+	err = quarantine(s.ctx, nil, outputDeadLetter(Output{Index: 1}), conversionErr)
+	s.ErrorIs(err, conversionErr)
+
+	all, err := s.repository.FindAll(s.ctx, "")
+	s.Require().NoError(err)
+	s.Empty(all)
+}
+
+func (s *DeadLetterSuite) TestSyncMovesPastConsecutiveQuarantinedInputs() {
+	abi, err := contracts.InputsMetaData.GetAbi()
+	s.Require().NoError(err)
+	inputRepository := &repository.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	inputRefRepository := &repository.RawInputRefRepository{Db: s.db}
+	creator := NewSynchronizerInputCreator(inputRepository, inputRefRepository, nil, NewAbiDecoder(abi))
+	creator.DeadLetterRepository = s.repository
+
+	// more consecutive malformed inputs than a batch holds, then a valid one
+	malformed := int(LIMIT) + 10
+	nodeInputs := []RawInput{}
+	for i := 0; i < malformed; i++ {
+		nodeInputs = append(nodeInputs, RawInput{Index: uint64(i), ApplicationId: 1, RawData: []byte{0x01}})
+	}
+	nodeInputs = append(nodeInputs, RawInput{
+		Index:          uint64(malformed),
+		ApplicationId:  1,
+		RawData:        common.Hex2Bytes(strings.TrimPrefix(validAdvanceInput, "0x")),
+		Status:         "NONE",
+		TransactionRef: []byte{0x01},
+	})
+	// fetches the inputs after the checkpoint as FindAppInputsGtIndex does
+	fetch := func(lastIndex *uint64) []RawInput {
+		inputs := []RawInput{}
+		for _, input := range nodeInputs {
+			if (lastIndex == nil || input.Index > *lastIndex) && len(inputs) < int(LIMIT) {
+				inputs = append(inputs, input)
+			}
+		}
+		return inputs
+	}
+
+	for cycle := 0; cycle < 3; cycle++ {
+		lastIndex, err := creator.appCheckpoint(s.ctx, 1)
+		s.Require().NoError(err)
+		for _, input := range fetch(lastIndex) {
+			s.Require().NoError(creator.createInput(s.ctx, input))
+		}
+	}
+
+	latestInputRef, err := inputRefRepository.GetLatestInputRefByAppID(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Require().NotNil(latestInputRef)
+	s.Equal(uint64(malformed), latestInputRef.InputIndex)
+	deadLetters, err := s.repository.FindAll(s.ctx, "")
+	s.Require().NoError(err)
+	s.Len(deadLetters, malformed)
+	lastIndex, err := creator.appCheckpoint(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Empty(fetch(lastIndex))
+}
+
+func (s *DeadLetterSuite) TestCheckpointMovesPastQuarantinedOutputs() {
+	outputRefRepository := &repository.RawOutputRefRepository{Db: s.db}
+	creator := &SynchronizerOutputCreate{
+		RawOutputRefRepository: outputRefRepository,
+		DeadLetterRepository:   s.repository,
+	}
+	checkpoint, err := creator.checkpoint(s.ctx)
+	s.Require().NoError(err)
+	s.Nil(checkpoint)
+
+	for _, index := range []uint64{3, 4} {
+		err = quarantine(s.ctx, s.repository, outputDeadLetter(Output{Index: index, InputIndex: 1, ApplicationId: 1}),
+			&ConversionError{Err: errors.New("output payload too short")})
+		s.Require().NoError(err)
+	}
+	checkpoint, err = creator.checkpoint(s.ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(checkpoint)
+	s.Equal(uint64(4), checkpoint.OutputIndex)
+	s.Equal(uint64(1), checkpoint.AppID)
+
+	lastIndex, err := creator.appCheckpoint(s.ctx, 1)
+	s.Require().NoError(err)
+	s.Require().NotNil(lastIndex)
+	s.Equal(uint64(4), *lastIndex)
+	lastIndex, err = creator.appCheckpoint(s.ctx, 2)
+	s.Require().NoError(err)
+	s.Nil(lastIndex)
+}
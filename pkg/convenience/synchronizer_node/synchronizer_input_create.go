@@ -18,6 +18,8 @@ type SynchronizerInputCreator struct {
 	RawInputRefRepository *repository.RawInputRefRepository
 	RawNodeV2Repository   *RawRepository
 	AbiDecoder            *AbiDecoder
+	// Quarantine inputs that fail to convert instead of stopping the sync
+	DeadLetterRepository *repository.DeadLetterRepository
 }
 
 func NewSynchronizerInputCreator(
@@ -51,6 +53,24 @@ func (s SynchronizerInputCreator) SyncInputs(ctx context.Context) error {
 	return nil
 }
 
+// SyncAppInputs syncs the inputs of a single application using its own checkpoint.
+func (s *SynchronizerInputCreator) SyncAppInputs(ctx context.Context, appID uint64) (int, error) {
+	txCtx, err := s.startTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total, err := s.syncAppInputs(txCtx, appID, LIMIT)
+	if err != nil {
+		s.rollbackTransaction(txCtx)
+		return 0, err
+	}
+	err = s.commitTransaction(txCtx)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
 func (s *SynchronizerInputCreator) startTransaction(ctx context.Context) (context.Context, error) {
 	db := s.InputRepository.Db
 	ctxWithTx, err := repository.StartTransaction(ctx, db)
@@ -80,7 +100,7 @@ func (s *SynchronizerInputCreator) commitTransaction(ctx context.Context) error
 }
 
 func (s *SynchronizerInputCreator) syncInputs(ctx context.Context) error {
-	latestInputRef, err := s.RawInputRefRepository.GetLatestInputRef(ctx)
+	latestInputRef, err := s.checkpoint(ctx)
 	if err != nil {
 		return err
 	}
@@ -89,25 +109,87 @@ func (s *SynchronizerInputCreator) syncInputs(ctx context.Context) error {
 		return err
 	}
 	for _, input := range inputs {
-		err = s.CreateInput(ctx, input)
+		err = s.createInput(ctx, input)
 		if err != nil {
 			return err
 		}
-
 	}
 	return nil
 }
 
+func (s *SynchronizerInputCreator) syncAppInputs(ctx context.Context, appID uint64, limit uint64) (int, error) {
+	lastInputIndex, err := s.appCheckpoint(ctx, appID)
+	if err != nil {
+		return 0, err
+	}
+	inputs, err := s.RawNodeV2Repository.FindAppInputsGtIndex(ctx, appID, lastInputIndex, limit)
+	if err != nil {
+		return 0, err
+	}
+	for _, input := range inputs {
+		err = s.createInput(ctx, input)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(inputs), nil
+}
+
+// checkpoint returns the last synced input, moved past the inputs quarantined after it,
+// so a run of them does not fill every batch and stop the sync.
+func (s *SynchronizerInputCreator) checkpoint(ctx context.Context) (*repository.RawInputRef, error) {
+	latestInputRef, err := s.RawInputRefRepository.GetLatestInputRef(ctx)
+	if err != nil || s.DeadLetterRepository == nil {
+		return latestInputRef, err
+	}
+	deadLetter, err := s.DeadLetterRepository.FindLatest(ctx, repository.DEAD_LETTER_INPUT)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil || latestInputRef != nil &&
+		!quarantinedAfter(deadLetter, latestInputRef.InputIndex, latestInputRef.AppID) {
+		return latestInputRef, nil
+	}
+	checkpoint := &repository.RawInputRef{
+		AppID:       deadLetter.AppID,
+		InputIndex:  deadLetter.RawIndex,
+		AppContract: deadLetter.AppContract,
+	}
+	if latestInputRef != nil {
+		checkpoint.CreatedAt = latestInputRef.CreatedAt
+	}
+	return checkpoint, nil
+}
+
+// appCheckpoint returns the index of the last synced or quarantined input of the application.
+func (s *SynchronizerInputCreator) appCheckpoint(ctx context.Context, appID uint64) (*uint64, error) {
+	latestInputRef, err := s.RawInputRefRepository.GetLatestInputRefByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	var lastInputIndex *uint64
+	if latestInputRef != nil {
+		lastInputIndex = &latestInputRef.InputIndex
+	}
+	return lastQuarantinedIndex(ctx, s.DeadLetterRepository, repository.DEAD_LETTER_INPUT, appID, lastInputIndex)
+}
+
+func (s *SynchronizerInputCreator) createInput(ctx context.Context, rawInput RawInput) error {
+	err := s.CreateInput(ctx, rawInput)
+	return quarantine(ctx, s.DeadLetterRepository, inputDeadLetter(rawInput), err)
+}
+
 func (s *SynchronizerInputCreator) CreateInput(ctx context.Context, rawInput RawInput) error {
 	advanceInput, err := s.GetAdvanceInputFromMap(rawInput)
 	if err != nil {
-		return err
+		return &ConversionError{Err: err}
 	}
 
 	inputBox, err := s.InputRepository.Create(ctx, *advanceInput)
 	if err != nil {
 		return err
 	}
+	markAppChanged(ctx, advanceInput.AppContract)
 
 	rawInputRef := repository.RawInputRef{
 		ID:          inputBox.ID,
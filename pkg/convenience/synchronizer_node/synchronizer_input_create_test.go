@@ -10,6 +10,7 @@ import (
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/contracts"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
@@ -47,6 +48,7 @@ func (s *SynchronizerInputCreate) SetupTest() {
 	sqliteFileName := filepath.Join(tempDir, "output.sqlite3")
 
 	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, db))
 	s.container = convenience.NewContainer(db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
@@ -17,6 +17,8 @@ type SynchronizerOutputCreate struct {
 	RawNodeV2Repository    *RawRepository
 	RawOutputRefRepository *repository.RawOutputRefRepository
 	AbiDecoder             *AbiDecoder
+	// Quarantine outputs that fail to convert instead of stopping the sync
+	DeadLetterRepository *repository.DeadLetterRepository
 }
 
 func NewSynchronizerOutputCreate(
@@ -53,7 +55,7 @@ func (s *SynchronizerOutputCreate) SyncOutputs(ctx context.Context) error {
 }
 
 func (s *SynchronizerOutputCreate) syncOutputs(ctx context.Context) error {
-	latestOutputRef, err := s.RawOutputRefRepository.FindLatestRawOutputRef(ctx)
+	latestOutputRef, err := s.checkpoint(ctx)
 	if err != nil {
 		return err
 	}
@@ -68,24 +70,116 @@ func (s *SynchronizerOutputCreate) syncOutputs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	return s.createOutputs(ctx, outputs)
+}
+
+// SyncAppOutputs syncs the outputs of a single application using its own checkpoint.
+func (s *SynchronizerOutputCreate) SyncAppOutputs(ctx context.Context, appID uint64) (int, error) {
+	txCtx, err := s.startTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total, err := s.syncAppOutputs(txCtx, appID, LIMIT)
+	if err != nil {
+		s.rollbackTransaction(txCtx)
+		return 0, err
+	}
+	err = s.commitTransaction(txCtx)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (s *SynchronizerOutputCreate) syncAppOutputs(ctx context.Context, appID uint64, limit uint64) (int, error) {
+	lastOutputIndex, err := s.appCheckpoint(ctx, appID)
+	if err != nil {
+		return 0, err
+	}
+	outputs, err := s.RawNodeV2Repository.FindAppOutputsGtIndex(ctx, appID, lastOutputIndex, limit)
+	if err != nil {
+		return 0, err
+	}
+	outputs = boundToSyncedInputs(ctx, outputs, outputInputIndex)
+	err = s.createOutputs(ctx, outputs)
+	if err != nil {
+		return 0, err
+	}
+	return len(outputs), nil
+}
+
+// checkpoint returns the last synced output, moved past the outputs quarantined after it,
+// so a run of them does not fill every batch and stop the sync.
+func (s *SynchronizerOutputCreate) checkpoint(ctx context.Context) (*repository.RawOutputRef, error) {
+	latestOutputRef, err := s.RawOutputRefRepository.FindLatestRawOutputRef(ctx)
+	if err != nil || s.DeadLetterRepository == nil {
+		return latestOutputRef, err
+	}
+	deadLetter, err := s.DeadLetterRepository.FindLatest(ctx, repository.DEAD_LETTER_OUTPUT)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil || latestOutputRef != nil &&
+		!quarantinedAfter(deadLetter, latestOutputRef.OutputIndex, latestOutputRef.AppID) {
+		return latestOutputRef, nil
+	}
+	checkpoint := &repository.RawOutputRef{
+		AppID:       deadLetter.AppID,
+		InputIndex:  deadLetter.InputIndex,
+		OutputIndex: deadLetter.RawIndex,
+		AppContract: deadLetter.AppContract,
+	}
+	if latestOutputRef != nil {
+		checkpoint.CreatedAt = latestOutputRef.CreatedAt
+	}
+	return checkpoint, nil
+}
+
+// appCheckpoint returns the index of the last synced or quarantined output of the application.
+func (s *SynchronizerOutputCreate) appCheckpoint(ctx context.Context, appID uint64) (*uint64, error) {
+	latestOutputRef, err := s.RawOutputRefRepository.FindLatestRawOutputRefByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	var lastOutputIndex *uint64
+	if latestOutputRef != nil {
+		lastOutputIndex = &latestOutputRef.OutputIndex
+	}
+	return lastQuarantinedIndex(ctx, s.DeadLetterRepository, repository.DEAD_LETTER_OUTPUT, appID, lastOutputIndex)
+}
+
+func (s *SynchronizerOutputCreate) createOutputs(ctx context.Context, outputs []Output) error {
 	for _, rawOutput := range outputs {
-		rawOutputRef, err := s.ToRawOutputRef(rawOutput)
-		if err != nil {
-			return err
-		}
-		err = s.RawOutputRefRepository.Create(ctx, *rawOutputRef)
+		err := s.createOutput(ctx, rawOutput)
+		err = quarantine(ctx, s.DeadLetterRepository, outputDeadLetter(rawOutput), err)
 		if err != nil {
 			return err
 		}
-		err = s.CreateOutput(ctx, rawOutputRef, rawOutput)
+	}
+	return nil
+}
+
+func (s *SynchronizerOutputCreate) createOutput(ctx context.Context, rawOutput Output) error {
+	rawOutputRef, err := s.ToRawOutputRef(rawOutput)
+	if err != nil {
+		return &ConversionError{Err: err}
+	}
+	if rawOutputRef.Type == repository.RAW_VOUCHER_TYPE {
+		// decode before writing anything, a failed statement aborts the whole transaction
+		_, err = s.ToConvenienceVoucher(rawOutput)
 		if err != nil {
-			return err
+			return &ConversionError{Err: err}
 		}
 	}
-	return nil
+	err = s.RawOutputRefRepository.Create(ctx, *rawOutputRef)
+	if err != nil {
+		return err
+	}
+	return s.CreateOutput(ctx, rawOutputRef, rawOutput)
 }
 
 func (s *SynchronizerOutputCreate) CreateOutput(ctx context.Context, rawOutputRef *repository.RawOutputRef, rawOutput Output) error {
+	markAppChanged(ctx, common.BytesToAddress(rawOutput.AppContract))
 	if rawOutputRef.Type == repository.RAW_VOUCHER_TYPE {
 		cVoucher, err := s.ToConvenienceVoucher(rawOutput)
 		if err != nil {
@@ -167,6 +261,9 @@ func (s *SynchronizerOutputCreate) ToRawOutputRef(rawOutput Output) (*repository
 }
 
 func getOutputType(rawData []byte) (string, error) {
+	if len(rawData) < 4 {
+		return "", fmt.Errorf("output payload too short: %d bytes", len(rawData))
+	}
 	var strPayload = "0x" + common.Bytes2Hex(rawData)
 	if strPayload[2:10] == model.VOUCHER_SELECTOR {
 		return repository.RAW_VOUCHER_TYPE, nil
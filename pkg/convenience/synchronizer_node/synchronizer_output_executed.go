@@ -73,8 +73,45 @@ func (s *SynchronizerOutputExecuted) syncOutputs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	return s.updateExecutions(ctx, rawOutputs)
+}
+
+// SyncAppOutputsExecution syncs the executed vouchers of a single application using its own checkpoint.
+func (s *SynchronizerOutputExecuted) SyncAppOutputsExecution(ctx context.Context, appID uint64) error {
+	txCtx, err := s.startTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.syncAppOutputsExecution(txCtx, appID, LIMIT)
+	if err != nil {
+		s.rollbackTransaction(txCtx)
+		return err
+	}
+	return s.commitTransaction(txCtx)
+}
+
+func (s *SynchronizerOutputExecuted) syncAppOutputsExecution(ctx context.Context, appID uint64, limit uint64) error {
+	lastOutputRef, err := s.RawOutputRefRepository.GetLastUpdatedAtExecutedByAppID(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if lastOutputRef == nil {
+		lastOutputRef = &repository.RawOutputRef{
+			AppID:     appID,
+			UpdatedAt: time.Unix(0, 0),
+		}
+	}
+	rawOutputs, err := s.RawNodeV2Repository.FindAppOutputsExecutedAfter(ctx, appID, lastOutputRef, limit)
+	if err != nil {
+		return err
+	}
+	rawOutputs = boundToSyncedInputs(ctx, rawOutputs, outputInputIndex)
+	return s.updateExecutions(ctx, rawOutputs)
+}
+
+func (s *SynchronizerOutputExecuted) updateExecutions(ctx context.Context, rawOutputs []Output) error {
 	for _, rawOutput := range rawOutputs {
-		err = s.UpdateExecutionData(ctx, rawOutput)
+		err := s.UpdateExecutionData(ctx, rawOutput)
 		if err != nil {
 			return err
 		}
@@ -96,6 +133,7 @@ func (s *SynchronizerOutputExecuted) UpdateExecutionData(
 	}
 	appContract := common.BytesToAddress(rawOutput.AppContract)
 	if ref.Type == repository.RAW_VOUCHER_TYPE {
+		markAppChanged(ctx, appContract)
 		err = s.VoucherRepository.SetExecuted(ctx,
 			&model.ConvenienceVoucher{
 				AppContract:     appContract,
@@ -65,6 +65,46 @@ func (s *SynchronizerOutputUpdate) syncOutputsProofs(ctx context.Context) error
 	if err != nil {
 		return err
 	}
+	return s.updateProofs(ctx, lastOutputRefWithoutProof, rawOutputs)
+}
+
+// SyncAppOutputsProofs syncs the output proofs of a single application using its own checkpoint.
+func (s *SynchronizerOutputUpdate) SyncAppOutputsProofs(ctx context.Context, appID uint64) error {
+	txCtx, err := s.startTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.syncAppOutputsProofs(txCtx, appID, LIMIT)
+	if err != nil {
+		s.rollbackTransaction(txCtx)
+		return err
+	}
+	return s.commitTransaction(txCtx)
+}
+
+func (s *SynchronizerOutputUpdate) syncAppOutputsProofs(ctx context.Context, appID uint64, limit uint64) error {
+	lastOutputRefWithoutProof, err := s.RawOutputRefRepository.GetFirstOutputRefWithoutProofByAppID(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if lastOutputRefWithoutProof == nil {
+		return nil
+	}
+	rawOutputs, err := s.RawNodeV2Repository.FindAppOutputsWithProofGteIndex(
+		ctx, appID, lastOutputRefWithoutProof.OutputIndex, limit,
+	)
+	if err != nil {
+		return err
+	}
+	rawOutputs = boundToSyncedInputs(ctx, rawOutputs, outputInputIndex)
+	return s.updateProofs(ctx, lastOutputRefWithoutProof, rawOutputs)
+}
+
+func (s *SynchronizerOutputUpdate) updateProofs(
+	ctx context.Context,
+	lastOutputRefWithoutProof *repository.RawOutputRef,
+	rawOutputs []Output,
+) error {
 	total := len(rawOutputs)
 	if total == 0 {
 		slog.DebugContext(ctx, "SyncOutputsProofs: no new proofs to sync")
@@ -96,11 +136,7 @@ func (s *SynchronizerOutputUpdate) syncOutputsProofs(ctx context.Context) error
 		"updated_at", lastOutputRefWithoutProof.UpdatedAt,
 		"rawOutputs", len(rawOutputs),
 	)
-	err = s.RawOutputRefRepository.UpdateSyncPriority(ctx, lastOutputRefWithoutProof)
-	if err != nil {
-		return err
-	}
-	return nil
+	return s.RawOutputRefRepository.UpdateSyncPriority(ctx, lastOutputRefWithoutProof)
 }
 
 func (s *SynchronizerOutputUpdate) SetTopPriority(
@@ -144,6 +180,7 @@ func (s *SynchronizerOutputUpdate) UpdateProof(
 	if err != nil {
 		return err
 	}
+	markAppChanged(ctx, common.HexToAddress(ref.AppContract))
 	if ref.Type == repository.RAW_VOUCHER_TYPE {
 		err = s.VoucherRepository.SetProof(ctx,
 			&model.ConvenienceVoucher{
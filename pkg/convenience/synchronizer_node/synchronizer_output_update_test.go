@@ -9,6 +9,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
@@ -50,6 +51,7 @@ func (s *SynchronizerOutputUpdateSuite) SetupTest() {
 	// sqliteFileName = fmt.Sprintf("../../../sync-proof-output-%d.sqlite3", time.Now().Unix())
 
 	db := sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, db))
 	s.container = convenience.NewContainer(db, false)
 
 	dbNodeV2 := sqlx.MustConnect("postgres", RAW_DB_URL)
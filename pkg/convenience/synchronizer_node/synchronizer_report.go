@@ -53,8 +53,52 @@ func (s *SynchronizerReport) syncReports(ctx context.Context) error {
 		slog.ErrorContext(ctx, "fail to find all reports")
 		return err
 	}
+	return s.createReports(ctx, rawReports)
+}
+
+// SyncAppReports syncs the reports of a single application using its own checkpoint.
+func (s *SynchronizerReport) SyncAppReports(ctx context.Context, appID uint64) (int, error) {
+	txCtx, err := s.startTransaction(ctx)
+	if err != nil {
+		return 0, err
+	}
+	total, err := s.syncAppReports(txCtx, appID, LIMIT)
+	if err != nil {
+		s.rollbackTransaction(txCtx)
+		return 0, err
+	}
+	err = s.commitTransaction(txCtx)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (s *SynchronizerReport) syncAppReports(ctx context.Context, appID uint64, limit uint64) (int, error) {
+	lastReport, err := s.ReportRepository.FindLastReportByAppID(ctx, appID)
+	if err != nil {
+		return 0, err
+	}
+	lastReportIndex := int64(-1)
+	if lastReport != nil {
+		lastReportIndex = int64(lastReport.Index)
+	}
+	rawReports, err := s.RawRepository.FindAppReportsGtIndex(ctx, appID, lastReportIndex, limit)
+	if err != nil {
+		return 0, err
+	}
+	rawReports = boundToSyncedInputs(ctx, rawReports, reportInputIndex)
+	err = s.createReports(ctx, rawReports)
+	if err != nil {
+		return 0, err
+	}
+	return len(rawReports), nil
+}
+
+func (s *SynchronizerReport) createReports(ctx context.Context, rawReports []RawReport) error {
 	for _, rawReport := range rawReports {
-		_, err = s.ReportRepository.CreateReport(ctx, model.Report{
+		markAppChanged(ctx, common.BytesToAddress(rawReport.AppContract))
+		_, err := s.ReportRepository.CreateReport(ctx, model.Report{
 			AppContract: common.BytesToAddress(rawReport.AppContract),
 			Index:       int(rawReport.Index),
 			InputIndex:  int(rawReport.InputIndex),
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/suite"
@@ -49,6 +50,7 @@ func (s *SynchronizerReportSuite) SetupTest() {
 	sqliteFileName := filepath.Join(tempDir, "report.sqlite3")
 
 	s.db = sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.container = convenience.NewContainer(s.db, false)
 
 	s.dbNodeV2 = sqlx.MustConnect("postgres", RAW_DB_URL)
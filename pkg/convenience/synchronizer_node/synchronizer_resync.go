@@ -0,0 +1,226 @@
+package synchronizernode
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SynchronizerResync rebuilds the synced data of applications from the node database.
+// Each application is rebuilt in a single transaction while the API keeps serving,
+// so the readers see either the previous rows or the rebuilt ones.
+// The change listener of the worker, if any, is told each rebuilt application.
+type SynchronizerResync struct {
+	Worker           SynchronizerCreateWorker
+	ResyncRepository *repository.ResyncRepository
+}
+
+func NewSynchronizerResync(
+	worker SynchronizerCreateWorker,
+	resyncRepository *repository.ResyncRepository,
+) *SynchronizerResync {
+	return &SynchronizerResync{
+		Worker:           worker,
+		ResyncRepository: resyncRepository,
+	}
+}
+
+// Resync deletes the rows of the application, or of all applications when appContract is nil,
+// from the input index fromInput on and syncs them again.
+func (s *SynchronizerResync) Resync(ctx context.Context, appContract *common.Address, fromInput uint64) error {
+	err := s.Worker.RawRepository.Db.PingContext(ctx)
+	if err != nil {
+		return fmt.Errorf("node database unavailable: %w", err)
+	}
+	err = s.Worker.SynchronizerAppCreate.SyncApps(ctx)
+	if err != nil {
+		return err
+	}
+	apps, err := s.Worker.SynchronizerAppCreate.AppRepository.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	if appContract != nil {
+		apps = filterApps(apps, *appContract)
+		if len(apps) == 0 {
+			return fmt.Errorf("application %s not found", appContract.Hex())
+		}
+	}
+	for _, app := range apps {
+		startedAt := time.Now()
+		err := s.resyncApp(ctx, app, fromInput)
+		if err != nil {
+			return fmt.Errorf("failed to resync application %s: %w", app.ApplicationAddress, err)
+		}
+		notifyAppChanged(ctx, s.Worker.ChangeListener, common.HexToAddress(app.ApplicationAddress))
+		slog.InfoContext(ctx, "Application resynced",
+			"app_id", app.ID,
+			"app_contract", app.ApplicationAddress,
+			"from_input", fromInput,
+			"after", time.Since(startedAt),
+		)
+	}
+	return nil
+}
+
+func filterApps(apps []model.ConvenienceApplication, appContract common.Address) []model.ConvenienceApplication {
+	for _, app := range apps {
+		if strings.EqualFold(app.ApplicationAddress, appContract.Hex()) {
+			return []model.ConvenienceApplication{app}
+		}
+	}
+	return nil
+}
+
+func (s *SynchronizerResync) resyncApp(ctx context.Context, app model.ConvenienceApplication, fromInput uint64) error {
+	txCtx, tx, err := repository.StartTransactionContext(ctx, s.ResyncRepository.Db)
+	if err != nil {
+		return err
+	}
+	err = s.rebuildApp(txCtx, app, fromInput)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			slog.ErrorContext(ctx, "transaction rollback error", "err", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebuildApp runs the synchronizers of the application until they catch up with the node,
+// all of them in the transaction of the context.
+func (s *SynchronizerResync) rebuildApp(ctx context.Context, app model.ConvenienceApplication, fromInput uint64) error {
+	// wait for the synchronizer to finish its current cycle of the application
+	err := repository.LockAppSyncTx(ctx, app.ID)
+	if err != nil {
+		return err
+	}
+	err = s.ResyncRepository.DeleteAppFromInput(ctx, app.ID, common.HexToAddress(app.ApplicationAddress), fromInput)
+	if err != nil {
+		return err
+	}
+	w := s.Worker
+	err = drainAll(ctx, func(ctx context.Context) (int, error) {
+		return w.SynchronizerCreateInput.syncAppInputs(ctx, app.ID, LIMIT)
+	})
+	if err != nil {
+		return err
+	}
+	err = drainUntilStable(ctx,
+		func(ctx context.Context) (any, error) {
+			ref, err := w.SynchronizerUpdate.RawInputRefRepository.FindFirstInputByStatusNoneByAppID(ctx, app.ID)
+			if err != nil || ref == nil {
+				return nil, err
+			}
+			return ref.InputIndex, nil
+		},
+		func(ctx context.Context) error {
+			return w.SynchronizerUpdate.syncAppInputStatus(ctx, app.ID, uint64(w.SynchronizerUpdate.BatchSize))
+		},
+	)
+	if err != nil {
+		return err
+	}
+	err = drainAll(ctx, func(ctx context.Context) (int, error) {
+		return w.SynchronizerReport.syncAppReports(ctx, app.ID, LIMIT)
+	})
+	if err != nil {
+		return err
+	}
+	err = drainAll(ctx, func(ctx context.Context) (int, error) {
+		return w.SynchronizerOutputCreate.syncAppOutputs(ctx, app.ID, LIMIT)
+	})
+	if err != nil {
+		return err
+	}
+	err = drainUntilStable(ctx,
+		func(ctx context.Context) (any, error) {
+			ref, err := w.SynchronizerOutputUpdate.RawOutputRefRepository.GetFirstOutputRefWithoutProofByAppID(ctx, app.ID)
+			if err != nil || ref == nil {
+				return nil, err
+			}
+			return ref.OutputIndex, nil
+		},
+		func(ctx context.Context) error {
+			return w.SynchronizerOutputUpdate.syncAppOutputsProofs(ctx, app.ID, LIMIT)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return s.resyncExecutions(ctx, app.ID, fromInput)
+}
+
+// resyncExecutions walks all the executed outputs of the application, since the execution
+// checkpoint of the remaining rows may be later than the executions of the deleted ones.
+func (s *SynchronizerResync) resyncExecutions(ctx context.Context, appID uint64, fromInput uint64) error {
+	executed := s.Worker.SynchronizerOutputExecuted
+	cursor := &repository.RawOutputRef{AppID: appID, UpdatedAt: time.Unix(0, 0)}
+	for {
+		rawOutputs, err := executed.RawNodeV2Repository.FindAppOutputsExecutedAfter(ctx, appID, cursor, LIMIT)
+		if err != nil {
+			return err
+		}
+		for _, rawOutput := range rawOutputs {
+			if rawOutput.InputIndex < fromInput {
+				continue
+			}
+			err = executed.UpdateExecutionData(ctx, rawOutput)
+			if err != nil {
+				return err
+			}
+		}
+		if len(rawOutputs) < int(LIMIT) {
+			return nil
+		}
+		last := rawOutputs[len(rawOutputs)-1]
+		cursor = &repository.RawOutputRef{AppID: appID, UpdatedAt: last.UpdatedAt, OutputIndex: last.Index}
+	}
+}
+
+// drainAll fetches batches until the node has no more rows.
+func drainAll(ctx context.Context, syncBatch func(ctx context.Context) (int, error)) error {
+	for {
+		total, err := syncBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if total < int(LIMIT) || ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// drainUntilStable runs an update step until its checkpoint stops moving.
+func drainUntilStable(
+	ctx context.Context,
+	checkpoint func(ctx context.Context) (any, error),
+	step func(ctx context.Context) error,
+) error {
+	current, err := checkpoint(ctx)
+	if err != nil {
+		return err
+	}
+	for current != nil {
+		err = step(ctx)
+		if err != nil {
+			return err
+		}
+		next, err := checkpoint(ctx)
+		if err != nil {
+			return err
+		}
+		if next == current || ctx.Err() != nil {
+			return ctx.Err()
+		}
+		current = next
+	}
+	return nil
+}
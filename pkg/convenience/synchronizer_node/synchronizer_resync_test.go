@@ -0,0 +1,85 @@
+package synchronizernode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResyncSuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (s *ResyncSuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func TestResyncSuite(t *testing.T) {
+	suite.Run(t, new(ResyncSuite))
+}
+
+func (s *ResyncSuite) TestDrainAllIsNotBounded() {
+	calls := 0
+	err := drainAll(s.ctx, func(ctx context.Context) (int, error) {
+		calls++
+		if calls <= MAX_APP_BATCHES_PER_CYCLE {
+			return int(LIMIT), nil
+		}
+		return 0, nil
+	})
+	s.Require().NoError(err)
+	s.Equal(MAX_APP_BATCHES_PER_CYCLE+1, calls)
+}
+
+func (s *ResyncSuite) TestDrainUntilStableStopsWhenCheckpointStops() {
+	checkpoints := []any{uint64(1), uint64(3), uint64(5), uint64(5)}
+	reads := 0
+	steps := 0
+	err := drainUntilStable(s.ctx,
+		func(ctx context.Context) (any, error) {
+			checkpoint := checkpoints[reads]
+			reads++
+			return checkpoint, nil
+		},
+		func(ctx context.Context) error {
+			steps++
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+	s.Equal(3, steps)
+}
+
+func (s *ResyncSuite) TestDrainUntilStableStopsWithoutCheckpoint() {
+	steps := 0
+	err := drainUntilStable(s.ctx,
+		func(ctx context.Context) (any, error) {
+			if steps == 0 {
+				return uint64(1), nil
+			}
+			return nil, nil
+		},
+		func(ctx context.Context) error {
+			steps++
+			return nil
+		},
+	)
+	s.Require().NoError(err)
+	s.Equal(1, steps)
+}
+
+func (s *ResyncSuite) TestDrainUntilStableReturnsError() {
+	expected := errors.New("node unavailable")
+	err := drainUntilStable(s.ctx,
+		func(ctx context.Context) (any, error) {
+			return uint64(1), nil
+		},
+		func(ctx context.Context) error {
+			return expected
+		},
+	)
+	s.ErrorIs(err, expected)
+}
@@ -129,7 +129,9 @@ func (s *SynchronizerUpdate) updateStatus(ctx context.Context, rawInputs []RawIn
 		err := s.InputRepository.UpdateStatus(ctx, appContract, rawInput.Index, status)
 		if err != nil {
 			slog.WarnContext(ctx, "Ignoring missing input", "err", err)
+			continue
 		}
+		markAppChanged(ctx, appContract)
 	}
 	return nil
 }
@@ -176,3 +178,40 @@ func (s *SynchronizerUpdate) SyncInputStatus(ctx context.Context) error {
 	}
 	return nil
 }
+
+// SyncAppInputStatus syncs the input statuses of a single application using its own checkpoint.
+func (s *SynchronizerUpdate) SyncAppInputStatus(ctx context.Context, appID uint64) error {
+	ctxWithTx, err := s.startTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.syncAppInputStatus(ctxWithTx, appID, uint64(s.BatchSize))
+	if err != nil {
+		s.rollbackTransaction(ctxWithTx)
+		return err
+	}
+	return s.commitTransaction(ctxWithTx)
+}
+
+func (s *SynchronizerUpdate) syncAppInputStatus(ctx context.Context, appID uint64, limit uint64) error {
+	inputRef, err := s.RawInputRefRepository.FindFirstInputByStatusNoneByAppID(ctx, appID)
+	if err != nil {
+		return err
+	}
+	if inputRef == nil {
+		return nil
+	}
+	for _, rosetta := range GetStatusRosetta() {
+		rawInputs, err := s.RawNodeRepository.FindAppInputsGteIndexWithStatus(
+			ctx, appID, inputRef.InputIndex, rosetta.RawStatus, limit,
+		)
+		if err != nil {
+			return err
+		}
+		err = s.updateManyInputAndRefsStatus(ctx, rawInputs, rosetta)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/postgres/raw"
@@ -57,6 +58,7 @@ func (s *SynchronizerUpdateNodeSuite) SetupTest() {
 	sqliteFileName := filepath.Join(tempDir, "update_input.sqlite3")
 	slog.Debug("SetupTest", "sqliteFileName", sqliteFileName)
 	s.db = sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.container = convenience.NewContainer(s.db, false)
 
 	s.dbNodeV2 = sqlx.MustConnect("postgres", RAW_DB_URL)
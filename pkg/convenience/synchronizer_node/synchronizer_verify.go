@@ -0,0 +1,699 @@
+package synchronizernode
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SynchronizerVerifier compares the rows of the node database with the synced ones.
+// Only the rows up to the sync checkpoints of each application are compared,
+// so the rows the synchronizer has not reached yet are not reported as missing.
+type SynchronizerVerifier struct {
+	Worker           SynchronizerCreateWorker
+	VerifyRepository *repository.VerifyRepository
+	Resync           *SynchronizerResync
+	last             lastVerifyReport
+}
+
+func NewSynchronizerVerifier(
+	worker SynchronizerCreateWorker,
+	verifyRepository *repository.VerifyRepository,
+	resync *SynchronizerResync,
+) *SynchronizerVerifier {
+	return &SynchronizerVerifier{
+		Worker:           worker,
+		VerifyRepository: verifyRepository,
+		Resync:           resync,
+	}
+}
+
+// A row of either database with the compared fields in a fixed order.
+type verifyRow struct {
+	Index      uint64
+	InputIndex uint64
+	Fields     []verifyField
+	// The node row cannot be converted and is kept as a dead letter
+	Quarantined bool
+}
+
+type verifyField struct {
+	Name  string
+	Value string
+}
+
+// Reads the page of rows after the given index, nil reads from the start.
+type verifyPage func(ctx context.Context, after *uint64) ([]verifyRow, error)
+
+// Checkpoints of an application, the fields of the rows after them are still being synced.
+type verifyBounds struct {
+	inputs  *uint64
+	outputs *uint64
+	reports *uint64
+	// first input still waiting for its status
+	status *uint64
+	// first output still waiting for its proof
+	proof *uint64
+	// last execution synced, the executions follow the update time of the outputs instead of their index
+	execution *repository.RawOutputRef
+}
+
+// executionSynced reports whether the execution of the node output was reached by the execution checkpoint.
+func (b *verifyBounds) executionSynced(updatedAt time.Time, index uint64) bool {
+	if b.execution == nil {
+		return false
+	}
+	return updatedAt.Before(b.execution.UpdatedAt) ||
+		(updatedAt.Equal(b.execution.UpdatedAt) && index <= b.execution.OutputIndex)
+}
+
+// Verify compares one application, or all of them when appContract is nil.
+// With repair the inconsistent applications are resynced from their first divergent input
+// and verified again.
+func (s *SynchronizerVerifier) Verify(
+	ctx context.Context, appContract *common.Address, repair bool,
+) (*model.VerifyReport, error) {
+	report := &model.VerifyReport{StartedAt: time.Now(), Consistent: true}
+	apps, err := s.Worker.SynchronizerAppCreate.AppRepository.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if appContract != nil {
+		apps = filterApps(apps, *appContract)
+		if len(apps) == 0 {
+			return nil, fmt.Errorf("%w: %s", repository.ErrApplicationNotFound, appContract.Hex())
+		}
+	}
+	for _, app := range apps {
+		appReport, err := s.verifyApp(ctx, app)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify application %s: %w", app.ApplicationAddress, err)
+		}
+		if appReport.HasIssues() && repair {
+			address := common.HexToAddress(app.ApplicationAddress)
+			err = s.Resync.Resync(ctx, &address, firstIssueInput(appReport))
+			if err != nil {
+				return nil, fmt.Errorf("failed to repair application %s: %w", app.ApplicationAddress, err)
+			}
+			report.Repaired = append(report.Repaired, app.ApplicationAddress)
+			appReport, err = s.verifyApp(ctx, app)
+			if err != nil {
+				return nil, fmt.Errorf("failed to verify application %s: %w", app.ApplicationAddress, err)
+			}
+		}
+		if appReport.HasIssues() {
+			report.Consistent = false
+		}
+		report.Apps = append(report.Apps, *appReport)
+	}
+	report.FinishedAt = time.Now()
+	s.last.set(report)
+	return report, nil
+}
+
+// LastReport returns the report of the latest verification, nil before the first one.
+func (s *SynchronizerVerifier) LastReport() *model.VerifyReport {
+	return s.last.get()
+}
+
+func firstIssueInput(appReport *model.AppVerifyReport) uint64 {
+	first := uint64(0)
+	for i, issue := range appReport.Issues {
+		if i == 0 || issue.InputIndex < first {
+			first = issue.InputIndex
+		}
+	}
+	return first
+}
+
+func (s *SynchronizerVerifier) verifyApp(
+	ctx context.Context, app model.ConvenienceApplication,
+) (*model.AppVerifyReport, error) {
+	appReport := &model.AppVerifyReport{
+		AppID:       app.ID,
+		AppContract: app.ApplicationAddress,
+		Issues:      []model.VerifyIssue{},
+	}
+	bounds, err := s.findBounds(ctx, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	appContract := common.HexToAddress(app.ApplicationAddress).Hex()
+	kinds := []struct {
+		kind        string
+		until       *uint64
+		node        verifyPage
+		convenience verifyPage
+	}{
+		{model.VERIFY_INPUTS, bounds.inputs,
+			s.nodeInputs(app.ID, bounds), s.convenienceInputs(appContract, bounds)},
+		{model.VERIFY_OUTPUTS, bounds.outputs,
+			s.nodeOutputs(app.ID, bounds), s.convenienceOutputs(appContract, bounds)},
+		{model.VERIFY_REPORTS, bounds.reports,
+			s.nodeReports(app.ID), s.convenienceReports(appContract)},
+	}
+	for _, k := range kinds {
+		kindReport, err := compareRows(ctx, k.kind, k.until, k.node, k.convenience, appReport)
+		if err != nil {
+			return nil, err
+		}
+		appReport.Kinds = append(appReport.Kinds, *kindReport)
+	}
+	return appReport, nil
+}
+
+func (s *SynchronizerVerifier) findBounds(ctx context.Context, appID uint64) (*verifyBounds, error) {
+	w := s.Worker
+	bounds := &verifyBounds{}
+	inputRef, err := w.SynchronizerUpdate.RawInputRefRepository.GetLatestInputRefByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if inputRef != nil {
+		bounds.inputs = &inputRef.InputIndex
+	}
+	outputRef, err := w.SynchronizerOutputUpdate.RawOutputRefRepository.FindLatestRawOutputRefByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if outputRef != nil {
+		bounds.outputs = &outputRef.OutputIndex
+	}
+	lastReport, err := w.SynchronizerReport.ReportRepository.FindLastReportByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if lastReport != nil {
+		index := uint64(lastReport.Index)
+		bounds.reports = &index
+	}
+	statusRef, err := w.SynchronizerUpdate.RawInputRefRepository.FindFirstInputByStatusNoneByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if statusRef != nil {
+		bounds.status = &statusRef.InputIndex
+	}
+	proofRef, err := w.SynchronizerOutputUpdate.RawOutputRefRepository.GetFirstOutputRefWithoutProofByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if proofRef != nil {
+		bounds.proof = &proofRef.OutputIndex
+	}
+	bounds.execution, err = w.SynchronizerOutputUpdate.RawOutputRefRepository.GetLastUpdatedAtExecutedByAppID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	return bounds, nil
+}
+
+// before reports whether the index was reached by a checkpoint, nil meaning all of them.
+func before(index uint64, checkpoint *uint64) bool {
+	return checkpoint == nil || index < *checkpoint
+}
+
+func (s *SynchronizerVerifier) nodeInputs(appID uint64, bounds *verifyBounds) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		rawInputs, err := s.Worker.RawRepository.FindAppInputsGtIndex(ctx, appID, after, LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]verifyRow, 0, len(rawInputs))
+		for _, rawInput := range rawInputs {
+			row := verifyRow{Index: rawInput.Index, InputIndex: rawInput.Index}
+			input, err := s.Worker.SynchronizerCreateInput.GetAdvanceInputFromMap(rawInput)
+			if err != nil {
+				row.Quarantined = true
+				rows = append(rows, row)
+				continue
+			}
+			row.Fields = []verifyField{
+				{"id", input.ID},
+				{"msg_sender", input.MsgSender.Hex()},
+				{"payload", "0x" + input.Payload},
+				{"block_number", strconv.FormatUint(input.BlockNumber, 10)},
+			}
+			if before(rawInput.Index, bounds.status) {
+				status := commons.ConvertStatusStringToCompletionStatus(rawInput.Status)
+				row.Fields = append(row.Fields, verifyField{"status", strconv.Itoa(int(status))})
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+}
+
+func (s *SynchronizerVerifier) convenienceInputs(appContract string, bounds *verifyBounds) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		inputs, err := s.VerifyRepository.FindAppInputsGtIndex(ctx, appContract, after, LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]verifyRow, 0, len(inputs))
+		for _, input := range inputs {
+			row := verifyRow{Index: input.Index, InputIndex: input.Index, Fields: []verifyField{
+				{"id", input.ID},
+				{"msg_sender", input.MsgSender},
+				{"block_number", strconv.FormatUint(input.BlockNumber, 10)},
+			}}
+			if !input.Pruned {
+				row.Fields = append(row.Fields, verifyField{"payload", input.Payload})
+			}
+			if before(input.Index, bounds.status) {
+				row.Fields = append(row.Fields, verifyField{"status", input.Status})
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+}
+
+func (s *SynchronizerVerifier) nodeOutputs(appID uint64, bounds *verifyBounds) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		rawOutputs, err := s.Worker.RawRepository.FindAppOutputsGtIndex(ctx, appID, after, LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]verifyRow, 0, len(rawOutputs))
+		for _, rawOutput := range rawOutputs {
+			row := verifyRow{Index: rawOutput.Index, InputIndex: rawOutput.InputIndex}
+			outputType, err := s.nodeOutputType(rawOutput)
+			if err != nil {
+				row.Quarantined = true
+				rows = append(rows, row)
+				continue
+			}
+			executed := outputType == repository.RAW_VOUCHER_TYPE && len(rawOutput.TransactionHash) > 0
+			transactionHash := ""
+			if executed {
+				transactionHash = "0x" + common.Bytes2Hex(rawOutput.TransactionHash)
+			}
+			row.Fields = outputFields(
+				rawOutput.Index,
+				bounds,
+				outputType,
+				"0x"+common.Bytes2Hex(rawOutput.RawData),
+				rawOutput.InputIndex,
+				len(rawOutput.OutputHashesSiblings) > 0,
+				// an output not executed yet has nothing to sync
+				!executed || bounds.executionSynced(rawOutput.UpdatedAt, rawOutput.Index),
+				executed,
+				transactionHash,
+			)
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+}
+
+// nodeOutputType fails for the outputs the synchronizer quarantines.
+func (s *SynchronizerVerifier) nodeOutputType(rawOutput Output) (string, error) {
+	outputType, err := getOutputType(rawOutput.RawData)
+	if err != nil {
+		return "", err
+	}
+	if outputType == repository.RAW_VOUCHER_TYPE {
+		_, err = s.Worker.SynchronizerOutputCreate.ToConvenienceVoucher(rawOutput)
+	} else {
+		_, err = s.Worker.SynchronizerOutputCreate.ToConvenienceNotice(rawOutput)
+	}
+	return outputType, err
+}
+
+func (s *SynchronizerVerifier) convenienceOutputs(appContract string, bounds *verifyBounds) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		outputs, err := s.VerifyRepository.FindAppOutputsGtIndex(ctx, appContract, after, LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]verifyRow, 0, len(outputs))
+		for _, output := range outputs {
+			row := verifyRow{
+				Index:      output.Index,
+				InputIndex: output.InputIndex,
+				Fields: outputFields(
+					output.Index,
+					bounds,
+					output.Type,
+					output.Payload,
+					output.InputIndex,
+					output.OutputHashesSiblings != "",
+					// only compared when the node output is within the execution checkpoint
+					true,
+					output.Executed,
+					output.TransactionHash,
+				),
+			}
+			if output.Pruned {
+				row.Fields = withoutField(row.Fields, "payload")
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+}
+
+func outputFields(
+	index uint64,
+	bounds *verifyBounds,
+	outputType string,
+	payload string,
+	inputIndex uint64,
+	proof bool,
+	execution bool,
+	executed bool,
+	transactionHash string,
+) []verifyField {
+	fields := []verifyField{
+		{"type", outputType},
+		{"payload", payload},
+		{"input_index", strconv.FormatUint(inputIndex, 10)},
+	}
+	if execution {
+		fields = append(fields,
+			verifyField{"executed", strconv.FormatBool(executed)},
+			verifyField{"transaction_hash", transactionHash},
+		)
+	}
+	if before(index, bounds.proof) {
+		fields = append(fields, verifyField{"proof", strconv.FormatBool(proof)})
+	}
+	return fields
+}
+
+func (s *SynchronizerVerifier) nodeReports(appID uint64) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		rawReports, err := s.Worker.RawRepository.FindAppReportsGtIndex(ctx, appID, afterReport(after), LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]verifyRow, 0, len(rawReports))
+		for _, rawReport := range rawReports {
+			rows = append(rows, reportRow(
+				rawReport.Index, rawReport.InputIndex, "0x"+common.Bytes2Hex(rawReport.RawData),
+			))
+		}
+		return rows, nil
+	}
+}
+
+func afterReport(after *uint64) int64 {
+	if after == nil {
+		return -1
+	}
+	return int64(*after)
+}
+
+func (s *SynchronizerVerifier) convenienceReports(appContract string) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		reports, err := s.VerifyRepository.FindAppReportsGtIndex(ctx, appContract, after, LIMIT)
+		if err != nil {
+			return nil, err
+		}
+		rows := make([]verifyRow, 0, len(reports))
+		for _, report := range reports {
+			row := reportRow(report.Index, report.InputIndex, report.Payload)
+			if report.Pruned {
+				row.Fields = withoutField(row.Fields, "payload")
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+}
+
+func reportRow(index uint64, inputIndex uint64, payload string) verifyRow {
+	return verifyRow{Index: index, InputIndex: inputIndex, Fields: []verifyField{
+		{"payload", payload},
+		{"input_index", strconv.FormatUint(inputIndex, 10)},
+	}}
+}
+
+func withoutField(fields []verifyField, name string) []verifyField {
+	kept := []verifyField{}
+	for _, field := range fields {
+		if field.Name != name {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
+
+// verifyCursor reads the rows of one database page by page up to the checkpoint.
+type verifyCursor struct {
+	page  verifyPage
+	until *uint64
+	rows  []verifyRow
+	after *uint64
+	done  bool
+	hash  []byte
+	count uint64
+}
+
+// peek returns the current row or nil when there are no more rows.
+func (c *verifyCursor) peek(ctx context.Context) (*verifyRow, error) {
+	for len(c.rows) == 0 {
+		if c.done || c.until == nil {
+			return nil, nil
+		}
+		rows, err := c.page(ctx, c.after)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) < int(LIMIT) {
+			c.done = true
+		}
+		if len(rows) > 0 {
+			last := rows[len(rows)-1].Index
+			c.after = &last
+		}
+		for _, row := range rows {
+			if row.Index > *c.until {
+				c.done = true
+				break
+			}
+			c.rows = append(c.rows, row)
+		}
+	}
+	return &c.rows[0], nil
+}
+
+// pop removes the current row, hash adds it to the count and content hash.
+func (c *verifyCursor) pop() verifyRow {
+	row := c.rows[0]
+	c.rows = c.rows[1:]
+	return row
+}
+
+func (c *verifyCursor) hashRow(row verifyRow) {
+	if row.Quarantined {
+		return
+	}
+	c.count++
+	h := sha256.New()
+	h.Write(c.hash)
+	fmt.Fprintf(h, "%d|%d", row.Index, row.InputIndex)
+	for _, field := range row.Fields {
+		fmt.Fprintf(h, "|%s=%s", field.Name, field.Value)
+	}
+	c.hash = h.Sum(nil)
+}
+
+// compareRows merges the rows of both databases by index, appending the issues to the app report.
+func compareRows(
+	ctx context.Context,
+	kind string,
+	until *uint64,
+	nodePage verifyPage,
+	conveniencePage verifyPage,
+	appReport *model.AppVerifyReport,
+) (*model.KindVerifyReport, error) {
+	report := &model.KindVerifyReport{Kind: kind}
+	node := &verifyCursor{page: nodePage, until: until}
+	convenience := &verifyCursor{page: conveniencePage, until: until}
+	addIssue := func(issue model.VerifyIssue) {
+		if len(appReport.Issues) < model.MAX_VERIFY_ISSUES {
+			appReport.Issues = append(appReport.Issues, issue)
+		}
+	}
+	for {
+		nodeRow, err := node.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		convenienceRow, err := convenience.peek(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if nodeRow == nil && convenienceRow == nil {
+			break
+		}
+		switch {
+		case convenienceRow == nil || (nodeRow != nil && nodeRow.Index < convenienceRow.Index):
+			row := node.pop()
+			node.hashRow(row)
+			if row.Quarantined {
+				report.Quarantined++
+				continue
+			}
+			report.Missing++
+			addIssue(model.VerifyIssue{
+				Kind: kind, Problem: model.VERIFY_MISSING, Index: row.Index, InputIndex: row.InputIndex,
+			})
+		case nodeRow == nil || convenienceRow.Index < nodeRow.Index:
+			row := convenience.pop()
+			convenience.hashRow(row)
+			report.Extra++
+			addIssue(model.VerifyIssue{
+				Kind: kind, Problem: model.VERIFY_EXTRA, Index: row.Index, InputIndex: row.InputIndex,
+			})
+		default:
+			nodeRow := node.pop()
+			convenienceRow := convenience.pop()
+			// the fields missing on either side, like pruned payloads, are not compared
+			nodeRow.Fields = commonFields(nodeRow.Fields, convenienceRow.Fields)
+			convenienceRow.Fields = commonFields(convenienceRow.Fields, nodeRow.Fields)
+			node.hashRow(nodeRow)
+			convenience.hashRow(convenienceRow)
+			if nodeRow.Quarantined {
+				report.Quarantined++
+				report.Extra++
+				addIssue(model.VerifyIssue{
+					Kind: kind, Problem: model.VERIFY_EXTRA,
+					Index: convenienceRow.Index, InputIndex: convenienceRow.InputIndex,
+				})
+				continue
+			}
+			diffs := diffFields(nodeRow.Fields, convenienceRow.Fields)
+			if len(diffs) > 0 {
+				report.Divergent++
+				addIssue(model.VerifyIssue{
+					Kind: kind, Problem: model.VERIFY_DIVERGENT,
+					Index: nodeRow.Index, InputIndex: min(nodeRow.InputIndex, convenienceRow.InputIndex),
+					Fields: diffs,
+				})
+			}
+		}
+	}
+	report.NodeCount = node.count
+	report.ConvenienceCount = convenience.count
+	report.NodeHash = hex.EncodeToString(node.hash)
+	report.ConvenienceHash = hex.EncodeToString(convenience.hash)
+	return report, nil
+}
+
+// commonFields keeps the fields also present in others.
+func commonFields(fields []verifyField, others []verifyField) []verifyField {
+	names := make(map[string]bool, len(others))
+	for _, field := range others {
+		names[field.Name] = true
+	}
+	shared := []verifyField{}
+	for _, field := range fields {
+		if names[field.Name] {
+			shared = append(shared, field)
+		}
+	}
+	return shared
+}
+
+func diffFields(node []verifyField, convenience []verifyField) []model.VerifyFieldDiff {
+	values := make(map[string]string, len(convenience))
+	for _, field := range convenience {
+		values[field.Name] = field.Value
+	}
+	diffs := []model.VerifyFieldDiff{}
+	for _, field := range node {
+		value, ok := values[field.Name]
+		if ok && value != field.Value {
+			diffs = append(diffs, model.VerifyFieldDiff{
+				Name:        field.Name,
+				Node:        field.Value,
+				Convenience: value,
+			})
+		}
+	}
+	return diffs
+}
+
+// VerifyWorker verifies all the applications periodically.
+type VerifyWorker struct {
+	Verifier *SynchronizerVerifier
+	Interval time.Duration
+	Repair   bool
+}
+
+type lastVerifyReport struct {
+	mu     sync.Mutex
+	report *model.VerifyReport
+}
+
+func (l *lastVerifyReport) get() *model.VerifyReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.report
+}
+
+func (l *lastVerifyReport) set(report *model.VerifyReport) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.report = report
+}
+
+func (w VerifyWorker) String() string {
+	return "verify"
+}
+
+// Start implements supervisor.Worker.
+func (w VerifyWorker) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			report, err := w.Verifier.Verify(ctx, nil, w.Repair)
+			if err != nil {
+				slog.WarnContext(ctx, "Verification failed", "err", err)
+				continue
+			}
+			logVerifyReport(ctx, report)
+		}
+	}
+}
+
+func logVerifyReport(ctx context.Context, report *model.VerifyReport) {
+	for _, app := range report.Apps {
+		if !app.HasIssues() {
+			continue
+		}
+		for _, kind := range app.Kinds {
+			slog.WarnContext(ctx, "Inconsistent application",
+				"app_contract", app.AppContract,
+				"kind", kind.Kind,
+				"missing", kind.Missing,
+				"extra", kind.Extra,
+				"divergent", kind.Divergent,
+			)
+		}
+	}
+	slog.InfoContext(ctx, "Verification finished",
+		"consistent", report.Consistent,
+		"apps", len(report.Apps),
+		"repaired", len(report.Repaired),
+		"after", report.FinishedAt.Sub(report.StartedAt),
+	)
+}
@@ -0,0 +1,163 @@
+package synchronizernode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifySuite struct {
+	suite.Suite
+	ctx context.Context
+}
+
+func (s *VerifySuite) SetupTest() {
+	s.ctx = context.Background()
+}
+
+func TestVerifySuite(t *testing.T) {
+	suite.Run(t, new(VerifySuite))
+}
+
+// fakePage serves the rows after the given index, LIMIT rows at a time.
+func fakePage(rows []verifyRow) verifyPage {
+	return func(ctx context.Context, after *uint64) ([]verifyRow, error) {
+		page := []verifyRow{}
+		for _, row := range rows {
+			if (after == nil || row.Index > *after) && len(page) < int(LIMIT) {
+				page = append(page, row)
+			}
+		}
+		return page, nil
+	}
+}
+
+func voucherRow(index uint64, executed bool, transactionHash string) verifyRow {
+	return verifyRow{Index: index, InputIndex: index / 2, Fields: outputFields(
+		index, &verifyBounds{}, "voucher", "0x237a816f", index/2, true, true, executed, transactionHash,
+	)}
+}
+
+func (s *VerifySuite) TestCompareRows() {
+	until := uint64(5)
+	node := []verifyRow{
+		voucherRow(0, false, ""),
+		voucherRow(1, true, "0xff"),
+		{Index: 2, InputIndex: 1, Quarantined: true},
+		voucherRow(3, false, ""),
+		voucherRow(5, false, ""),
+		// not synced yet
+		voucherRow(6, false, ""),
+	}
+	convenience := []verifyRow{
+		voucherRow(0, false, ""),
+		voucherRow(1, false, ""),
+		voucherRow(4, false, ""),
+		voucherRow(5, false, ""),
+	}
+	appReport := &model.AppVerifyReport{}
+	report, err := compareRows(s.ctx, model.VERIFY_OUTPUTS, &until, fakePage(node), fakePage(convenience), appReport)
+	s.Require().NoError(err)
+
+	s.Equal(uint64(4), report.NodeCount)
+	s.Equal(uint64(4), report.ConvenienceCount)
+	s.NotEqual(report.NodeHash, report.ConvenienceHash)
+	s.Equal(uint64(1), report.Missing)
+	s.Equal(uint64(1), report.Extra)
+	s.Equal(uint64(1), report.Divergent)
+	s.Equal(uint64(1), report.Quarantined)
+	appReport.Kinds = append(appReport.Kinds, *report)
+	s.True(appReport.HasIssues())
+
+	s.Require().Len(appReport.Issues, 3)
+	divergent := appReport.Issues[0]
+	s.Equal(model.VERIFY_DIVERGENT, divergent.Problem)
+	s.Equal(uint64(1), divergent.Index)
+	s.Equal([]model.VerifyFieldDiff{
+		{Name: "executed", Node: "true", Convenience: "false"},
+		{Name: "transaction_hash", Node: "0xff", Convenience: ""},
+	}, divergent.Fields)
+	s.Equal(model.VERIFY_MISSING, appReport.Issues[1].Problem)
+	s.Equal(uint64(3), appReport.Issues[1].Index)
+	s.Equal(model.VERIFY_EXTRA, appReport.Issues[2].Problem)
+	s.Equal(uint64(4), appReport.Issues[2].Index)
+	s.Equal(uint64(0), firstIssueInput(appReport))
+}
+
+func (s *VerifySuite) TestCompareRowsConsistentAcrossPages() {
+	rows := []verifyRow{}
+	for index := uint64(0); index < 2*LIMIT+3; index++ {
+		rows = append(rows, voucherRow(index, index%3 == 0, ""))
+	}
+	until := uint64(len(rows) - 1)
+	appReport := &model.AppVerifyReport{}
+	report, err := compareRows(s.ctx, model.VERIFY_OUTPUTS, &until, fakePage(rows), fakePage(rows), appReport)
+	s.Require().NoError(err)
+	s.Equal(uint64(len(rows)), report.NodeCount)
+	s.Equal(report.NodeHash, report.ConvenienceHash)
+	appReport.Kinds = append(appReport.Kinds, *report)
+	s.False(appReport.HasIssues())
+	s.Empty(appReport.Issues)
+}
+
+func (s *VerifySuite) TestCompareRowsWithoutCheckpoint() {
+	appReport := &model.AppVerifyReport{}
+	report, err := compareRows(s.ctx, model.VERIFY_REPORTS, nil,
+		fakePage([]verifyRow{reportRow(0, 0, "0x01")}), fakePage(nil), appReport)
+	s.Require().NoError(err)
+	s.Zero(report.NodeCount)
+	s.False(appReport.HasIssues())
+}
+
+func (s *VerifySuite) TestProofIsNotComparedWhileSyncing() {
+	bounds := &verifyBounds{}
+	proof := uint64(2)
+	bounds.proof = &proof
+	node := outputFields(3, bounds, "notice", "0x01", 1, true, true, false, "")
+	convenience := outputFields(3, bounds, "notice", "0x01", 1, false, true, false, "")
+	s.Empty(diffFields(node, convenience))
+	node = outputFields(1, bounds, "notice", "0x01", 1, true, true, false, "")
+	convenience = outputFields(1, bounds, "notice", "0x01", 1, false, true, false, "")
+	s.Len(diffFields(node, convenience), 1)
+}
+
+func (s *VerifySuite) TestExecutionIsNotComparedWhileSyncing() {
+	checkpoint := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	bounds := &verifyBounds{}
+	s.False(bounds.executionSynced(checkpoint.Add(-time.Hour), 0))
+	bounds.execution = &repository.RawOutputRef{UpdatedAt: checkpoint, OutputIndex: 3}
+	s.True(bounds.executionSynced(checkpoint.Add(-time.Second), 9))
+	s.True(bounds.executionSynced(checkpoint, 3))
+	s.False(bounds.executionSynced(checkpoint, 4))
+	s.False(bounds.executionSynced(checkpoint.Add(time.Second), 0))
+
+	// executed on the node after the checkpoint, not synced yet
+	node := outputFields(4, bounds, "voucher", "0x01", 1, false,
+		bounds.executionSynced(checkpoint.Add(time.Second), 4), true, "0xff")
+	convenience := outputFields(4, bounds, "voucher", "0x01", 1, false, true, false, "")
+	s.Empty(diffFields(commonFields(node, convenience), commonFields(convenience, node)))
+	until := uint64(4)
+	appReport := &model.AppVerifyReport{}
+	report, err := compareRows(s.ctx, model.VERIFY_OUTPUTS, &until,
+		fakePage([]verifyRow{{Index: 4, InputIndex: 1, Fields: node}}),
+		fakePage([]verifyRow{{Index: 4, InputIndex: 1, Fields: convenience}}), appReport)
+	s.Require().NoError(err)
+	s.Zero(report.Divergent)
+	s.Equal(report.NodeHash, report.ConvenienceHash)
+}
+
+func (s *VerifySuite) TestCompareRowsSkipsPrunedPayload() {
+	until := uint64(0)
+	pruned := reportRow(0, 0, "0x")
+	pruned.Fields = withoutField(pruned.Fields, "payload")
+	appReport := &model.AppVerifyReport{}
+	report, err := compareRows(s.ctx, model.VERIFY_REPORTS, &until,
+		fakePage([]verifyRow{reportRow(0, 0, "0x01")}), fakePage([]verifyRow{pruned}), appReport)
+	s.Require().NoError(err)
+	s.Zero(report.Divergent)
+	s.Equal(report.NodeHash, report.ConvenienceHash)
+}
@@ -0,0 +1,295 @@
+// This package dumps the synced data of an application as JSON lines or CSV,
+// with the fields decoded as in the GraphQL API.
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	graphql "github.com/cartesi/rollups-graphql/v2/pkg/reader/model"
+)
+
+const (
+	ENTITY_INPUTS   = "inputs"
+	ENTITY_VOUCHERS = "vouchers"
+	ENTITY_NOTICES  = "notices"
+	ENTITY_REPORTS  = "reports"
+
+	FORMAT_JSONL = "jsonl"
+	FORMAT_CSV   = "csv"
+
+	// Rows read per query
+	PAGE_SIZE = uint64(1000)
+)
+
+var Entities = []string{ENTITY_INPUTS, ENTITY_VOUCHERS, ENTITY_NOTICES, ENTITY_REPORTS}
+var Formats = []string{FORMAT_JSONL, FORMAT_CSV}
+
+type Field struct {
+	Name  string
+	Value any
+}
+
+// A Record is written as a JSON object with the fields in this order, or as a CSV row.
+type Record []Field
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	line := []byte{'{'}
+	for i, f := range r {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, name...)
+		line = append(line, ':')
+		line = append(line, value...)
+	}
+	return append(line, '}'), nil
+}
+
+type recordWriter interface {
+	write(record Record) error
+	flush() error
+}
+
+// Exporter writes the rows of one entity page by page.
+type Exporter struct {
+	Repository *repository.ExportRepository
+}
+
+func NewExporter(exportRepository *repository.ExportRepository) *Exporter {
+	return &Exporter{Repository: exportRepository}
+}
+
+// Export writes the rows of the entity in the range and returns how many were written.
+func (e *Exporter) Export(
+	ctx context.Context, w io.Writer, exportRange repository.ExportRange, entity string, format string,
+) (uint64, error) {
+	var writer recordWriter
+	switch format {
+	case FORMAT_JSONL:
+		writer = &jsonlWriter{w: w}
+	case FORMAT_CSV:
+		writer = &csvWriter{w: csv.NewWriter(w)}
+	default:
+		return 0, fmt.Errorf("unsupported format %q, expected one of %v", format, Formats)
+	}
+	page, err := e.pageReader(entity, exportRange)
+	if err != nil {
+		return 0, err
+	}
+	total := uint64(0)
+	var after *uint64
+	for {
+		records, last, err := page(ctx, after)
+		if err != nil {
+			return total, err
+		}
+		for _, record := range records {
+			err = writer.write(record)
+			if err != nil {
+				return total, err
+			}
+		}
+		total += uint64(len(records))
+		err = writer.flush()
+		if err != nil {
+			return total, err
+		}
+		if uint64(len(records)) < PAGE_SIZE || ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+		after = &last
+	}
+}
+
+// Reads the records after the given index and returns the index of the last one.
+type pageReader func(ctx context.Context, after *uint64) ([]Record, uint64, error)
+
+func (e *Exporter) pageReader(entity string, exportRange repository.ExportRange) (pageReader, error) {
+	switch entity {
+	case ENTITY_INPUTS:
+		return func(ctx context.Context, after *uint64) ([]Record, uint64, error) {
+			inputs, err := e.Repository.FindInputs(ctx, exportRange, after, PAGE_SIZE)
+			if err != nil || len(inputs) == 0 {
+				return nil, 0, err
+			}
+			records := make([]Record, 0, len(inputs))
+			for _, input := range inputs {
+				record, err := InputRecord(ctx, input)
+				if err != nil {
+					return nil, 0, err
+				}
+				records = append(records, record)
+			}
+			return records, uint64(inputs[len(inputs)-1].Index), nil
+		}, nil
+	case ENTITY_VOUCHERS:
+		return func(ctx context.Context, after *uint64) ([]Record, uint64, error) {
+			vouchers, err := e.Repository.FindVouchers(ctx, exportRange, after, PAGE_SIZE)
+			if err != nil || len(vouchers) == 0 {
+				return nil, 0, err
+			}
+			records := make([]Record, 0, len(vouchers))
+			for _, voucher := range vouchers {
+				records = append(records, VoucherRecord(voucher))
+			}
+			return records, vouchers[len(vouchers)-1].OutputIndex, nil
+		}, nil
+	case ENTITY_NOTICES:
+		return func(ctx context.Context, after *uint64) ([]Record, uint64, error) {
+			notices, err := e.Repository.FindNotices(ctx, exportRange, after, PAGE_SIZE)
+			if err != nil || len(notices) == 0 {
+				return nil, 0, err
+			}
+			records := make([]Record, 0, len(notices))
+			for _, notice := range notices {
+				records = append(records, NoticeRecord(notice))
+			}
+			return records, notices[len(notices)-1].OutputIndex, nil
+		}, nil
+	case ENTITY_REPORTS:
+		return func(ctx context.Context, after *uint64) ([]Record, uint64, error) {
+			reports, err := e.Repository.FindReports(ctx, exportRange, after, PAGE_SIZE)
+			if err != nil || len(reports) == 0 {
+				return nil, 0, err
+			}
+			records := make([]Record, 0, len(reports))
+			for _, report := range reports {
+				records = append(records, ReportRecord(report))
+			}
+			return records, uint64(reports[len(reports)-1].Index), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported entity %q, expected one of %v", entity, Entities)
+	}
+}
+
+func InputRecord(ctx context.Context, advanceInput cModel.AdvanceInput) (Record, error) {
+	input, err := graphql.ConvertInput(ctx, advanceInput)
+	if err != nil {
+		return nil, err
+	}
+	return Record{
+		{"index", input.Index},
+		{"id", input.ID},
+		{"status", input.Status.String()},
+		{"msgSender", input.MsgSender},
+		{"payload", input.Payload},
+		{"blockNumber", input.BlockNumber},
+		{"blockTimestamp", input.BlockTimestamp},
+		{"inputBoxIndex", input.InputBoxIndex},
+		{"prevRandao", input.PrevRandao},
+		{"espressoBlockNumber", input.EspressoBlockNumber},
+		{"espressoTimestamp", input.EspressoTimestamp},
+	}, nil
+}
+
+// VoucherRecord describes a voucher or a delegate call voucher.
+func VoucherRecord(cVoucher cModel.ConvenienceVoucher) Record {
+	voucher := graphql.ConvertConvenientVoucherV1(cVoucher)
+	return Record{
+		{"index", voucher.Index},
+		{"inputIndex", voucher.InputIndex},
+		{"destination", voucher.Destination},
+		{"value", voucher.Value},
+		{"payload", voucher.Payload},
+		{"delegateCall", cVoucher.IsDelegatedCall},
+		{"executed", voucher.Executed},
+		{"transactionHash", voucher.TransactionHash},
+		{"proofOutputIndex", voucher.Proof.OutputIndex},
+		{"outputHashesSiblings", voucher.Proof.OutputHashesSiblings},
+	}
+}
+
+func NoticeRecord(cNotice cModel.ConvenienceNotice) Record {
+	notice := graphql.ConvertConvenientNoticeV1(cNotice)
+	return Record{
+		{"index", notice.Index},
+		{"inputIndex", notice.InputIndex},
+		{"payload", notice.Payload},
+		{"proofOutputIndex", notice.Proof.OutputIndex},
+		{"outputHashesSiblings", notice.Proof.OutputHashesSiblings},
+	}
+}
+
+func ReportRecord(report cModel.Report) Record {
+	return Record{
+		{"index", report.Index},
+		{"inputIndex", report.InputIndex},
+		{"payload", report.Payload},
+	}
+}
+
+type jsonlWriter struct {
+	w io.Writer
+}
+
+func (j *jsonlWriter) write(record Record) error {
+	line, err := record.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, err = j.w.Write(append(line, '\n'))
+	return err
+}
+
+func (j *jsonlWriter) flush() error {
+	return nil
+}
+
+// csvWriter writes the field names as the header, lists are written as JSON.
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvWriter) write(record Record) error {
+	if !c.header {
+		names := make([]string, len(record))
+		for i, f := range record {
+			names[i] = f.Name
+		}
+		err := c.w.Write(names)
+		if err != nil {
+			return err
+		}
+		c.header = true
+	}
+	values := make([]string, len(record))
+	for i, f := range record {
+		switch value := f.Value.(type) {
+		case string:
+			values[i] = value
+		case int:
+			values[i] = strconv.Itoa(value)
+		case bool:
+			values[i] = strconv.FormatBool(value)
+		default:
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			values[i] = string(encoded)
+		}
+	}
+	return c.w.Write(values)
+}
+
+func (c *csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
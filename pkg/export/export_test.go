@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	exportApp = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+	otherApp  = "0x75135d8ADb7180640d29d822D9AD59E83E8695b2"
+)
+
+type ExportSuite struct {
+	suite.Suite
+	ctx      context.Context
+	tempDir  string
+	db       *sqlx.DB
+	exporter *Exporter
+}
+
+func (s *ExportSuite) SetupTest() {
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.db = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "export.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &repository.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	outputRepository := repository.OutputRepository{Db: s.db}
+	voucherRepository := &repository.VoucherRepository{Db: s.db, OutputRepository: outputRepository}
+	noticeRepository := &repository.NoticeRepository{Db: s.db, OutputRepository: outputRepository}
+	reportRepository := &repository.ReportRepository{Db: s.db}
+	for _, app := range []string{exportApp, otherApp} {
+		for i := range 3 {
+			_, err := inputRepository.Create(s.ctx, model.AdvanceInput{
+				ID:             fmt.Sprintf("%d", i),
+				Index:          i,
+				Status:         model.CompletionStatusAccepted,
+				MsgSender:      common.HexToAddress(otherApp),
+				Payload:        "0x1122",
+				BlockNumber:    uint64(i + 1),
+				BlockTimestamp: time.UnixMilli(1700000000000),
+				AppContract:    common.HexToAddress(app),
+			})
+			s.Require().NoError(err)
+			_, err = voucherRepository.CreateVoucher(s.ctx, &model.ConvenienceVoucher{
+				Destination: common.HexToAddress(otherApp),
+				Payload:     "0x3344",
+				Value:       "0x01",
+				InputIndex:  uint64(i),
+				OutputIndex: uint64(2 * i),
+				AppContract: common.HexToAddress(app),
+			})
+			s.Require().NoError(err)
+			_, err = noticeRepository.Create(s.ctx, &model.ConvenienceNotice{
+				AppContract: common.HexToAddress(app).Hex(),
+				Payload:     "0x5566",
+				InputIndex:  uint64(i),
+				OutputIndex: uint64(2*i + 1),
+			})
+			s.Require().NoError(err)
+			_, err = reportRepository.CreateReport(s.ctx, model.Report{
+				Index:       i,
+				InputIndex:  i,
+				Payload:     "0x7788",
+				AppContract: common.HexToAddress(app),
+			})
+			s.Require().NoError(err)
+		}
+	}
+	s.exporter = NewExporter(&repository.ExportRepository{Db: s.db})
+}
+
+func (s *ExportSuite) TearDownTest() {
+	s.db.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
+
+func (s *ExportSuite) export(entity string, format string, exportRange repository.ExportRange) (string, uint64) {
+	var out bytes.Buffer
+	total, err := s.exporter.Export(s.ctx, &out, exportRange, entity, format)
+	s.Require().NoError(err)
+	return out.String(), total
+}
+
+func (s *ExportSuite) TestExportInputsJsonl() {
+	out, total := s.export(ENTITY_INPUTS, FORMAT_JSONL, repository.ExportRange{AppContract: exportApp})
+	s.Equal(uint64(3), total)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	s.Require().Len(lines, 3)
+	s.True(strings.HasPrefix(lines[0], `{"index":0,"id":"0","status":"ACCEPTED"`))
+	var input map[string]any
+	s.Require().NoError(json.Unmarshal([]byte(lines[2]), &input))
+	s.Equal(float64(2), input["index"])
+	s.Equal("0x1122", input["payload"])
+	s.Equal(otherApp, input["msgSender"])
+	s.Equal("3", input["blockNumber"])
+}
+
+func (s *ExportSuite) TestExportVouchersCsv() {
+	to := uint64(1)
+	out, total := s.export(ENTITY_VOUCHERS, FORMAT_CSV, repository.ExportRange{
+		AppContract: exportApp, FromInput: 1, ToInput: &to,
+	})
+	s.Equal(uint64(1), total)
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 2)
+	s.Equal([]string{
+		"index", "inputIndex", "destination", "value", "payload", "delegateCall",
+		"executed", "transactionHash", "proofOutputIndex", "outputHashesSiblings",
+	}, records[0])
+	s.Equal("2", records[1][0])
+	s.Equal("1", records[1][1])
+	s.Equal("0x3344", records[1][4])
+	s.Equal("false", records[1][6])
+}
+
+func (s *ExportSuite) TestExportPages() {
+	for i := 3; i < int(PAGE_SIZE)+3; i++ {
+		_, err := s.db.Exec(`INSERT INTO convenience_reports (input_index, output_index, app_contract, payload)
+			VALUES ($1, $1, $2, '0x7788')`, i, exportApp)
+		s.Require().NoError(err)
+	}
+	out, total := s.export(ENTITY_REPORTS, FORMAT_JSONL, repository.ExportRange{AppContract: exportApp})
+	s.Equal(PAGE_SIZE+3, total)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	s.Len(lines, int(PAGE_SIZE)+3)
+	s.Equal(fmt.Sprintf(`{"index":%d,"inputIndex":%d,"payload":"0x7788"}`, PAGE_SIZE+2, PAGE_SIZE+2), lines[len(lines)-1])
+}
+
+func (s *ExportSuite) TestExportNoRows() {
+	out, total := s.export(ENTITY_NOTICES, FORMAT_CSV, repository.ExportRange{AppContract: exportApp, FromInput: 3})
+	s.Equal(uint64(0), total)
+	s.Empty(out)
+}
+
+func (s *ExportSuite) TestExportUnsupported() {
+	var out bytes.Buffer
+	_, err := s.exporter.Export(s.ctx, &out, repository.ExportRange{AppContract: exportApp}, "proofs", FORMAT_JSONL)
+	s.ErrorContains(err, "unsupported entity")
+	_, err = s.exporter.Export(s.ctx, &out, repository.ExportRange{AppContract: exportApp}, ENTITY_INPUTS, "xml")
+	s.ErrorContains(err, "unsupported format")
+}
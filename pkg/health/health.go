@@ -1,14 +1,85 @@
 package health
 
 import (
+	"context"
 	"net/http"
+	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 )
 
+// Timeout of each check in the status endpoint
+const CheckTimeout = 2 * time.Second
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+	StatusFailing  = "failing"
+)
+
+// Check reports whether a dependency, like a database connection, is available.
+type Check struct {
+	Name string
+	// The API cannot serve without a critical dependency
+	Critical bool
+	Ping     func(ctx context.Context) error
+}
+
+// DatabaseCheck pings the database connection pool.
+func DatabaseCheck(name string, db *sqlx.DB, critical bool) Check {
+	return Check{Name: name, Critical: critical, Ping: db.PingContext}
+}
+
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+}
+
+type StatusResult struct {
+	Status string        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
 // Register the health API to echo
-func Register(e *echo.Echo) {
+func Register(e *echo.Echo, checks ...Check) {
 	e.GET("/health", func(c echo.Context) error {
 		return c.String(http.StatusOK, "Ok")
 	})
+	e.GET("/health/status", func(c echo.Context) error {
+		result := Status(c.Request().Context(), checks)
+		code := http.StatusOK
+		if result.Status == StatusFailing {
+			code = http.StatusServiceUnavailable
+		}
+		return c.JSON(code, result)
+	})
+}
+
+// Status runs the checks. The status is degraded when a non-critical check is down,
+// for instance the node database while the API serves the synced data,
+// and failing when a critical one is down.
+func Status(ctx context.Context, checks []Check) StatusResult {
+	result := StatusResult{Status: StatusOk, Checks: []CheckResult{}}
+	for _, check := range checks {
+		checkResult := CheckResult{Name: check.Name, Status: StatusUp, Critical: check.Critical}
+		checkCtx, cancel := context.WithTimeout(ctx, CheckTimeout)
+		err := check.Ping(checkCtx)
+		cancel()
+		if err != nil {
+			checkResult.Status = StatusDown
+			checkResult.Error = err.Error()
+			if check.Critical {
+				result.Status = StatusFailing
+			} else if result.Status == StatusOk {
+				result.Status = StatusDegraded
+			}
+		}
+		result.Checks = append(result.Checks, checkResult)
+	}
+	return result
 }
@@ -0,0 +1,49 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthSuite struct {
+	suite.Suite
+}
+
+func TestHealthSuite(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
+
+func up(ctx context.Context) error {
+	return nil
+}
+
+func down(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func (s *HealthSuite) TestStatusOk() {
+	result := Status(context.Background(), []Check{{Name: "graphql_database", Critical: true, Ping: up}})
+	s.Equal(StatusOk, result.Status)
+	s.Equal(StatusUp, result.Checks[0].Status)
+}
+
+func (s *HealthSuite) TestNodeDatabaseDownIsDegraded() {
+	result := Status(context.Background(), []Check{
+		{Name: "graphql_database", Critical: true, Ping: up},
+		{Name: "node_database", Ping: down},
+	})
+	s.Equal(StatusDegraded, result.Status)
+	s.Equal(StatusDown, result.Checks[1].Status)
+	s.Equal("connection refused", result.Checks[1].Error)
+}
+
+func (s *HealthSuite) TestCriticalDownIsFailing() {
+	result := Status(context.Background(), []Check{
+		{Name: "graphql_database", Critical: true, Ping: down},
+		{Name: "node_database", Ping: down},
+	})
+	s.Equal(StatusFailing, result.Status)
+}
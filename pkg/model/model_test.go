@@ -16,6 +16,7 @@ import (
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jmoiron/sqlx"
@@ -56,6 +57,7 @@ func (s *ModelSuite) SetupTest() {
 	sqliteFileName := fmt.Sprintf("test%d.sqlite3", time.Now().UnixMilli())
 	sqliteFileName = path.Join(tempDir, sqliteFileName)
 	s.db = sqlx.MustConnect("sqlite3", sqliteFileName)
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	container := convenience.NewContainer(s.db, false)
 	decoder := container.GetOutputDecoder(s.ctx)
 	s.reportRepository = container.GetReportRepository(s.ctx)
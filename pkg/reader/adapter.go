@@ -17,9 +17,9 @@ type Adapter interface {
 		first *int, last *int, after *string, before *string, inputIndex *int,
 	) (*graphql.ReportConnection, error)
 
-	GetAllReportsByInputIndex(
+	GetReportsByInputIndex(
 		ctx context.Context,
-		inputIndex *int,
+		inputIndex int, first *int, after *string,
 	) (*graphql.ReportConnection, error)
 
 	GetInputs(
@@ -66,19 +66,19 @@ type Adapter interface {
 		filter []*graphql.ConvenientFilter,
 	) (*graphql.DelegateCallVoucherConnection, error)
 
-	GetAllVouchersByInputIndex(
+	GetVouchersByInputIndex(
 		ctx context.Context,
-		inputIndex *int,
+		inputIndex int, first *int, after *string,
 	) (*graphql.VoucherConnection, error)
 
-	GetAllDelegateCallVouchersByInputIndex(
+	GetDelegateCallVouchersByInputIndex(
 		ctx context.Context,
-		inputIndex *int,
+		inputIndex int, first *int, after *string,
 	) (*graphql.DelegateCallVoucherConnection, error)
 
-	GetAllNoticesByInputIndex(
+	GetNoticesByInputIndex(
 		ctx context.Context,
-		inputIndex *int,
+		inputIndex int, first *int, after *string,
 	) (*graphql.Connection[*graphql.Notice], error)
 
 	GetApplications(
@@ -60,12 +60,17 @@ func (a AdapterV1) GetApplicationByAppContract(ctx context.Context, inputBoxInde
 		}
 		if input == nil {
 			slog.DebugContext(ctx, "input not found", "inputBoxIndex", inputBoxIndex)
-			return nil, fmt.Errorf("input from inputBoxIndex not found")
+			return nil, fmt.Errorf("input from inputBoxIndex %w", cRepos.ErrNotFound)
 		}
 		address = &input.AppContract
 	}
 
-	app, err := a.convenienceService.FindAppByAppContract(ctx, address)
+	var app *cModel.ConvenienceApplication
+	if loaders := loaders.For(ctx); loaders != nil {
+		app, err = loaders.ApplicationLoader.Load(ctx, address.Hex())
+	} else {
+		app, err = a.convenienceService.FindAppByAppContract(ctx, address)
+	}
 
 	if err != nil {
 		return nil, err
@@ -184,32 +189,31 @@ func (a AdapterV1) GetVoucher(ctx context.Context, outputIndex int) (*graphql.Vo
 		return nil, err
 	}
 	if voucher == nil {
-		return nil, fmt.Errorf("voucher not found")
+		return nil, fmt.Errorf("voucher %w", cRepos.ErrNotFound)
 	}
 	return graphql.ConvertConvenientVoucherV1(*voucher), nil
 }
 
-// GetAllDelegateCallVouchersByInputIndex implements Adapter.
-func (a AdapterV1) GetAllDelegateCallVouchersByInputIndex(ctx context.Context, inputIndex *int) (*graphql.DelegateCallVoucherConnection, error) {
-	loaders := loaders.For(ctx)
-	if loaders == nil {
-		return a.GetDelegateCallVouchers(ctx, nil, nil, nil, nil, inputIndex, nil)
-	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
-		key := cRepos.GenerateBatchVoucherKey(appContract, *inputIndex)
-		vouchers, err := loaders.VoucherLoader.Load(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-		return graphql.ConvertToDelegateCallVoucherConnectionV1(
-			vouchers.Rows,
-			int(vouchers.Offset),
-			int(vouchers.Total),
-		)
+// GetDelegateCallVouchersByInputIndex implements Adapter.
+func (a AdapterV1) GetDelegateCallVouchersByInputIndex(ctx context.Context, inputIndex int, first *int, after *string) (*graphql.DelegateCallVoucherConnection, error) {
+	dataLoaders := loaders.For(ctx)
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dataLoaders == nil || appContract == nil {
+		return a.GetDelegateCallVouchers(ctx, first, nil, after, nil, &inputIndex, nil)
+	}
+	key := cRepos.GenerateBatchVoucherKey(appContract, inputIndex)
+	vouchers, err := loaders.LoadPage(ctx, dataLoaders.DelegateCallVoucherLoader, key, first, after)
+	if err != nil {
+		return nil, err
 	}
+	return graphql.ConvertToDelegateCallVoucherConnectionV1(
+		vouchers.Rows,
+		int(vouchers.Offset),
+		int(vouchers.Total),
+	)
 }
 
 // GetDelegateCallVoucher implements Adapter.
@@ -224,7 +228,7 @@ func (a AdapterV1) GetDelegateCallVoucher(ctx context.Context, outputIndex int)
 		return nil, err
 	}
 	if voucher == nil {
-		return nil, fmt.Errorf("delegated call voucher not found")
+		return nil, fmt.Errorf("delegated call voucher %w", cRepos.ErrNotFound)
 	}
 	return graphql.ConvertConvenientDelegateCallVoucherV1(*voucher), nil
 }
@@ -337,48 +341,46 @@ func (a AdapterV1) GetVouchers(
 	)
 }
 
-func (a AdapterV1) GetAllNoticesByInputIndex(ctx context.Context, inputIndex *int) (*graphql.Connection[*graphql.Notice], error) {
-	loaders := loaders.For(ctx)
-	if loaders == nil {
-		return a.GetNotices(ctx, nil, nil, nil, nil, inputIndex)
-	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
-		key := cRepos.GenerateBatchNoticeKey(appContract.Hex(), uint64(*inputIndex))
-		notices, err := loaders.NoticeLoader.Load(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-		return graphql.ConvertToNoticeConnectionV1(
-			notices.Rows,
-			int(notices.Offset),
-			int(notices.Total),
-		)
+func (a AdapterV1) GetNoticesByInputIndex(ctx context.Context, inputIndex int, first *int, after *string) (*graphql.Connection[*graphql.Notice], error) {
+	dataLoaders := loaders.For(ctx)
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dataLoaders == nil || appContract == nil {
+		return a.GetNotices(ctx, first, nil, after, nil, &inputIndex)
+	}
+	key := cRepos.GenerateBatchNoticeKey(appContract.Hex(), uint64(inputIndex))
+	notices, err := loaders.LoadPage(ctx, dataLoaders.NoticeLoader, key, first, after)
+	if err != nil {
+		return nil, err
 	}
+	return graphql.ConvertToNoticeConnectionV1(
+		notices.Rows,
+		int(notices.Offset),
+		int(notices.Total),
+	)
 }
 
-func (a AdapterV1) GetAllVouchersByInputIndex(ctx context.Context, inputIndex *int) (*graphql.Connection[*graphql.Voucher], error) {
-	loaders := loaders.For(ctx)
-	if loaders == nil {
-		return a.GetVouchers(ctx, nil, nil, nil, nil, inputIndex, nil)
-	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
-		key := cRepos.GenerateBatchVoucherKey(appContract, *inputIndex)
-		vouchers, err := loaders.VoucherLoader.Load(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-		return graphql.ConvertToVoucherConnectionV1(
-			vouchers.Rows,
-			int(vouchers.Offset),
-			int(vouchers.Total),
-		)
+func (a AdapterV1) GetVouchersByInputIndex(ctx context.Context, inputIndex int, first *int, after *string) (*graphql.Connection[*graphql.Voucher], error) {
+	dataLoaders := loaders.For(ctx)
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dataLoaders == nil || appContract == nil {
+		return a.GetVouchers(ctx, first, nil, after, nil, &inputIndex, nil)
+	}
+	key := cRepos.GenerateBatchVoucherKey(appContract, inputIndex)
+	vouchers, err := loaders.LoadPage(ctx, dataLoaders.VoucherLoader, key, first, after)
+	if err != nil {
+		return nil, err
 	}
+	return graphql.ConvertToVoucherConnectionV1(
+		vouchers.Rows,
+		int(vouchers.Offset),
+		int(vouchers.Total),
+	)
 }
 
 func (a AdapterV1) GetNotice(ctx context.Context, outputIndex int) (*graphql.Notice, error) {
@@ -395,7 +397,7 @@ func (a AdapterV1) GetNotice(ctx context.Context, outputIndex int) (*graphql.Not
 		return nil, err
 	}
 	if notice == nil {
-		return nil, fmt.Errorf("notice not found")
+		return nil, fmt.Errorf("notice %w", cRepos.ErrNotFound)
 	}
 	return graphql.ConvertConvenientNoticeV1(*notice), nil
 }
@@ -417,7 +419,7 @@ func (a AdapterV1) GetReport(
 		return nil, err
 	}
 	if report == nil {
-		return nil, fmt.Errorf("report not found")
+		return nil, fmt.Errorf("report %w", cRepos.ErrNotFound)
 	}
 	return a.convertToReport(*report), nil
 }
@@ -457,26 +459,25 @@ func (a AdapterV1) GetReports(
 	)
 }
 
-func (a AdapterV1) GetAllReportsByInputIndex(ctx context.Context, inputIndex *int) (*graphql.Connection[*graphql.Report], error) {
-	loaders := loaders.For(ctx)
-	if loaders == nil {
-		return a.GetReports(ctx, nil, nil, nil, nil, inputIndex)
-	} else {
-		appContract, err := getAppContractFromContext(ctx)
-		if err != nil {
-			return nil, err
-		}
-		key := cRepos.GenerateBatchReportKey(appContract, *inputIndex)
-		reports, err := loaders.ReportLoader.Load(ctx, key)
-		if err != nil {
-			return nil, err
-		}
-		return a.convertToReportConnection(
-			reports.Rows,
-			int(reports.Offset),
-			int(reports.Total),
-		)
+func (a AdapterV1) GetReportsByInputIndex(ctx context.Context, inputIndex int, first *int, after *string) (*graphql.Connection[*graphql.Report], error) {
+	dataLoaders := loaders.For(ctx)
+	appContract, err := getAppContractFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dataLoaders == nil || appContract == nil {
+		return a.GetReports(ctx, first, nil, after, nil, &inputIndex)
+	}
+	key := cRepos.GenerateBatchReportKey(appContract, inputIndex)
+	reports, err := loaders.LoadPage(ctx, dataLoaders.ReportLoader, key, first, after)
+	if err != nil {
+		return nil, err
 	}
+	return a.convertToReportConnection(
+		reports.Rows,
+		int(reports.Offset),
+		int(reports.Total),
+	)
 }
 
 func (a AdapterV1) convertToReportConnection(
@@ -494,9 +495,10 @@ func (a AdapterV1) convertToReport(
 	report cModel.Report,
 ) *graphql.Report {
 	return &graphql.Report{
-		Index:      report.Index,
-		InputIndex: report.InputIndex,
-		Payload:    report.Payload,
+		Index:       report.Index,
+		InputIndex:  report.InputIndex,
+		Payload:     report.Payload,
+		AppContract: report.AppContract.Hex(),
 	}
 }
 
@@ -510,7 +512,7 @@ func (a AdapterV1) GetInputByIndex(
 		return nil, err
 	}
 	loaders := loaders.For(ctx)
-	if loaders != nil {
+	if loaders != nil && appContract != nil {
 		key := cRepos.GenerateBatchInputKey(appContract.Hex(), uint64(inputIndex))
 		input, err := loaders.InputLoader.Load(ctx, key)
 		if err != nil {
@@ -528,7 +530,7 @@ func (a AdapterV1) GetInputByIndex(
 
 func getConvertedInputFromGraphql(ctx context.Context, input *cModel.AdvanceInput) (*graphql.Input, error) {
 	if input == nil {
-		return nil, fmt.Errorf("input not found")
+		return nil, fmt.Errorf("input %w", cRepos.ErrNotFound)
 	}
 	convertedInput, err := graphql.ConvertInput(ctx, *input)
 
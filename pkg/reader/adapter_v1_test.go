@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
@@ -46,6 +47,7 @@ func (s *AdapterSuite) SetupTest() {
 	s.dbFactory, err = commons.NewDbFactory()
 	s.Require().NoError(err)
 	s.db = s.dbFactory.CreateDb(s.ctx, "adapterV1.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.reportRepository = &cRepos.ReportRepository{
 		Db: s.db,
 	}
@@ -200,6 +202,7 @@ func (s *AdapterSuite) TestGetVoucherFilteredByAppContract() {
 	ctx2 := context.WithValue(ctx, cModel.AppContractKey, appContract2.Hex())
 	res2, err := s.adapter.GetVoucher(ctx2, 1)
 	s.ErrorContains(err, "voucher not found")
+	s.ErrorIs(err, cRepos.ErrNotFound)
 	s.Nil(res2) // returns nothing
 
 	// with correct address
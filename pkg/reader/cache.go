@@ -0,0 +1,223 @@
+package reader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const (
+	DefaultResponseCacheTTL = time.Minute
+
+	cacheHeader = "X-Cache"
+	// The root endpoint reads every application, so any change invalidates it
+	rootCacheApp = ""
+)
+
+// CacheStats counts the lookups of the response cache.
+type CacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+	Entries       int    `json:"entries"`
+}
+
+type cachedResponse struct {
+	generation uint64
+	body       []byte
+}
+
+// ResponseCache keeps the successful GraphQL responses by normalized query, variables
+// and application. Each application has a generation, bumped when the synchronizer
+// writes its data, and a response is only served while its generation is current.
+// The TTL bounds the staleness after writes done outside the synchronizer.
+type ResponseCache struct {
+	// Delay after which the changes are invalidated again, dropping the responses
+	// read from a replica that had not replayed them yet, zero disables it
+	ReplayDelay time.Duration
+
+	entries *lru.LRU[string, cachedResponse]
+
+	mu          sync.Mutex
+	generations map[string]uint64
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func NewResponseCache(size int, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		entries:     lru.NewLRU[string, cachedResponse](size, nil, ttl),
+		generations: make(map[string]uint64),
+	}
+}
+
+// AppsChanged invalidates the responses of the applications and of the root endpoint,
+// and again after the replay delay, if any.
+func (c *ResponseCache) AppsChanged(ctx context.Context, apps []common.Address) {
+	c.invalidate(ctx, apps)
+	if c.ReplayDelay > 0 {
+		ctx := context.WithoutCancel(ctx)
+		time.AfterFunc(c.ReplayDelay, func() {
+			c.invalidate(ctx, apps)
+		})
+	}
+}
+
+func (c *ResponseCache) invalidate(ctx context.Context, apps []common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, app := range apps {
+		c.generations[app.Hex()]++
+	}
+	c.generations[rootCacheApp]++
+	c.invalidations.Add(1)
+	slog.DebugContext(ctx, "graphql cache: invalidated", "apps", len(apps))
+}
+
+func (c *ResponseCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+		Entries:       c.entries.Len(),
+	}
+}
+
+func (c *ResponseCache) generation(app string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[app]
+}
+
+func (c *ResponseCache) get(key string, app string) ([]byte, bool) {
+	entry, ok := c.entries.Get(key)
+	if ok && entry.generation == c.generation(app) {
+		c.hits.Add(1)
+		return entry.body, true
+	}
+	c.misses.Add(1)
+	return nil, false
+}
+
+// Handler serves the cached responses of the GraphQL POST requests of the
+// application, or of the root endpoint when app is empty, in front of next.
+func (c *ResponseCache) Handler(app string, next http.Handler) http.Handler {
+	if app != rootCacheApp && common.IsHexAddress(app) {
+		app = common.HexToAddress(app).Hex()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, ok := cacheKey(r, app)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if body, ok := c.get(key, app); ok {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set(cacheHeader, "HIT")
+			_, _ = w.Write(body)
+			return
+		}
+		// a change during the request may not be visible in its response
+		generation := c.generation(app)
+		w.Header().Set(cacheHeader, "MISS")
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		if recorder.status == http.StatusOK && !hasErrors(recorder.body.Bytes()) {
+			c.entries.Add(key, cachedResponse{generation, recorder.body.Bytes()})
+		}
+	})
+}
+
+// cacheKey hashes the normalized request, restoring its body for the handler.
+// The requests that are not plain JSON queries bypass the cache.
+func cacheKey(r *http.Request, app string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Method != http.MethodPost || err != nil || mediaType != "application/json" {
+		return "", false
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var params graphql.RawParams
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		return "", false
+	}
+	query, ok := normalizeQuery(&params)
+	if !ok {
+		return "", false
+	}
+	// the map keys are marshaled in order
+	variables, err := json.Marshal(params.Variables)
+	if err != nil {
+		return "", false
+	}
+	hash := sha256.New()
+	for _, part := range []string{app, params.OperationName, query, string(variables)} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), true
+}
+
+// normalizeQuery formats the query so that whitespace and comments do not matter.
+// A persisted query sent without its text is identified by its hash.
+func normalizeQuery(params *graphql.RawParams) (string, bool) {
+	if params.Query == "" {
+		hash := persistedQueryHash(params)
+		return "persisted:" + hash, hash != ""
+	}
+	doc, err := parser.ParseQuery(&ast.Source{Input: params.Query})
+	if err != nil {
+		return "", false
+	}
+	var normalized strings.Builder
+	formatter.NewFormatter(&normalized).FormatQueryDocument(doc)
+	return normalized.String(), true
+}
+
+func hasErrors(body []byte) bool {
+	var response struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(body, &response) != nil || len(response.Errors) > 0
+}
+
+// responseRecorder keeps a copy of the response written to the client.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
@@ -0,0 +1,172 @@
+package reader
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheSuite struct {
+	suite.Suite
+	ctx              context.Context
+	db               *sqlx.DB
+	dbFactory        *commons.DbFactory
+	reportRepository *cRepos.ReportRepository
+	cache            *ResponseCache
+	echo             *echo.Echo
+	reports          int
+}
+
+const reportsCountQuery = `{"query": "query { reports { totalCount } }"}`
+
+func (s *CacheSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "cache.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	s.reportRepository = &cRepos.ReportRepository{Db: s.db}
+	s.reports = 0
+	s.createReport()
+	service := services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		inputRepository,
+		s.reportRepository,
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+	s.cache = NewResponseCache(10, 0)
+	opts := NewGraphQLOpts()
+	opts.Cache = s.cache
+	s.echo = echo.New()
+	Register(s.ctx, s.echo, service, NewAdapterV1(s.ctx, s.db, service), opts)
+}
+
+func (s *CacheSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+func (s *CacheSuite) createReport() {
+	_, err := s.reportRepository.CreateReport(s.ctx, cModel.Report{
+		Index:       s.reports,
+		InputIndex:  0,
+		Payload:     "0x3344",
+		AppContract: common.HexToAddress(ApplicationAddress),
+	})
+	s.Require().NoError(err)
+	s.reports++
+}
+
+func (s *CacheSuite) post(target string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *CacheSuite) TestHitIgnoresFormatting() {
+	rec := s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+	s.Contains(rec.Body.String(), `"totalCount":1`)
+
+	// the same query, differently formatted and with the address in lower case
+	rec = s.post("/graphql/"+strings.ToLower(ApplicationAddress),
+		`{"query": "query {\n  reports {\n    totalCount\n  }\n}"}`)
+	s.Equal("HIT", rec.Header().Get(cacheHeader))
+	s.Contains(rec.Body.String(), `"totalCount":1`)
+
+	// the root endpoint has its own entries
+	rec = s.post("/graphql", reportsCountQuery)
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+
+	s.Equal(CacheStats{Hits: 1, Misses: 2, Entries: 2}, s.cache.Stats())
+}
+
+func (s *CacheSuite) TestVariablesAreInTheKey() {
+	query := `{"query": "query($n: Int) { reports(first: $n) { totalCount } }", "variables": {"n": %s}}`
+	rec := s.post("/graphql/"+ApplicationAddress, strings.Replace(query, "%s", "1", 1))
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+	rec = s.post("/graphql/"+ApplicationAddress, strings.Replace(query, "%s", "2", 1))
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+	rec = s.post("/graphql/"+ApplicationAddress, strings.Replace(query, "%s", "1", 1))
+	s.Equal("HIT", rec.Header().Get(cacheHeader))
+}
+
+func (s *CacheSuite) TestAppsChangedInvalidates() {
+	s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.post("/graphql", reportsCountQuery)
+	s.createReport()
+
+	// the write is not visible until the synchronizer reports it
+	rec := s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("HIT", rec.Header().Get(cacheHeader))
+	s.Contains(rec.Body.String(), `"totalCount":1`)
+
+	s.cache.AppsChanged(s.ctx, []common.Address{common.HexToAddress(ApplicationAddress)})
+	rec = s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+	s.Contains(rec.Body.String(), `"totalCount":2`)
+	rec = s.post("/graphql", reportsCountQuery)
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+	s.Contains(rec.Body.String(), `"totalCount":2`)
+	s.Equal(uint64(1), s.cache.Stats().Invalidations)
+}
+
+func (s *CacheSuite) TestAppsChangedKeepsOtherApps() {
+	s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.cache.AppsChanged(s.ctx, []common.Address{common.HexToAddress("0x01")})
+	rec := s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("HIT", rec.Header().Get(cacheHeader))
+}
+
+func (s *CacheSuite) TestReplayDelayInvalidatesAgain() {
+	s.cache.ReplayDelay = 50 * time.Millisecond
+	app := []common.Address{common.HexToAddress(ApplicationAddress)}
+	s.cache.AppsChanged(s.ctx, app)
+	// a response read before the replica replays the change is cached
+	rec := s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+	rec = s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("HIT", rec.Header().Get(cacheHeader))
+
+	// and dropped once it is replayed
+	s.Eventually(func() bool {
+		return s.cache.Stats().Invalidations == 2
+	}, time.Second, 10*time.Millisecond)
+	rec = s.post("/graphql/"+ApplicationAddress, reportsCountQuery)
+	s.Equal("MISS", rec.Header().Get(cacheHeader))
+}
+
+func (s *CacheSuite) TestErrorsAreNotCached() {
+	body := `{"query": "query { reports { unknown } }"}`
+	for range 2 {
+		rec := s.post("/graphql/"+ApplicationAddress, body)
+		s.Equal("MISS", rec.Header().Get(cacheHeader))
+		s.Contains(rec.Body.String(), `"errors"`)
+	}
+	s.Zero(s.cache.Stats().Entries)
+}
@@ -0,0 +1,650 @@
+package reader
+
+//go:generate protoc -I ../../api --go_out=readerpb --go_opt=paths=source_relative --go-grpc_out=readerpb --go-grpc_opt=paths=source_relative reader.proto
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	graphql "github.com/cartesi/rollups-graphql/v2/pkg/reader/model"
+	pb "github.com/cartesi/rollups-graphql/v2/pkg/reader/readerpb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Interval between two reads of the new outputs of a watch
+	DefaultWatchInterval = time.Second
+
+	// Outputs of each kind read at once by a watch
+	WATCH_PAGE_SIZE = 100
+)
+
+// GrpcServer implements the gRPC reader API on top of the same adapter as GraphQL.
+type GrpcServer struct {
+	pb.UnimplementedReaderServer
+	adapter Adapter
+	// database the watches read their snapshots from, nil reads without a snapshot
+	db            *sqlx.DB
+	WatchInterval time.Duration
+}
+
+func NewGrpcServer(adapter Adapter, db *sqlx.DB) *GrpcServer {
+	return &GrpcServer{adapter: adapter, db: db, WatchInterval: DefaultWatchInterval}
+}
+
+// RegisterGrpc registers the gRPC reader API to the server.
+func RegisterGrpc(server *grpc.Server, adapter Adapter, db *sqlx.DB) {
+	pb.RegisterReaderServer(server, NewGrpcServer(adapter, db))
+}
+
+// appContext scopes the context to the application of the request, as the
+// /graphql/:appContract endpoint does. An empty address leaves it unscoped.
+func appContext(ctx context.Context, appContract string) (context.Context, error) {
+	if appContract == "" {
+		return ctx, nil
+	}
+	if !common.IsHexAddress(appContract) {
+		return nil, status.Error(codes.InvalidArgument, "invalid app_contract")
+	}
+	return context.WithValue(ctx, cModel.AppContractKey, common.HexToAddress(appContract).Hex()), nil
+}
+
+// grpcError converts the errors of the adapter to gRPC status errors.
+func grpcError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, cRepos.ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func grpcResult[T any, R any](value T, err error, convert func(T) R) (R, error) {
+	if err != nil {
+		var zero R
+		return zero, grpcError(err)
+	}
+	return convert(value), nil
+}
+
+func optionalInt[T int32 | int64](value *T) *int {
+	if value == nil {
+		return nil
+	}
+	converted := int(*value)
+	return &converted
+}
+
+func pagination(p *pb.Pagination) (first *int, last *int, after *string, before *string) {
+	if p == nil {
+		return nil, nil, nil, nil
+	}
+	return optionalInt(p.First), optionalInt(p.Last), p.After, p.Before
+}
+
+func (s *GrpcServer) GetInput(ctx context.Context, req *pb.GetInputRequest) (*pb.Input, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	input, err := s.adapter.GetInput(ctx, req.Id)
+	return grpcResult(input, err, inputToProto)
+}
+
+func (s *GrpcServer) ListInputs(ctx context.Context, req *pb.ListInputsRequest) (*pb.InputConnection, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	first, last, after, before := pagination(req.Pagination)
+	var where *graphql.InputFilter
+	if req.Where != nil {
+		where = &graphql.InputFilter{
+			IndexLowerThan:   optionalInt(req.Where.IndexLowerThan),
+			IndexGreaterThan: optionalInt(req.Where.IndexGreaterThan),
+			MsgSender:        req.Where.MsgSender,
+			Type:             req.Where.Type,
+		}
+	}
+	conn, err := s.adapter.GetInputs(ctx, first, last, after, before, where)
+	return grpcResult(conn, err, func(conn *graphql.InputConnection) *pb.InputConnection {
+		edges := make([]*pb.InputEdge, len(conn.Edges))
+		for i, edge := range conn.Edges {
+			edges[i] = &pb.InputEdge{Node: inputToProto(edge.Node), Cursor: edge.Cursor()}
+		}
+		return &pb.InputConnection{
+			TotalCount: int64(conn.TotalCount), Edges: edges, PageInfo: pageInfoToProto(conn.PageInfo),
+		}
+	})
+}
+
+func (s *GrpcServer) GetVoucher(ctx context.Context, req *pb.GetOutputRequest) (*pb.Voucher, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := s.adapter.GetVoucher(ctx, int(req.OutputIndex))
+	return grpcResult(voucher, err, voucherToProto)
+}
+
+func (s *GrpcServer) ListVouchers(ctx context.Context, req *pb.ListVouchersRequest) (*pb.VoucherConnection, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	first, last, after, before := pagination(req.Pagination)
+	conn, err := s.adapter.GetVouchers(ctx, first, last, after, before,
+		optionalInt(req.InputIndex), filtersFromProto(req.Filter))
+	return grpcResult(conn, err, vouchersToProto)
+}
+
+func (s *GrpcServer) GetDelegateCallVoucher(
+	ctx context.Context, req *pb.GetOutputRequest,
+) (*pb.DelegateCallVoucher, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := s.adapter.GetDelegateCallVoucher(ctx, int(req.OutputIndex))
+	return grpcResult(voucher, err, delegateCallVoucherToProto)
+}
+
+func (s *GrpcServer) ListDelegateCallVouchers(
+	ctx context.Context, req *pb.ListVouchersRequest,
+) (*pb.DelegateCallVoucherConnection, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	first, last, after, before := pagination(req.Pagination)
+	conn, err := s.adapter.GetDelegateCallVouchers(ctx, first, last, after, before,
+		optionalInt(req.InputIndex), filtersFromProto(req.Filter))
+	return grpcResult(conn, err, delegateCallVouchersToProto)
+}
+
+func (s *GrpcServer) GetNotice(ctx context.Context, req *pb.GetOutputRequest) (*pb.Notice, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	notice, err := s.adapter.GetNotice(ctx, int(req.OutputIndex))
+	return grpcResult(notice, err, noticeToProto)
+}
+
+func (s *GrpcServer) ListNotices(ctx context.Context, req *pb.ListOutputsRequest) (*pb.NoticeConnection, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	first, last, after, before := pagination(req.Pagination)
+	conn, err := s.adapter.GetNotices(ctx, first, last, after, before, optionalInt(req.InputIndex))
+	return grpcResult(conn, err, noticesToProto)
+}
+
+func (s *GrpcServer) GetReport(ctx context.Context, req *pb.GetReportRequest) (*pb.Report, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.adapter.GetReport(ctx, int(req.ReportIndex))
+	return grpcResult(report, err, reportToProto)
+}
+
+func (s *GrpcServer) ListReports(ctx context.Context, req *pb.ListOutputsRequest) (*pb.ReportConnection, error) {
+	ctx, err := appContext(ctx, req.AppContract)
+	if err != nil {
+		return nil, err
+	}
+	first, last, after, before := pagination(req.Pagination)
+	conn, err := s.adapter.GetReports(ctx, first, last, after, before, optionalInt(req.InputIndex))
+	return grpcResult(conn, err, func(conn *graphql.ReportConnection) *pb.ReportConnection {
+		edges := make([]*pb.ReportEdge, len(conn.Edges))
+		for i, edge := range conn.Edges {
+			edges[i] = &pb.ReportEdge{Node: reportToProto(edge.Node), Cursor: edge.Cursor()}
+		}
+		return &pb.ReportConnection{
+			TotalCount: int64(conn.TotalCount), Edges: edges, PageInfo: pageInfoToProto(conn.PageInfo),
+		}
+	})
+}
+
+func (s *GrpcServer) ListApplications(
+	ctx context.Context, req *pb.ListApplicationsRequest,
+) (*pb.AppConnection, error) {
+	first, last, after, before := pagination(req.Pagination)
+	var filter *graphql.AppFilter
+	if req.Where != nil {
+		if req.Where.Address != nil && !common.IsHexAddress(*req.Where.Address) {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+		filter = &graphql.AppFilter{
+			IndexLowerThan:   optionalInt(req.Where.IndexLowerThan),
+			IndexGreaterThan: optionalInt(req.Where.IndexGreaterThan),
+			Name:             req.Where.Name,
+			Address:          req.Where.Address,
+		}
+	}
+	conn, err := s.adapter.GetApplications(ctx, first, last, after, before, filter)
+	return grpcResult(conn, err, func(conn *graphql.AppConnection) *pb.AppConnection {
+		edges := make([]*pb.AppEdge, len(conn.Edges))
+		for i, edge := range conn.Edges {
+			edges[i] = &pb.AppEdge{
+				Node: &pb.Application{
+					Id: edge.Node.ID, Name: edge.Node.Name, Address: edge.Node.Address,
+				},
+				Cursor: edge.Cursor(),
+			}
+		}
+		return &pb.AppConnection{
+			TotalCount: int64(conn.TotalCount), Edges: edges, PageInfo: pageInfoToProto(conn.PageInfo),
+		}
+	})
+}
+
+// WatchOutputs sends the outputs of the application already synced after the cursors
+// of the request and then polls the adapter for the new ones until the client leaves.
+// Vouchers, delegate call vouchers and notices share the output index, so the outputs
+// of every kind are merged and sent in output index order, except the ones synced late,
+// e.g. by the retry of a dead letter, which are sent once found.
+func (s *GrpcServer) WatchOutputs(req *pb.WatchOutputsRequest, stream grpc.ServerStreamingServer[pb.Output]) error {
+	if req.AppContract == "" {
+		return status.Error(codes.InvalidArgument, "missing app_contract")
+	}
+	ctx, err := appContext(stream.Context(), req.AppContract)
+	if err != nil {
+		return err
+	}
+	cursor := newWatchCursor(map[string]*string{
+		"voucher":               emptyAsNil(req.VoucherAfter),
+		"delegate_call_voucher": emptyAsNil(req.DelegateCallVoucherAfter),
+		"notice":                emptyAsNil(req.NoticeAfter),
+	})
+	for {
+		outputs, hasMore, err := s.readOutputs(ctx, cursor)
+		if err != nil {
+			return grpcError(err)
+		}
+		for _, output := range outputs {
+			if err := stream.Send(output); err != nil {
+				return err
+			}
+		}
+		if hasMore {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			slog.DebugContext(ctx, "grpc: watch finished", "appContract", req.AppContract)
+			return nil
+		case <-time.After(s.WatchInterval):
+		}
+	}
+}
+
+// watchCursor is where a watch is: the cursor of the adapter for each kind of output
+// and the outputs sent.
+type watchCursor struct {
+	kinds map[string]*string
+	// cursors of the request, a kind is read again from its own
+	// when an output is synced before the outputs already read
+	starts     map[string]*string
+	rescanning map[string]bool
+	sent       watchSent
+}
+
+func newWatchCursor(kinds map[string]*string) *watchCursor {
+	// a watch from the beginning expects every output from the first one,
+	// a resumed one from the first output it sends
+	resumed := false
+	for _, after := range kinds {
+		resumed = resumed || after != nil
+	}
+	return &watchCursor{
+		kinds:      kinds,
+		starts:     maps.Clone(kinds),
+		rescanning: map[string]bool{},
+		sent:       watchSent{started: !resumed, above: map[int64]bool{}},
+	}
+}
+
+// watchSent is the set of the output indexes sent by a watch. The outputs
+// below next are all sent, so only the ones sent after a gap are kept.
+type watchSent struct {
+	started bool
+	next    int64
+	above   map[int64]bool
+}
+
+func (s *watchSent) has(index int64) bool {
+	return s.started && index < s.next || s.above[index]
+}
+
+func (s *watchSent) add(index int64) {
+	if !s.started {
+		s.started = true
+		s.next = index
+	}
+	if index < s.next {
+		return
+	}
+	s.above[index] = true
+	for s.above[s.next] {
+		delete(s.above, s.next)
+		s.next++
+	}
+}
+
+// watchPage is a page of one kind of output read by a watch.
+type watchPage struct {
+	kind    string
+	outputs []*pb.Output
+	hasMore bool
+}
+
+// readOutputs reads a page of each kind of output after the cursor and advances it,
+// returning the outputs to send in order and telling whether there are more to read.
+// Reading an output already sent means the outputs of its kind were shifted by one
+// synced late, so the kind is read again from the start to find it.
+func (s *GrpcServer) readOutputs(
+	ctx context.Context, cursor *watchCursor,
+) (outputs []*pb.Output, hasMore bool, err error) {
+	// every kind is read from the same snapshot, so an output synced between
+	// two reads cannot be sent before an older output of another kind
+	if s.db != nil {
+		var tx *sqlx.Tx
+		ctx, tx, err = cRepos.StartReadSnapshot(ctx, cRepos.ReadDB(ctx, s.db))
+		if err != nil {
+			return nil, false, err
+		}
+		defer func() {
+			if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+				slog.WarnContext(ctx, "grpc: failed to release the snapshot", "error", err)
+			}
+		}()
+	}
+	pages, err := s.readOutputPages(ctx, cursor)
+	if err != nil {
+		return nil, false, err
+	}
+	// the outputs after the last one read of a kind with more pages
+	// may come after outputs of that kind not read yet
+	limit := int64(math.MaxInt64)
+	for _, page := range pages {
+		if page.hasMore && len(page.outputs) > 0 {
+			limit = min(limit, outputIndex(page.outputs[len(page.outputs)-1]))
+		}
+		hasMore = hasMore || page.hasMore
+	}
+	for _, page := range pages {
+		shifted := false
+		for _, output := range page.outputs {
+			if outputIndex(output) > limit {
+				break
+			}
+			outputCursor := output.Cursor
+			cursor.kinds[page.kind] = &outputCursor
+			if cursor.sent.has(outputIndex(output)) {
+				shifted = shifted || !cursor.rescanning[page.kind]
+				continue
+			}
+			outputs = append(outputs, output)
+		}
+		if cursor.rescanning[page.kind] && !page.hasMore {
+			delete(cursor.rescanning, page.kind)
+		}
+		if shifted {
+			slog.DebugContext(ctx, "grpc: output synced late, reading the kind again", "kind", page.kind)
+			cursor.kinds[page.kind] = cursor.starts[page.kind]
+			cursor.rescanning[page.kind] = true
+			hasMore = true
+		}
+	}
+	slices.SortFunc(outputs, func(a, b *pb.Output) int {
+		return cmp.Compare(outputIndex(a), outputIndex(b))
+	})
+	for _, output := range outputs {
+		cursor.sent.add(outputIndex(output))
+	}
+	return outputs, hasMore, nil
+}
+
+func (s *GrpcServer) readOutputPages(ctx context.Context, cursor *watchCursor) ([]watchPage, error) {
+	first := WATCH_PAGE_SIZE
+	vouchers, err := s.adapter.GetVouchers(ctx, &first, nil, cursor.kinds["voucher"], nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	voucherPage := watchPage{kind: "voucher", hasMore: hasNextPage(vouchers.PageInfo)}
+	for _, edge := range vouchers.Edges {
+		voucherPage.outputs = append(voucherPage.outputs, &pb.Output{
+			Output: &pb.Output_Voucher{Voucher: voucherToProto(edge.Node)}, Cursor: edge.Cursor(),
+		})
+	}
+	delegateCallVouchers, err := s.adapter.GetDelegateCallVouchers(
+		ctx, &first, nil, cursor.kinds["delegate_call_voucher"], nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	delegateCallVoucherPage := watchPage{
+		kind: "delegate_call_voucher", hasMore: hasNextPage(delegateCallVouchers.PageInfo),
+	}
+	for _, edge := range delegateCallVouchers.Edges {
+		delegateCallVoucherPage.outputs = append(delegateCallVoucherPage.outputs, &pb.Output{
+			Output: &pb.Output_DelegateCallVoucher{
+				DelegateCallVoucher: delegateCallVoucherToProto(edge.Node),
+			},
+			Cursor: edge.Cursor(),
+		})
+	}
+	notices, err := s.adapter.GetNotices(ctx, &first, nil, cursor.kinds["notice"], nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	noticePage := watchPage{kind: "notice", hasMore: hasNextPage(notices.PageInfo)}
+	for _, edge := range notices.Edges {
+		noticePage.outputs = append(noticePage.outputs, &pb.Output{
+			Output: &pb.Output_Notice{Notice: noticeToProto(edge.Node)}, Cursor: edge.Cursor(),
+		})
+	}
+	return []watchPage{voucherPage, delegateCallVoucherPage, noticePage}, nil
+}
+
+func hasNextPage(pageInfo *graphql.PageInfo) bool {
+	return pageInfo != nil && pageInfo.HasNextPage
+}
+
+func outputIndex(output *pb.Output) int64 {
+	switch output := output.Output.(type) {
+	case *pb.Output_Voucher:
+		return output.Voucher.Index
+	case *pb.Output_DelegateCallVoucher:
+		return output.DelegateCallVoucher.Index
+	case *pb.Output_Notice:
+		return output.Notice.Index
+	}
+	return 0
+}
+
+func emptyAsNil(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+//
+// Conversions from the GraphQL model
+//
+
+func pageInfoToProto(pageInfo *graphql.PageInfo) *pb.PageInfo {
+	if pageInfo == nil {
+		return &pb.PageInfo{}
+	}
+	return &pb.PageInfo{
+		StartCursor:     pageInfo.StartCursor,
+		EndCursor:       pageInfo.EndCursor,
+		HasNextPage:     pageInfo.HasNextPage,
+		HasPreviousPage: pageInfo.HasPreviousPage,
+	}
+}
+
+func proofToProto(proof graphql.Proof) *pb.Proof {
+	return &pb.Proof{
+		OutputIndex:          proof.OutputIndex,
+		OutputHashesSiblings: proof.OutputHashesSiblings,
+	}
+}
+
+func inputToProto(input *graphql.Input) *pb.Input {
+	return &pb.Input{
+		Id:                  input.ID,
+		Index:               int64(input.Index),
+		Status:              pb.CompletionStatus(pb.CompletionStatus_value["COMPLETION_STATUS_"+string(input.Status)]),
+		MsgSender:           input.MsgSender,
+		Timestamp:           input.Timestamp,
+		BlockNumber:         input.BlockNumber,
+		Payload:             input.Payload,
+		EspressoTimestamp:   input.EspressoTimestamp,
+		EspressoBlockNumber: input.EspressoBlockNumber,
+		InputBoxIndex:       input.InputBoxIndex,
+		BlockTimestamp:      input.BlockTimestamp,
+		PrevRandao:          input.PrevRandao,
+	}
+}
+
+func voucherToProto(voucher *graphql.Voucher) *pb.Voucher {
+	return &pb.Voucher{
+		Index:           int64(voucher.Index),
+		InputIndex:      int64(voucher.InputIndex),
+		Destination:     voucher.Destination,
+		Payload:         voucher.Payload,
+		Proof:           proofToProto(voucher.Proof),
+		Value:           voucher.Value,
+		Executed:        voucher.Executed,
+		TransactionHash: voucher.TransactionHash,
+	}
+}
+
+func vouchersToProto(conn *graphql.VoucherConnection) *pb.VoucherConnection {
+	edges := make([]*pb.VoucherEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &pb.VoucherEdge{Node: voucherToProto(edge.Node), Cursor: edge.Cursor()}
+	}
+	return &pb.VoucherConnection{
+		TotalCount: int64(conn.TotalCount), Edges: edges, PageInfo: pageInfoToProto(conn.PageInfo),
+	}
+}
+
+func delegateCallVoucherToProto(voucher *graphql.DelegateCallVoucher) *pb.DelegateCallVoucher {
+	return &pb.DelegateCallVoucher{
+		Index:           int64(voucher.Index),
+		InputIndex:      int64(voucher.InputIndex),
+		Destination:     voucher.Destination,
+		Payload:         voucher.Payload,
+		Proof:           proofToProto(voucher.Proof),
+		Executed:        voucher.Executed,
+		TransactionHash: voucher.TransactionHash,
+	}
+}
+
+func delegateCallVouchersToProto(conn *graphql.DelegateCallVoucherConnection) *pb.DelegateCallVoucherConnection {
+	edges := make([]*pb.DelegateCallVoucherEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &pb.DelegateCallVoucherEdge{Node: delegateCallVoucherToProto(edge.Node), Cursor: edge.Cursor()}
+	}
+	return &pb.DelegateCallVoucherConnection{
+		TotalCount: int64(conn.TotalCount), Edges: edges, PageInfo: pageInfoToProto(conn.PageInfo),
+	}
+}
+
+func noticeToProto(notice *graphql.Notice) *pb.Notice {
+	return &pb.Notice{
+		Index:      int64(notice.Index),
+		InputIndex: int64(notice.InputIndex),
+		Payload:    notice.Payload,
+		Proof:      proofToProto(notice.Proof),
+	}
+}
+
+func noticesToProto(conn *graphql.NoticeConnection) *pb.NoticeConnection {
+	edges := make([]*pb.NoticeEdge, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		edges[i] = &pb.NoticeEdge{Node: noticeToProto(edge.Node), Cursor: edge.Cursor()}
+	}
+	return &pb.NoticeConnection{
+		TotalCount: int64(conn.TotalCount), Edges: edges, PageInfo: pageInfoToProto(conn.PageInfo),
+	}
+}
+
+func reportToProto(report *graphql.Report) *pb.Report {
+	return &pb.Report{
+		Index:      int64(report.Index),
+		InputIndex: int64(report.InputIndex),
+		Payload:    report.Payload,
+	}
+}
+
+func filtersFromProto(filters []*pb.ConvenientFilter) []*graphql.ConvenientFilter {
+	if filters == nil {
+		return nil
+	}
+	converted := make([]*graphql.ConvenientFilter, len(filters))
+	for i, filter := range filters {
+		converted[i] = filterFromProto(filter)
+	}
+	return converted
+}
+
+func filterFromProto(filter *pb.ConvenientFilter) *graphql.ConvenientFilter {
+	if filter == nil {
+		return nil
+	}
+	converted := &graphql.ConvenientFilter{
+		And: filtersFromProto(filter.And),
+		Or:  filtersFromProto(filter.Or),
+	}
+	if destination := filter.Destination; destination != nil {
+		converted.Destination = &graphql.AddressFilterInput{
+			Eq:  destination.Eq,
+			Ne:  destination.Ne,
+			In:  pointers(destination.In),
+			Nin: pointers(destination.Nin),
+			And: filtersFromProto(destination.And),
+			Or:  filtersFromProto(destination.Or),
+		}
+	}
+	if executed := filter.Executed; executed != nil {
+		converted.Executed = &graphql.BooleanFilterInput{
+			Eq:  executed.Eq,
+			Ne:  executed.Ne,
+			And: filtersFromProto(executed.And),
+			Or:  filtersFromProto(executed.Or),
+		}
+	}
+	return converted
+}
+
+func pointers(values []string) []*string {
+	if values == nil {
+		return nil
+	}
+	converted := make([]*string, len(values))
+	for i := range values {
+		converted[i] = &values[i]
+	}
+	return converted
+}
@@ -0,0 +1,265 @@
+package reader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	pb "github.com/cartesi/rollups-graphql/v2/pkg/reader/readerpb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type GrpcSuite struct {
+	suite.Suite
+	ctx               context.Context
+	db                *sqlx.DB
+	dbFactory         *commons.DbFactory
+	voucherRepository *cRepos.VoucherRepository
+	server            *grpc.Server
+	conn              *grpc.ClientConn
+	client            pb.ReaderClient
+}
+
+func (s *GrpcSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "grpc.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	outputRepository := cRepos.OutputRepository{Db: s.db}
+	s.voucherRepository = &cRepos.VoucherRepository{Db: s.db, OutputRepository: outputRepository}
+	noticeRepository := &cRepos.NoticeRepository{Db: s.db, OutputRepository: outputRepository}
+	reportRepository := &cRepos.ReportRepository{Db: s.db}
+	for i := range 3 {
+		_, err := inputRepository.Create(s.ctx, cModel.AdvanceInput{
+			ID:             fmt.Sprint(i),
+			Index:          i,
+			Status:         cModel.CompletionStatusAccepted,
+			Payload:        "0x1122",
+			BlockNumber:    1,
+			BlockTimestamp: time.UnixMilli(1700000000000),
+			AppContract:    common.HexToAddress(ApplicationAddress),
+		})
+		s.Require().NoError(err)
+		s.createVoucher(2*i, i)
+		_, err = noticeRepository.Create(s.ctx, &cModel.ConvenienceNotice{
+			AppContract: common.HexToAddress(ApplicationAddress).Hex(),
+			Payload:     "0x5566",
+			InputIndex:  uint64(i),
+			OutputIndex: uint64(2*i + 1),
+		})
+		s.Require().NoError(err)
+		_, err = reportRepository.CreateReport(s.ctx, cModel.Report{
+			Index:       i,
+			InputIndex:  i,
+			Payload:     "0x7788",
+			AppContract: common.HexToAddress(ApplicationAddress),
+		})
+		s.Require().NoError(err)
+	}
+	service := services.NewConvenienceService(
+		s.voucherRepository,
+		noticeRepository,
+		inputRepository,
+		reportRepository,
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+	grpcServer := NewGrpcServer(NewAdapterV1(s.ctx, s.db, service), s.db)
+	grpcServer.WatchInterval = 10 * time.Millisecond
+	s.server = grpc.NewServer()
+	pb.RegisterReaderServer(s.server, grpcServer)
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	s.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.client = pb.NewReaderClient(s.conn)
+}
+
+func (s *GrpcSuite) TearDownTest() {
+	s.NoError(s.conn.Close())
+	s.server.Stop()
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestGrpcSuite(t *testing.T) {
+	suite.Run(t, new(GrpcSuite))
+}
+
+func (s *GrpcSuite) createVoucher(outputIndex int, inputIndex int) {
+	_, err := s.voucherRepository.CreateVoucher(s.ctx, &cModel.ConvenienceVoucher{
+		Destination: common.HexToAddress(ApplicationAddress),
+		Payload:     "0x3344",
+		Value:       "0x01",
+		InputIndex:  uint64(inputIndex),
+		OutputIndex: uint64(outputIndex),
+		AppContract: common.HexToAddress(ApplicationAddress),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *GrpcSuite) TestWatchOutputsInOrderAcrossPages() {
+	// more vouchers than a page, then a notice after all of them
+	last := 6 + WATCH_PAGE_SIZE
+	for outputIndex := 6; outputIndex < last; outputIndex++ {
+		s.createVoucher(outputIndex, 3)
+	}
+	noticeRepository := &cRepos.NoticeRepository{Db: s.db, OutputRepository: cRepos.OutputRepository{Db: s.db}}
+	_, err := noticeRepository.Create(s.ctx, &cModel.ConvenienceNotice{
+		AppContract: common.HexToAddress(ApplicationAddress).Hex(),
+		Payload:     "0x5566",
+		InputIndex:  3,
+		OutputIndex: uint64(last),
+	})
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
+	defer cancel()
+	stream, err := s.client.WatchOutputs(ctx, &pb.WatchOutputsRequest{AppContract: ApplicationAddress})
+	s.Require().NoError(err)
+	for i := range last + 1 {
+		output, err := stream.Recv()
+		s.Require().NoError(err)
+		s.Equal(int64(i), outputIndex(output))
+	}
+}
+
+func (s *GrpcSuite) TestWatchOutputsSyncedLate() {
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
+	defer cancel()
+	stream, err := s.client.WatchOutputs(ctx, &pb.WatchOutputsRequest{AppContract: ApplicationAddress})
+	s.Require().NoError(err)
+	for i := range 6 {
+		output, err := stream.Recv()
+		s.Require().NoError(err)
+		s.Equal(int64(i), outputIndex(output))
+	}
+
+	// the voucher 6 is synced after the voucher 7, e.g. by the retry of a dead letter
+	s.createVoucher(7, 2)
+	output, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(int64(7), outputIndex(output))
+	s.createVoucher(6, 2)
+	output, err = stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(int64(6), outputIndex(output))
+
+	// the watch goes on without sending any output twice
+	s.createVoucher(8, 2)
+	output, err = stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(int64(8), outputIndex(output))
+}
+
+func (s *GrpcSuite) TestListInputs() {
+	conn, err := s.client.ListInputs(s.ctx, &pb.ListInputsRequest{
+		AppContract: ApplicationAddress,
+		Pagination:  &pb.Pagination{First: proto.Int32(2)},
+	})
+	s.Require().NoError(err)
+	s.Equal(int64(3), conn.TotalCount)
+	s.Require().Len(conn.Edges, 2)
+	s.Equal(int64(1), conn.Edges[1].Node.Index)
+	s.Equal(pb.CompletionStatus_COMPLETION_STATUS_ACCEPTED, conn.Edges[1].Node.Status)
+	s.True(conn.PageInfo.HasNextPage)
+
+	next, err := s.client.ListInputs(s.ctx, &pb.ListInputsRequest{
+		AppContract: ApplicationAddress,
+		Pagination:  &pb.Pagination{After: conn.PageInfo.EndCursor},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(next.Edges, 1)
+	s.Equal(int64(2), next.Edges[0].Node.Index)
+}
+
+func (s *GrpcSuite) TestGetErrors() {
+	report, err := s.client.GetReport(s.ctx, &pb.GetReportRequest{AppContract: ApplicationAddress, ReportIndex: 2})
+	s.Require().NoError(err)
+	s.Equal("0x7788", report.Payload)
+
+	_, err = s.client.GetReport(s.ctx, &pb.GetReportRequest{AppContract: ApplicationAddress, ReportIndex: 99})
+	s.Equal(codes.NotFound, status.Code(err))
+	_, err = s.client.GetVoucher(s.ctx, &pb.GetOutputRequest{AppContract: "0x1234"})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *GrpcSuite) TestListVouchersOfInput() {
+	conn, err := s.client.ListVouchers(s.ctx, &pb.ListVouchersRequest{
+		AppContract: ApplicationAddress,
+		InputIndex:  proto.Int64(1),
+	})
+	s.Require().NoError(err)
+	s.Require().Len(conn.Edges, 1)
+	s.Equal(int64(2), conn.Edges[0].Node.Index)
+	s.Equal("0x3344", conn.Edges[0].Node.Payload)
+}
+
+func (s *GrpcSuite) TestWatchOutputs() {
+	ctx, cancel := context.WithTimeout(s.ctx, 5*time.Second)
+	defer cancel()
+	stream, err := s.client.WatchOutputs(ctx, &pb.WatchOutputsRequest{AppContract: ApplicationAddress})
+	s.Require().NoError(err)
+	var lastVoucher, lastNotice string
+	for i := range 6 {
+		output, err := stream.Recv()
+		s.Require().NoError(err)
+		switch output := output.Output.(type) {
+		case *pb.Output_Voucher:
+			s.Equal(int64(i), output.Voucher.Index)
+		case *pb.Output_Notice:
+			s.Equal(int64(i), output.Notice.Index)
+		default:
+			s.Failf("unexpected output", "%T", output)
+		}
+		if output.GetVoucher() != nil {
+			lastVoucher = output.Cursor
+		} else {
+			lastNotice = output.Cursor
+		}
+	}
+
+	// the outputs synced after the watch started are streamed too
+	s.createVoucher(6, 2)
+	output, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Equal(int64(6), output.GetVoucher().GetIndex())
+
+	// resume after the last voucher received before
+	resumed, err := s.client.WatchOutputs(ctx, &pb.WatchOutputsRequest{
+		AppContract:  ApplicationAddress,
+		VoucherAfter: lastVoucher,
+		NoticeAfter:  lastNotice,
+	})
+	s.Require().NoError(err)
+	output, err = resumed.Recv()
+	s.Require().NoError(err)
+	s.Equal(int64(6), output.GetVoucher().GetIndex())
+}
@@ -0,0 +1,207 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/reader/graph"
+	"github.com/cartesi/rollups-graphql/v2/pkg/reader/model"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	DefaultQueryMaxDepth = 15
+	// Allows a page of 1000 rows with a few dozen fields, not nested pages of 1000 rows
+	DefaultQueryMaxComplexity = 50000
+
+	errDepthLimit    = "DEPTH_LIMIT_EXCEEDED"
+	errPageSizeLimit = "PAGE_SIZE_LIMIT_EXCEEDED"
+)
+
+// QueryLimits bounds the cost of the GraphQL queries. A zero limit disables it.
+type QueryLimits struct {
+	// Maximum nesting of the selected fields, not counting the introspection queries
+	MaxDepth int
+	// Maximum complexity, where a connection costs its page size times the cost of its selection
+	MaxComplexity int
+	// Maximum value of the first and last arguments of the connections
+	MaxPageSize int
+}
+
+// Create the limits with the default values.
+func NewQueryLimits() QueryLimits {
+	return QueryLimits{
+		MaxDepth:      DefaultQueryMaxDepth,
+		MaxComplexity: DefaultQueryMaxComplexity,
+		MaxPageSize:   commons.DefaultPaginationLimit,
+	}
+}
+
+// Rows read by a connection field given its pagination arguments.
+func pageSize(first *int, last *int) int {
+	if first != nil {
+		return *first
+	}
+	if last != nil {
+		return *last
+	}
+	return commons.DefaultPaginationLimit
+}
+
+func connectionComplexity(childComplexity int, first *int, last *int, _ *string, _ *string) int {
+	return 1 + pageSize(first, last)*childComplexity
+}
+
+// setConnectionComplexity scores each connection field by the rows it may read.
+func setConnectionComplexity(complexity *graph.ComplexityRoot) {
+	complexity.Input.Vouchers = connectionComplexity
+	complexity.Input.DelegateCallVouchers = connectionComplexity
+	complexity.Input.Notices = connectionComplexity
+	complexity.Input.Reports = connectionComplexity
+	complexity.Query.Notices = connectionComplexity
+	complexity.Query.Reports = connectionComplexity
+	complexity.Query.Inputs = func(
+		childComplexity int, first *int, last *int, after *string, before *string, _ *model.InputFilter,
+	) int {
+		return connectionComplexity(childComplexity, first, last, after, before)
+	}
+	complexity.Query.Vouchers = func(
+		childComplexity int, first *int, last *int, after *string, before *string, _ []*model.ConvenientFilter,
+	) int {
+		return connectionComplexity(childComplexity, first, last, after, before)
+	}
+	complexity.Query.DelegateCallVouchers = func(
+		childComplexity int, first *int, last *int, after *string, before *string, _ []*model.ConvenientFilter,
+	) int {
+		return connectionComplexity(childComplexity, first, last, after, before)
+	}
+	complexity.Query.Applications = func(
+		childComplexity int, first *int, last *int, after *string, before *string, _ *model.AppFilter,
+	) int {
+		return connectionComplexity(childComplexity, first, last, after, before)
+	}
+}
+
+// useQueryLimits installs the limits in the GraphQL handler.
+func useQueryLimits(server *handler.Server, limits QueryLimits) {
+	if limits.MaxDepth > 0 || limits.MaxPageSize > 0 {
+		server.Use(queryLimitsExtension{limits})
+	}
+	if limits.MaxComplexity > 0 {
+		server.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	}
+}
+
+// queryLimitsExtension rejects the operations exceeding the maximum depth or page size
+// before running any resolver.
+type queryLimitsExtension struct {
+	limits QueryLimits
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = queryLimitsExtension{}
+
+func (queryLimitsExtension) ExtensionName() string {
+	return "QueryLimits"
+}
+
+func (queryLimitsExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (q queryLimitsExtension) MutateOperationContext(
+	ctx context.Context, opCtx *graphql.OperationContext,
+) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if op == nil {
+		return nil
+	}
+	depth, err := q.walk(op.SelectionSet, opCtx.Variables, 0)
+	if err != nil {
+		return err
+	}
+	if q.limits.MaxDepth > 0 && depth > q.limits.MaxDepth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, q.limits.MaxDepth)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+// walk returns the depth of the selection set, checking the page size of each field.
+func (q queryLimitsExtension) walk(
+	selectionSet ast.SelectionSet, variables map[string]any, depth int,
+) (int, *gqlerror.Error) {
+	maxDepth := depth
+	for _, selection := range selectionSet {
+		var (
+			childDepth int
+			err        *gqlerror.Error
+		)
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			if err := q.checkPageSize(selection, variables); err != nil {
+				return 0, err
+			}
+			childDepth, err = q.walk(selection.SelectionSet, variables, depth+1)
+		case *ast.InlineFragment:
+			childDepth, err = q.walk(selection.SelectionSet, variables, depth)
+		case *ast.FragmentSpread:
+			if selection.Definition == nil {
+				continue
+			}
+			childDepth, err = q.walk(selection.Definition.SelectionSet, variables, depth)
+		}
+		if err != nil {
+			return 0, err
+		}
+		maxDepth = max(maxDepth, childDepth)
+	}
+	return maxDepth, nil
+}
+
+func (q queryLimitsExtension) checkPageSize(field *ast.Field, variables map[string]any) *gqlerror.Error {
+	if q.limits.MaxPageSize <= 0 || field.Definition == nil {
+		return nil
+	}
+	args := field.ArgumentMap(variables)
+	for _, name := range []string{"first", "last"} {
+		size, ok := intArgument(args[name])
+		if ok && size > int64(q.limits.MaxPageSize) {
+			err := gqlerror.ErrorPosf(field.Position,
+				"%s.%s has %s %d, which exceeds the limit of %d",
+				field.ObjectDefinition.Name, field.Name, name, size, q.limits.MaxPageSize)
+			errcode.Set(err, errPageSizeLimit)
+			return err
+		}
+	}
+	return nil
+}
+
+// intArgument converts an argument from a literal or from a JSON variable.
+func intArgument(value any) (int64, bool) {
+	switch value := value.(type) {
+	case int:
+		return int64(value), true
+	case int64:
+		return value, true
+	case float64:
+		return int64(value), true
+	case json.Number:
+		size, err := value.Int64()
+		return size, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,158 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type LimitsSuite struct {
+	suite.Suite
+	ctx       context.Context
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	service   *services.ConvenienceService
+}
+
+type graphqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func (s *LimitsSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "limits.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	s.service = services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		inputRepository,
+		&cRepos.ReportRepository{Db: s.db},
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+}
+
+func (s *LimitsSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestLimitsSuite(t *testing.T) {
+	suite.Run(t, new(LimitsSuite))
+}
+
+func (s *LimitsSuite) query(limits QueryLimits, query string, variables map[string]any) graphqlResponse {
+	e := echo.New()
+	Register(s.ctx, e, s.service, NewAdapterV1(s.ctx, s.db, s.service), GraphQLOpts{Limits: limits})
+	return postGraphQL(&s.Suite, e, map[string]any{"query": query, "variables": variables})
+}
+
+func (s *LimitsSuite) errorCode(response graphqlResponse) string {
+	return errorCode(&s.Suite, response)
+}
+
+func postGraphQL(s *suite.Suite, e *echo.Echo, params map[string]any) graphqlResponse {
+	body, err := json.Marshal(params)
+	s.Require().NoError(err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql/"+ApplicationAddress, strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	var response graphqlResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+	return response
+}
+
+func errorCode(s *suite.Suite, response graphqlResponse) string {
+	s.Require().Len(response.Errors, 1)
+	return response.Errors[0].Extensions["code"].(string)
+}
+
+const nestedQuery = `query {
+	inputs(first: 10) { edges { node {
+		vouchers(first: 10) { edges { node {
+			input { notices(first: 10) { edges { node { payload } } } }
+		} } }
+	} } }
+}`
+
+func (s *LimitsSuite) TestDefaultLimitsAcceptNestedQuery() {
+	response := s.query(NewQueryLimits(), nestedQuery, nil)
+	s.Empty(response.Errors)
+	s.Contains(response.Data, "inputs")
+}
+
+func (s *LimitsSuite) TestDefaultLimitsRejectNestedFanOut() {
+	query := strings.ReplaceAll(nestedQuery, "first: 10", "first: 1000")
+	response := s.query(NewQueryLimits(), query, nil)
+	s.Equal("COMPLEXITY_LIMIT_EXCEEDED", s.errorCode(response))
+	s.Nil(response.Data)
+
+	// a single page of the maximum size is accepted
+	response = s.query(NewQueryLimits(), `query { inputs(first: 1000) { edges { node { index payload } } } }`, nil)
+	s.Empty(response.Errors)
+}
+
+func (s *LimitsSuite) TestMaxDepth() {
+	response := s.query(QueryLimits{MaxDepth: 5}, nestedQuery, nil)
+	s.Equal(errDepthLimit, s.errorCode(response))
+	s.Contains(response.Errors[0].Message, "operation has depth 11, which exceeds the limit of 5")
+}
+
+func (s *LimitsSuite) TestMaxDepthFollowsFragments() {
+	query := `query { inputs { ...page } }
+	fragment page on InputConnection { edges { node { vouchers { edges { node { index } } } } } }`
+	response := s.query(QueryLimits{MaxDepth: 5}, query, nil)
+	s.Equal(errDepthLimit, s.errorCode(response))
+}
+
+func (s *LimitsSuite) TestMaxDepthIgnoresIntrospection() {
+	query := `query { __schema { types { fields { type { ofType { ofType { name } } } } } } }`
+	response := s.query(QueryLimits{MaxDepth: 2}, query, nil)
+	s.Empty(response.Errors)
+}
+
+func (s *LimitsSuite) TestMaxPageSize() {
+	response := s.query(QueryLimits{MaxPageSize: 100}, `query { reports(last: 101) { totalCount } }`, nil)
+	s.Equal(errPageSizeLimit, s.errorCode(response))
+	s.Contains(response.Errors[0].Message, "Query.reports has last 101, which exceeds the limit of 100")
+
+	response = s.query(QueryLimits{MaxPageSize: 100},
+		`query($n: Int) { inputs(first: 1) { edges { node { notices(first: $n) { totalCount } } } } }`,
+		map[string]any{"n": 500})
+	s.Equal(errPageSizeLimit, s.errorCode(response))
+
+	response = s.query(QueryLimits{MaxPageSize: 100}, `query { reports(first: 100) { totalCount } }`, nil)
+	s.Empty(response.Errors)
+}
+
+func (s *LimitsSuite) TestMaxComplexity() {
+	// inputs: 1 + 10 * (edges: 1 + node: 1 + vouchers: (1 + 10 * (edges: 1 + node: 1 + index: 1)))
+	query := `query { inputs(first: 10) { edges { node { vouchers(first: 10) { edges { node { index } } } } } } }`
+	response := s.query(QueryLimits{MaxComplexity: 400}, query, nil)
+	s.Empty(response.Errors)
+	response = s.query(QueryLimits{MaxComplexity: 300}, query, nil)
+	s.Equal("COMPLEXITY_LIMIT_EXCEEDED", s.errorCode(response))
+	s.Contains(response.Errors[0].Message, "operation has complexity 331")
+}
@@ -2,8 +2,6 @@ package loaders
 
 import (
 	"context"
-	"strconv"
-	"strings"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
@@ -11,23 +9,19 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-// dataReader reads Users from a database
+// dataReader reads the batches of the loaders from the repositories
 type dataReader struct {
-	reportRepository  *repository.ReportRepository
-	voucherRepository *repository.VoucherRepository
-	noticeRepository  *repository.NoticeRepository
-	inputRepository   *repository.InputRepository
+	reportRepository      *repository.ReportRepository
+	voucherRepository     *repository.VoucherRepository
+	noticeRepository      *repository.NoticeRepository
+	inputRepository       *repository.InputRepository
+	applicationRepository *repository.ApplicationRepository
 }
 
 // getReports implements a batch function that can retrieve many users by ID,
 // for use in a dataloader
 func (u *dataReader) getReports(ctx context.Context, reportsKeys []string) ([]*commons.PageResult[cModel.Report], []error) {
-	filters, errors := buildBatchFilters(reportsKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
-		return &repository.BatchFilterItem{
-			AppContract: &appContract,
-			InputIndex:  inputIndex,
-		}
-	})
+	filters, errors := buildBatchFilters(reportsKeys, newBatchFilterItem)
 	if errors != nil {
 		return nil, errors
 	}
@@ -36,12 +30,7 @@ func (u *dataReader) getReports(ctx context.Context, reportsKeys []string) ([]*c
 }
 
 func (u *dataReader) getVouchers(ctx context.Context, voucherKeys []string) ([]*commons.PageResult[cModel.ConvenienceVoucher], []error) {
-	filters, errors := buildBatchFilters(voucherKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
-		return &repository.BatchFilterItem{
-			AppContract: &appContract,
-			InputIndex:  inputIndex,
-		}
-	})
+	filters, errors := buildBatchFilters(voucherKeys, newBatchFilterItem)
 	if errors != nil {
 		return nil, errors
 	}
@@ -49,11 +38,21 @@ func (u *dataReader) getVouchers(ctx context.Context, voucherKeys []string) ([]*
 	return u.voucherRepository.BatchFindAllByInputIndexAndAppContract(ctx, filters)
 }
 
+func (u *dataReader) getDelegateCallVouchers(ctx context.Context, voucherKeys []string) ([]*commons.PageResult[cModel.ConvenienceVoucher], []error) {
+	filters, errors := buildBatchFilters(voucherKeys, newBatchFilterItem)
+	if errors != nil {
+		return nil, errors
+	}
+
+	return u.voucherRepository.BatchFindAllDelegateCallsByInputIndexAndAppContract(ctx, filters)
+}
+
 func (u *dataReader) getNotices(ctx context.Context, noticesKeys []string) ([]*commons.PageResult[cModel.ConvenienceNotice], []error) {
-	filters, errors := buildBatchFilters(noticesKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItemForNotice {
+	filters, errors := buildBatchFilters(noticesKeys, func(appContract common.Address, inputIndex int, page repository.BatchPage) *repository.BatchFilterItemForNotice {
 		return &repository.BatchFilterItemForNotice{
 			AppContract: appContract.Hex(),
 			InputIndex:  inputIndex,
+			BatchPage:   page,
 		}
 	})
 	if errors != nil {
@@ -64,12 +63,7 @@ func (u *dataReader) getNotices(ctx context.Context, noticesKeys []string) ([]*c
 }
 
 func (u *dataReader) getInputs(ctx context.Context, inputsKeys []string) ([]*cModel.AdvanceInput, []error) {
-	filters, errors := buildBatchFilters(inputsKeys, func(appContract common.Address, inputIndex int) *repository.BatchFilterItem {
-		return &repository.BatchFilterItem{
-			AppContract: &appContract,
-			InputIndex:  inputIndex,
-		}
-	})
+	filters, errors := buildBatchFilters(inputsKeys, newBatchFilterItem)
 	if errors != nil {
 		return nil, errors
 	}
@@ -77,19 +71,28 @@ func (u *dataReader) getInputs(ctx context.Context, inputsKeys []string) ([]*cMo
 	return u.inputRepository.BatchFindInputByInputIndexAndAppContract(ctx, filters)
 }
 
-func buildBatchFilters[T any](keys []string, filterFunc func(appContract common.Address, inputIndex int) T) ([]T, []error) {
-	errors := []error{}
+func (u *dataReader) getApplications(ctx context.Context, appContracts []string) ([]*cModel.ConvenienceApplication, []error) {
+	return u.applicationRepository.BatchFindAppsByAppContract(ctx, appContracts)
+}
+
+func newBatchFilterItem(appContract common.Address, inputIndex int, page repository.BatchPage) *repository.BatchFilterItem {
+	return &repository.BatchFilterItem{
+		AppContract: &appContract,
+		InputIndex:  inputIndex,
+		BatchPage:   page,
+	}
+}
+
+func buildBatchFilters[T any](keys []string, filterFunc func(appContract common.Address, inputIndex int, page repository.BatchPage) T) ([]T, []error) {
 	filters := []T{}
 
 	for _, key := range keys {
-		aux := strings.Split(key, "|")
-		appContract := common.HexToAddress(aux[0])
-		inputIndex, err := strconv.Atoi(aux[1])
+		appContract, inputIndex, page, err := repository.ParseBatchKey(key)
 		if err != nil {
-			return nil, errors
+			return nil, []error{err}
 		}
 
-		filter := filterFunc(appContract, inputIndex)
+		filter := filterFunc(common.HexToAddress(appContract), inputIndex, page)
 		filters = append(filters, filter)
 	}
 
@@ -2,12 +2,16 @@ package loaders
 
 import (
 	"context"
+	"errors"
+	"math"
 	"time"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
 	"github.com/vikstrous/dataloadgen"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type ctxKey string
@@ -16,12 +20,17 @@ const (
 	LoadersKey = ctxKey("dataLoaders")
 )
 
-// Loaders wrap your data loaders to inject via middleware
+// Loaders wrap your data loaders to inject via middleware.
+// The loaders of lists are keyed by application and input index, followed by the
+// page of the rows when paginated (see repository.BatchPage), the application
+// loader by application address.
 type Loaders struct {
-	ReportLoader  *dataloadgen.Loader[string, *commons.PageResult[cModel.Report]]
-	VoucherLoader *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceVoucher]]
-	NoticeLoader  *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceNotice]]
-	InputLoader   *dataloadgen.Loader[string, *cModel.AdvanceInput]
+	ReportLoader              *dataloadgen.Loader[string, *commons.PageResult[cModel.Report]]
+	VoucherLoader             *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceVoucher]]
+	DelegateCallVoucherLoader *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceVoucher]]
+	NoticeLoader              *dataloadgen.Loader[string, *commons.PageResult[cModel.ConvenienceNotice]]
+	InputLoader               *dataloadgen.Loader[string, *cModel.AdvanceInput]
+	ApplicationLoader         *dataloadgen.Loader[string, *cModel.ConvenienceApplication]
 }
 
 // NewLoaders instantiates data loaders for the middleware
@@ -30,34 +39,57 @@ func NewLoaders(
 	voucherRepository *repository.VoucherRepository,
 	noticeRepository *repository.NoticeRepository,
 	inputRepository *repository.InputRepository,
+	applicationRepository *repository.ApplicationRepository,
 ) *Loaders {
 	// define the data loader
 	ur := &dataReader{
-		reportRepository:  reportRepository,
-		voucherRepository: voucherRepository,
-		noticeRepository:  noticeRepository,
-		inputRepository:   inputRepository,
+		reportRepository:      reportRepository,
+		voucherRepository:     voucherRepository,
+		noticeRepository:      noticeRepository,
+		inputRepository:       inputRepository,
+		applicationRepository: applicationRepository,
 	}
 	return &Loaders{
 		ReportLoader: dataloadgen.NewLoader(
-			ur.getReports,
+			traceBatch("reports", ur.getReports),
 			dataloadgen.WithWait(time.Millisecond),
 		),
 		VoucherLoader: dataloadgen.NewLoader(
-			ur.getVouchers,
+			traceBatch("vouchers", ur.getVouchers),
+			dataloadgen.WithWait(time.Millisecond),
+		),
+		DelegateCallVoucherLoader: dataloadgen.NewLoader(
+			traceBatch("delegate_call_vouchers", ur.getDelegateCallVouchers),
 			dataloadgen.WithWait(time.Millisecond),
 		),
 		NoticeLoader: dataloadgen.NewLoader(
-			ur.getNotices,
+			traceBatch("notices", ur.getNotices),
 			dataloadgen.WithWait(time.Millisecond),
 		),
 		InputLoader: dataloadgen.NewLoader(
-			ur.getInputs,
+			traceBatch("inputs", ur.getInputs),
+			dataloadgen.WithWait(time.Millisecond),
+		),
+		ApplicationLoader: dataloadgen.NewLoader(
+			traceBatch("applications", ur.getApplications),
 			dataloadgen.WithWait(time.Millisecond),
 		),
 	}
 }
 
+// traceBatch starts a span for each batch fetched by a loader.
+func traceBatch[V any](
+	name string,
+	fetch func(context.Context, []string) ([]V, []error),
+) func(context.Context, []string) ([]V, []error) {
+	return func(ctx context.Context, keys []string) ([]V, []error) {
+		ctx, span := tracing.Start(ctx, "loader."+name, attribute.Int("loader.batch.size", len(keys)))
+		values, errs := fetch(ctx, keys)
+		tracing.End(span, errors.Join(errs...))
+		return values, errs
+	}
+}
+
 // For returns the dataloader for a given context
 func For(ctx context.Context) *Loaders {
 	aux := ctx.Value(LoadersKey)
@@ -78,3 +110,38 @@ func GetMayReports(ctx context.Context, reportsKeys []string) ([]*commons.PageRe
 	loaders := For(ctx)
 	return loaders.ReportLoader.LoadAll(ctx, reportsKeys)
 }
+
+// LoadPage loads the page of the rows of an input selected by the forward pagination
+// of a connection, key being the key of all the rows of the input.
+func LoadPage[T any](
+	ctx context.Context,
+	loader *dataloadgen.Loader[string, *commons.PageResult[T]],
+	key string,
+	first *int,
+	after *string,
+) (*commons.PageResult[T], error) {
+	limit := commons.DefaultPaginationLimit
+	if first != nil {
+		if *first < 0 {
+			return nil, commons.ErrInvalidLimit
+		}
+		limit = *first
+	}
+	page := repository.BatchPage{Limit: &limit}
+	if after != nil {
+		// the cursor is checked against the total once loaded
+		offset, err := commons.DecodeCursor(*after, math.MaxInt)
+		if err != nil {
+			return nil, err
+		}
+		page.Offset = offset + 1
+	}
+	result, err := loader.Load(ctx, page.Key(key))
+	if err != nil {
+		return nil, err
+	}
+	if after != nil && page.Offset > int(result.Total) {
+		return nil, commons.ErrInvalidCursor
+	}
+	return result, nil
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,14 +28,15 @@ const ApplicationAddress = "0x75135d8ADb7180640d29d822D9AD59E83E8695b2"
 
 type LoaderSuite struct {
 	suite.Suite
-	reportRepository  *cRepos.ReportRepository
-	inputRepository   *cRepos.InputRepository
-	voucherRepository *cRepos.VoucherRepository
-	noticeRepository  *cRepos.NoticeRepository
-	dbFactory         *commons.DbFactory
-	ctx               context.Context
-	ctxCancel         context.CancelFunc
-	db                *sqlx.DB
+	reportRepository      *cRepos.ReportRepository
+	inputRepository       *cRepos.InputRepository
+	voucherRepository     *cRepos.VoucherRepository
+	noticeRepository      *cRepos.NoticeRepository
+	applicationRepository *cRepos.ApplicationRepository
+	dbFactory             *commons.DbFactory
+	ctx                   context.Context
+	ctxCancel             context.CancelFunc
+	db                    *sqlx.DB
 }
 
 func (s *LoaderSuite) SetupTest() {
@@ -44,6 +46,7 @@ func (s *LoaderSuite) SetupTest() {
 	s.dbFactory, err = commons.NewDbFactory()
 	s.Require().NoError(err)
 	s.db = s.dbFactory.CreateDb(s.ctx, "adapterV1.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
 	s.reportRepository = &cRepos.ReportRepository{
 		Db: s.db,
 	}
@@ -67,6 +70,10 @@ func (s *LoaderSuite) SetupTest() {
 	err = s.noticeRepository.CreateTables(s.ctx)
 	s.Require().NoError(err)
 
+	s.applicationRepository = &cRepos.ApplicationRepository{
+		Db: s.db,
+	}
+
 }
 
 func (s *LoaderSuite) TearDownTest() {
@@ -89,6 +96,7 @@ func (s *LoaderSuite) TestGetReports() {
 		s.voucherRepository,
 		s.noticeRepository,
 		s.inputRepository,
+		s.applicationRepository,
 	)
 	rCtx := context.WithValue(ctx, LoadersKey, loaders)
 
@@ -151,6 +159,7 @@ func (s *LoaderSuite) TestGetVouchers() {
 		s.voucherRepository,
 		s.noticeRepository,
 		s.inputRepository,
+		s.applicationRepository,
 	)
 	vCtx := context.WithValue(ctx, LoadersKey, loaders)
 
@@ -213,6 +222,7 @@ func (s *LoaderSuite) TestGetNotices() {
 		s.voucherRepository,
 		s.noticeRepository,
 		s.inputRepository,
+		s.applicationRepository,
 	)
 	rCtx := context.WithValue(ctx, LoadersKey, loaders)
 
@@ -12,6 +12,7 @@ import (
 	"strconv"
 
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 //
@@ -77,6 +78,7 @@ func ConvertInput(ctx context.Context, input cModel.AdvanceInput) (*Input, error
 		InputBoxIndex:       inputBoxIndexStr,
 		BlockTimestamp:      timestamp,
 		PrevRandao:          input.PrevRandao,
+		AppContract:         input.AppContract.Hex(),
 	}, nil
 }
 
@@ -93,6 +95,7 @@ func ConvertConvenientDelegateCallVoucherV1(cVoucher cModel.ConvenienceVoucher)
 		Payload:         cVoucher.Payload,
 		Executed:        cVoucher.Executed,
 		TransactionHash: cVoucher.TransactionHash,
+		AppContract:     cVoucher.AppContract.Hex(),
 		Proof: Proof{
 			OutputIndex:          strconv.FormatUint(cVoucher.ProofOutputIndex, 10),
 			OutputHashesSiblings: outputHashesSiblings,
@@ -122,6 +125,7 @@ func ConvertConvenientVoucherV1(cVoucher cModel.ConvenienceVoucher) *Voucher {
 		Value:           cVoucher.Value,
 		Executed:        cVoucher.Executed,
 		TransactionHash: cVoucher.TransactionHash,
+		AppContract:     cVoucher.AppContract.Hex(),
 		Proof: Proof{
 			OutputIndex:          strconv.FormatUint(cVoucher.ProofOutputIndex, 10),
 			OutputHashesSiblings: outputHashesSiblings,
@@ -293,9 +297,10 @@ func ConvertConvenientNoticeV1(cNotice cModel.ConvenienceNotice) *Notice {
 		outputHashesSiblings = []string{}
 	}
 	return &Notice{
-		Index:      int(cNotice.OutputIndex),
-		InputIndex: int(cNotice.InputIndex),
-		Payload:    cNotice.Payload,
+		Index:       int(cNotice.OutputIndex),
+		InputIndex:  int(cNotice.InputIndex),
+		Payload:     cNotice.Payload,
+		AppContract: common.HexToAddress(cNotice.AppContract).Hex(),
 		Proof: Proof{
 			OutputIndex:          strconv.FormatUint(cNotice.ProofOutputIndex, 10),
 			OutputHashesSiblings: outputHashesSiblings,
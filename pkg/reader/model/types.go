@@ -30,6 +30,9 @@ type Input struct {
 	BlockTimestamp string `json:"blockTimestamp"`
 
 	PrevRandao string `json:"prevRandao"`
+
+	// Address of the application, resolving the nested fields on the root endpoint
+	AppContract string `json:"-"`
 }
 
 // Representation of a transaction that can be carried out on the base layer blockchain, such as a
@@ -38,7 +41,7 @@ type Voucher struct {
 	// Voucher index within the context of the input that produced it
 	Index int `json:"index"`
 	// Index of the input
-	InputIndex int
+	InputIndex int `json:"inputIndex"`
 	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with
 	// '0x'
 	Destination string `json:"destination"`
@@ -52,13 +55,16 @@ type Voucher struct {
 	Proof Proof `json:"proof"`
 
 	TransactionHash string `json:"transactionHash"`
+
+	// Address of the application, resolving the nested fields on the root endpoint
+	AppContract string `json:"-"`
 }
 
 type DelegateCallVoucher struct {
 	// Voucher index within the context of the input that produced it
 	Index int `json:"index"`
 	// Index of the input
-	InputIndex int
+	InputIndex int `json:"inputIndex"`
 	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with
 	// '0x'
 	Destination string `json:"destination"`
@@ -70,6 +76,9 @@ type DelegateCallVoucher struct {
 	Proof Proof `json:"proof"`
 
 	TransactionHash string `json:"transactionHash"`
+
+	// Address of the application, resolving the nested fields on the root endpoint
+	AppContract string `json:"-"`
 }
 
 type Proof struct {
@@ -82,9 +91,12 @@ type Report struct {
 	// Report index within the context of the input that produced it
 	Index int `json:"index"`
 	// Index of the input
-	InputIndex int
+	InputIndex int `json:"inputIndex"`
 	// Report data as a payload in Ethereum hex binary format, starting with '0x'
 	Payload string `json:"payload"`
+
+	// Address of the application, resolving the nested fields on the root endpoint
+	AppContract string `json:"-"`
 }
 
 // Informational statement that can be validated in the base layer blockchain
@@ -92,11 +104,14 @@ type Notice struct {
 	// Notice index within the context of the input that produced it
 	Index int `json:"index"`
 	// Index of the input
-	InputIndex int
+	InputIndex int `json:"inputIndex"`
 	// Notice data as a payload in Ethereum hex binary format, starting with '0x'
 	Payload string `json:"payload"`
 	// InputId string
 	Proof Proof `json:"proof"`
+
+	// Address of the application, resolving the nested fields on the root endpoint
+	AppContract string `json:"-"`
 }
 
 //
@@ -0,0 +1,162 @@
+package reader
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/carlmjohnson/versioninfo"
+	graphql "github.com/cartesi/rollups-graphql/v2/pkg/reader/model"
+)
+
+const OPENAPI_VERSION = "3.0.3"
+
+var pathParamRegex = regexp.MustCompile(`:(\w+)`)
+
+// newOpenAPI generates the OpenAPI document of the REST routes,
+// deriving the schemas from the JSON encoding of the returned types.
+func newOpenAPI(routes []restRoute) map[string]any {
+	schemas := map[string]any{}
+	paths := map[string]any{}
+	for _, route := range routes {
+		schema := schemaRef(schemas, route.item)
+		if route.paginated {
+			schema = pageSchemaRef(schemas, route.item)
+		}
+		parameters := []any{}
+		for _, match := range pathParamRegex.FindAllStringSubmatch(route.path, -1) {
+			parameters = append(parameters, pathParameter(match[1]))
+		}
+		for _, param := range route.query {
+			parameters = append(parameters, map[string]any{
+				"name":        param.name,
+				"in":          "query",
+				"description": param.description,
+				"schema":      kindSchema(param.kind),
+			})
+		}
+		responses := map[string]any{
+			"200": map[string]any{
+				"description": route.summary,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": schema},
+				},
+			},
+			"400": map[string]any{"description": "Invalid parameter"},
+		}
+		if !route.paginated {
+			responses["404"] = map[string]any{"description": "Not found"}
+		}
+		paths[pathParamRegex.ReplaceAllString(route.path, "{$1}")] = map[string]any{
+			"get": map[string]any{
+				"summary":    route.summary,
+				"parameters": parameters,
+				"responses":  responses,
+			},
+		}
+	}
+	return map[string]any{
+		"openapi": OPENAPI_VERSION,
+		"info": map[string]any{
+			"title":       "Cartesi Rollups GraphQL REST API",
+			"description": "Read-only REST mirror of the GraphQL reader API",
+			"version":     versioninfo.Short(),
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+func pathParameter(name string) map[string]any {
+	schema := map[string]any{"type": "integer", "minimum": 0}
+	if name == "appContract" {
+		schema = map[string]any{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+	}
+	return map[string]any{"name": name, "in": "path", "required": true, "schema": schema}
+}
+
+func kindSchema(kind reflect.Kind) map[string]any {
+	switch kind {
+	case reflect.Int, reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func pageSchemaRef(schemas map[string]any, item reflect.Type) map[string]any {
+	name := item.Name() + "Page"
+	if _, ok := schemas[name]; !ok {
+		schemas[name] = map[string]any{
+			"type":     "object",
+			"required": []string{"totalCount", "items", "pageInfo"},
+			"properties": map[string]any{
+				"totalCount": map[string]any{"type": "integer"},
+				"items":      map[string]any{"type": "array", "items": schemaRef(schemas, item)},
+				"pageInfo":   schemaRef(schemas, reflect.TypeFor[graphql.PageInfo]()),
+			},
+		}
+	}
+	return ref(name)
+}
+
+// schemaRef adds the schema of the struct to the components and returns a reference to it.
+func schemaRef(schemas map[string]any, t reflect.Type) map[string]any {
+	if _, ok := schemas[t.Name()]; ok {
+		return ref(t.Name())
+	}
+	// set before the fields, so recursive types end
+	schemas[t.Name()] = nil
+	properties := map[string]any{}
+	required := []string{}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(schemas, field.Type)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	schemas[t.Name()] = map[string]any{
+		"type":       "object",
+		"required":   required,
+		"properties": properties,
+	}
+	return ref(t.Name())
+}
+
+func typeSchema(schemas map[string]any, t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := typeSchema(schemas, t.Elem())
+		if t.Elem().Kind() == reflect.Struct {
+			// a reference cannot have siblings, so it is wrapped to be made nullable
+			schema = map[string]any{"allOf": []any{schema}}
+		}
+		schema["nullable"] = true
+		return schema
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(schemas, t.Elem())}
+	case reflect.Struct:
+		return schemaRef(schemas, t)
+	}
+	if t == reflect.TypeFor[graphql.CompletionStatus]() {
+		return map[string]any{"type": "string", "enum": graphql.AllCompletionStatus}
+	}
+	return kindSchema(t.Kind())
+}
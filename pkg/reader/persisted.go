@@ -0,0 +1,111 @@
+package reader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	DefaultPersistedQueryCacheSize = 100
+
+	errOperationNotAllowed = "OPERATION_NOT_ALLOWED"
+)
+
+// QueryAllowlist holds the only operations accepted in strict mode, by the
+// SHA-256 hash of their text as sent by the automatic persisted queries.
+type QueryAllowlist struct {
+	queries map[string]string
+}
+
+func NewQueryAllowlist(queries ...string) *QueryAllowlist {
+	allowlist := &QueryAllowlist{queries: make(map[string]string, len(queries))}
+	for _, query := range queries {
+		allowlist.queries[queryHash(query)] = query
+	}
+	return allowlist
+}
+
+// LoadQueryAllowlist reads the allowlist from a JSON file mapping the hex SHA-256 hash
+// of each query to its text, the manifest generated by the persisted query tools.
+func LoadQueryAllowlist(path string) (*QueryAllowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest map[string]string
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid allowlist %s: %w", path, err)
+	}
+	allowlist := &QueryAllowlist{queries: make(map[string]string, len(manifest))}
+	for hash, query := range manifest {
+		if queryHash(query) != hash {
+			return nil, fmt.Errorf("invalid allowlist %s: hash %s does not match its query", path, hash)
+		}
+		allowlist.queries[hash] = query
+	}
+	return allowlist, nil
+}
+
+func (a *QueryAllowlist) Len() int {
+	return len(a.queries)
+}
+
+func queryHash(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
+
+// allowlistExtension rejects the operations outside the allowlist. Clients may send
+// only the hash of an allowed query, without registering it first.
+// It must run before the automatic persisted queries, which would not know the hash.
+type allowlistExtension struct {
+	allowlist *QueryAllowlist
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = allowlistExtension{}
+
+func (allowlistExtension) ExtensionName() string {
+	return "QueryAllowlist"
+}
+
+func (allowlistExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a allowlistExtension) MutateOperationParameters(
+	ctx context.Context, rawParams *graphql.RawParams,
+) *gqlerror.Error {
+	hash := persistedQueryHash(rawParams)
+	if rawParams.Query == "" {
+		if query, ok := a.allowlist.queries[hash]; ok {
+			rawParams.Query = query
+			return nil
+		}
+	} else if _, ok := a.allowlist.queries[queryHash(rawParams.Query)]; ok {
+		return nil
+	}
+	err := gqlerror.Errorf("operation is not in the allowlist")
+	errcode.Set(err, errOperationNotAllowed)
+	return err
+}
+
+// persistedQueryHash returns the hash of the persisted query extension, if any.
+func persistedQueryHash(rawParams *graphql.RawParams) string {
+	extension, ok := rawParams.Extensions["persistedQuery"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	hash, _ := extension["sha256Hash"].(string)
+	return hash
+}
@@ -0,0 +1,151 @@
+package reader
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type PersistedSuite struct {
+	suite.Suite
+	ctx       context.Context
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	service   *services.ConvenienceService
+}
+
+const allowedQuery = `query { reports { totalCount } }`
+
+func (s *PersistedSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "persisted.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	s.service = services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		inputRepository,
+		&cRepos.ReportRepository{Db: s.db},
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+}
+
+func (s *PersistedSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestPersistedSuite(t *testing.T) {
+	suite.Run(t, new(PersistedSuite))
+}
+
+func (s *PersistedSuite) newEcho(opts GraphQLOpts) *echo.Echo {
+	e := echo.New()
+	Register(s.ctx, e, s.service, NewAdapterV1(s.ctx, s.db, s.service), opts)
+	return e
+}
+
+func persistedQuery(hash string) map[string]any {
+	return map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": hash}}
+}
+
+func (s *PersistedSuite) TestAutomaticPersistedQuery() {
+	e := s.newEcho(NewGraphQLOpts())
+	hash := queryHash(allowedQuery)
+
+	response := postGraphQL(&s.Suite, e, map[string]any{"extensions": persistedQuery(hash)})
+	s.Equal("PERSISTED_QUERY_NOT_FOUND", errorCode(&s.Suite, response))
+
+	response = postGraphQL(&s.Suite, e, map[string]any{"query": allowedQuery, "extensions": persistedQuery(hash)})
+	s.Empty(response.Errors)
+
+	response = postGraphQL(&s.Suite, e, map[string]any{"extensions": persistedQuery(hash)})
+	s.Empty(response.Errors)
+	s.Contains(response.Data, "reports")
+}
+
+func (s *PersistedSuite) TestAllowlist() {
+	opts := NewGraphQLOpts()
+	opts.Allowlist = NewQueryAllowlist(allowedQuery)
+	e := s.newEcho(opts)
+
+	response := postGraphQL(&s.Suite, e, map[string]any{"query": allowedQuery})
+	s.Empty(response.Errors)
+
+	// the allowed queries do not need to be registered before sending their hash
+	response = postGraphQL(&s.Suite, e, map[string]any{"extensions": persistedQuery(queryHash(allowedQuery))})
+	s.Empty(response.Errors)
+	s.Contains(response.Data, "reports")
+
+	response = postGraphQL(&s.Suite, e, map[string]any{"query": `query { inputs { totalCount } }`})
+	s.Equal(errOperationNotAllowed, errorCode(&s.Suite, response))
+
+	response = postGraphQL(&s.Suite, e, map[string]any{"extensions": persistedQuery(queryHash("query { x }"))})
+	s.Equal(errOperationNotAllowed, errorCode(&s.Suite, response))
+}
+
+func (s *PersistedSuite) TestAllowlistDisablesIntrospectionAndPlayground() {
+	introspection := `query { __schema { queryType { name } } }`
+	opts := NewGraphQLOpts()
+	opts.Allowlist = NewQueryAllowlist(introspection)
+	e := s.newEcho(opts)
+
+	response := postGraphQL(&s.Suite, e, map[string]any{"query": introspection})
+	s.Require().Len(response.Errors, 1)
+	s.Contains(response.Errors[0].Message, "introspection disabled")
+
+	for _, target := range []string{"/graphql", "/graphql/" + ApplicationAddress} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		s.Equal(http.StatusMethodNotAllowed, rec.Code)
+	}
+}
+
+func (s *PersistedSuite) TestAllowlistClosesTheOtherAPIs() {
+	opts := NewGraphQLOpts()
+	opts.Allowlist = NewQueryAllowlist(allowedQuery)
+	targets := []string{"/apps", "/openapi.json", "/api/v1/apps/" + ApplicationAddress + "/inputs"}
+	e := s.newEcho(opts)
+	for _, target := range targets {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		s.Equal(http.StatusNotFound, rec.Code, target)
+	}
+
+	opts.AllowlistOpenAPIs = true
+	e = s.newEcho(opts)
+	for _, target := range targets {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		s.Equal(http.StatusOK, rec.Code, target)
+	}
+}
+
+func (s *PersistedSuite) TestLoadQueryAllowlist() {
+	path := filepath.Join(s.T().TempDir(), "allowlist.json")
+	s.Require().NoError(os.WriteFile(path, []byte(`{"`+queryHash(allowedQuery)+`": "`+allowedQuery+`"}`), 0600))
+	allowlist, err := LoadQueryAllowlist(path)
+	s.Require().NoError(err)
+	s.Equal(1, allowlist.Len())
+
+	s.Require().NoError(os.WriteFile(path, []byte(`{"1234": "`+allowedQuery+`"}`), 0600))
+	_, err = LoadQueryAllowlist(path)
+	s.ErrorContains(err, "hash 1234 does not match its query")
+}
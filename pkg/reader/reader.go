@@ -10,50 +10,96 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
 	"github.com/cartesi/rollups-graphql/v2/pkg/reader/graph"
 	"github.com/cartesi/rollups-graphql/v2/pkg/reader/loaders"
+	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
-// Register the GraphQL reader API to echo.
+// Options of the GraphQL reader API.
+type GraphQLOpts struct {
+	Limits QueryLimits
+	// Size of the cache of the automatic persisted queries, by hash
+	PersistedQueryCacheSize int
+	// Accept only the operations of the allowlist, disabling introspection, the playground
+	// and the REST and streaming APIs, which it does not cover
+	Allowlist *QueryAllowlist
+	// Keep serving the REST, streaming and gRPC APIs along with the allowlist
+	AllowlistOpenAPIs bool
+	// Trace the operations and the field resolvers
+	Tracing bool
+	// Optional cache of the responses of the POST requests
+	Cache *ResponseCache
+	// Optional router of the reads to a replica of the database
+	ReadRouter *repository.ReadRouter
+}
+
+// Create the options with the default values.
+func NewGraphQLOpts() GraphQLOpts {
+	return GraphQLOpts{
+		Limits:                  NewQueryLimits(),
+		PersistedQueryCacheSize: DefaultPersistedQueryCacheSize,
+	}
+}
+
+// Register the GraphQL reader API, its REST mirror and the streaming endpoints to echo.
+// With the allowlist only the GraphQL API is served, unless AllowlistOpenAPIs is set.
 func Register(
 	ctx context.Context,
 	e *echo.Echo,
 	convenienceService *services.ConvenienceService,
 	adapter Adapter,
+	opts GraphQLOpts,
 ) {
 	resolver := Resolver{
 		convenienceService,
 		adapter,
 	}
 	config := graph.Config{Resolvers: &resolver}
+	setConnectionComplexity(&config.Complexity)
 	schema := graph.NewExecutableSchema(config)
-	graphqlHandler := handler.NewDefaultServer(schema)
+	graphqlHandler := newGraphQLHandler(schema, convenienceService.InputRepository.Db, opts)
 	playgroundHandler := playground.Handler("GraphQL", "/graphql")
+	if opts.ReadRouter != nil {
+		e.Use(readRouting(opts.ReadRouter))
+	}
 	e.POST("/graphql", func(c echo.Context) error {
-		graphqlHandler.ServeHTTP(c.Response(), c.Request())
+		ctx := withLoaders(c.Request().Context(), convenienceService)
+		c.SetRequest(c.Request().WithContext(ctx))
+		cachedHandler(opts.Cache, "", graphqlHandler).ServeHTTP(c.Response(), c.Request())
 		return nil
 	})
 	e.POST("/graphql/:appContract", func(c echo.Context) error {
 		appContract := c.Param("appContract")
 		slog.DebugContext(ctx, "path parameter received: ", "app_contract", appContract)
 		ctx := context.WithValue(c.Request().Context(), cModel.AppContractKey, appContract)
-		loader := loaders.NewLoaders(
-			convenienceService.ReportRepository,
-			convenienceService.VoucherRepository,
-			convenienceService.NoticeRepository,
-			convenienceService.InputRepository,
-		)
-		ctx = context.WithValue(ctx, loaders.LoadersKey, loader)
+		ctx = withLoaders(ctx, convenienceService)
 		c.SetRequest(c.Request().WithContext(ctx))
-		graphqlHandler.ServeHTTP(c.Response(), c.Request())
+		cachedHandler(opts.Cache, appContract, graphqlHandler).ServeHTTP(c.Response(), c.Request())
 		return nil
 	})
+	if opts.Allowlist == nil || opts.AllowlistOpenAPIs {
+		registerStream(e, convenienceService)
+		registerRest(e, adapter)
+	}
+	if opts.Allowlist != nil {
+		slog.InfoContext(ctx, "graphql: allowlist enforced",
+			"operations", opts.Allowlist.Len(), "open_apis", opts.AllowlistOpenAPIs)
+		return
+	}
 	e.GET("/graphql", func(c echo.Context) error {
 		playgroundHandler.ServeHTTP(c.Response(), c.Request())
 		return nil
@@ -68,3 +114,54 @@ func Register(
 		return nil
 	})
 }
+
+// newGraphQLHandler creates the GraphQL server with the transports of gqlgen's default
+// server, the query limits, the snapshot of the database of each query and, when enforced,
+// the allowlist instead of introspection.
+func newGraphQLHandler(schema graphql.ExecutableSchema, db *sqlx.DB, opts GraphQLOpts) *handler.Server {
+	server := handler.New(schema)
+	server.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+	})
+	server.AddTransport(transport.Options{})
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.AddTransport(transport.MultipartForm{})
+	server.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	if opts.Tracing {
+		server.Use(tracingExtension{})
+	}
+	server.Use(snapshotExtension{db})
+	if opts.Allowlist != nil {
+		server.Use(allowlistExtension{opts.Allowlist})
+	} else {
+		server.Use(extension.Introspection{})
+	}
+	if opts.PersistedQueryCacheSize > 0 {
+		server.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New[string](opts.PersistedQueryCacheSize),
+		})
+	}
+	useQueryLimits(server, opts.Limits)
+	return server
+}
+
+// withLoaders adds new data loaders to the context, batching the fields of the request.
+func withLoaders(ctx context.Context, convenienceService *services.ConvenienceService) context.Context {
+	loader := loaders.NewLoaders(
+		convenienceService.ReportRepository,
+		convenienceService.VoucherRepository,
+		convenienceService.NoticeRepository,
+		convenienceService.InputRepository,
+		convenienceService.ApplicationRepository,
+	)
+	return context.WithValue(ctx, loaders.LoadersKey, loader)
+}
+
+// cachedHandler puts the response cache, when enabled, in front of the handler.
+func cachedHandler(cache *ResponseCache, app string, handler http.Handler) http.Handler {
+	if cache == nil {
+		return handler
+	}
+	return cache.Handler(app, handler)
+}
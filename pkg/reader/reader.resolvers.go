@@ -15,42 +15,46 @@ import (
 
 // Input is the resolver for the input field.
 func (r *delegateCallVoucherResolver) Input(ctx context.Context, obj *model.DelegateCallVoucher) (*model.Input, error) {
-	return r.adapter.GetInputByIndex(ctx, obj.InputIndex)
+	return r.adapter.GetInputByIndex(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // Application is the resolver for the application field.
 func (r *delegateCallVoucherResolver) Application(ctx context.Context, obj *model.DelegateCallVoucher) (*model.Application, error) {
-	return r.adapter.GetApplicationByAppContract(ctx, obj.InputIndex)
+	return r.adapter.GetApplicationByAppContract(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // Vouchers is the resolver for the vouchers field.
 func (r *inputResolver) Vouchers(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.Voucher], error) {
-	if first == nil && last == nil && after == nil && before == nil {
-		return r.adapter.GetAllVouchersByInputIndex(ctx, &obj.Index)
+	ctx = withAppContract(ctx, obj.AppContract)
+	if last == nil && before == nil {
+		return r.adapter.GetVouchersByInputIndex(ctx, obj.Index, first, after)
 	}
 	return r.adapter.GetVouchers(ctx, first, last, after, before, &obj.Index, nil)
 }
 
 // DelegateCallVouchers is the resolver for the delegateCallVouchers field.
 func (r *inputResolver) DelegateCallVouchers(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.DelegateCallVoucher], error) {
-	if first == nil && last == nil && after == nil && before == nil {
-		return r.adapter.GetAllDelegateCallVouchersByInputIndex(ctx, &obj.Index)
+	ctx = withAppContract(ctx, obj.AppContract)
+	if last == nil && before == nil {
+		return r.adapter.GetDelegateCallVouchersByInputIndex(ctx, obj.Index, first, after)
 	}
 	return r.adapter.GetDelegateCallVouchers(ctx, first, last, after, before, &obj.Index, nil)
 }
 
 // Notices is the resolver for the notices field.
 func (r *inputResolver) Notices(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.Notice], error) {
-	if first == nil && last == nil && after == nil && before == nil {
-		return r.adapter.GetAllNoticesByInputIndex(ctx, &obj.Index)
+	ctx = withAppContract(ctx, obj.AppContract)
+	if last == nil && before == nil {
+		return r.adapter.GetNoticesByInputIndex(ctx, obj.Index, first, after)
 	}
 	return r.adapter.GetNotices(ctx, first, last, after, before, &obj.Index)
 }
 
 // Reports is the resolver for the reports field.
 func (r *inputResolver) Reports(ctx context.Context, obj *model.Input, first *int, last *int, after *string, before *string) (*model.Connection[*model.Report], error) {
-	if first == nil && last == nil && after == nil && before == nil {
-		return r.adapter.GetAllReportsByInputIndex(ctx, &obj.Index)
+	ctx = withAppContract(ctx, obj.AppContract)
+	if last == nil && before == nil {
+		return r.adapter.GetReportsByInputIndex(ctx, obj.Index, first, after)
 	}
 	return r.adapter.GetReports(ctx, first, last, after, before, &obj.Index)
 }
@@ -61,13 +65,13 @@ func (r *inputResolver) Application(ctx context.Context, obj *model.Input) (*mod
 	if err != nil {
 		return nil, err
 	}
-	return r.adapter.GetApplicationByAppContract(ctx, inputBoxIndex)
+	return r.adapter.GetApplicationByAppContract(withAppContract(ctx, obj.AppContract), inputBoxIndex)
 }
 
 // Input is the resolver for the input field.
 func (r *noticeResolver) Input(ctx context.Context, obj *model.Notice) (*model.Input, error) {
 	slog.DebugContext(ctx, "Find input by index", "inputIndex", obj.InputIndex)
-	input, err := r.adapter.GetInputByIndex(ctx, obj.InputIndex)
+	input, err := r.adapter.GetInputByIndex(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 	if err != nil {
 		slog.ErrorContext(ctx, "Input not found")
 		return nil, err
@@ -77,7 +81,7 @@ func (r *noticeResolver) Input(ctx context.Context, obj *model.Notice) (*model.I
 
 // Application is the resolver for the application field.
 func (r *noticeResolver) Application(ctx context.Context, obj *model.Notice) (*model.Application, error) {
-	return r.adapter.GetApplicationByAppContract(ctx, obj.InputIndex)
+	return r.adapter.GetApplicationByAppContract(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // Input is the resolver for the input field.
@@ -142,22 +146,22 @@ func (r *queryResolver) Applications(ctx context.Context, first *int, last *int,
 
 // Input is the resolver for the input field.
 func (r *reportResolver) Input(ctx context.Context, obj *model.Report) (*model.Input, error) {
-	return r.adapter.GetInputByIndex(ctx, obj.InputIndex)
+	return r.adapter.GetInputByIndex(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // Application is the resolver for the application field.
 func (r *reportResolver) Application(ctx context.Context, obj *model.Report) (*model.Application, error) {
-	return r.adapter.GetApplicationByAppContract(ctx, obj.InputIndex)
+	return r.adapter.GetApplicationByAppContract(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // Input is the resolver for the input field.
 func (r *voucherResolver) Input(ctx context.Context, obj *model.Voucher) (*model.Input, error) {
-	return r.adapter.GetInputByIndex(ctx, obj.InputIndex)
+	return r.adapter.GetInputByIndex(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // Application is the resolver for the application field.
 func (r *voucherResolver) Application(ctx context.Context, obj *model.Voucher) (*model.Application, error) {
-	return r.adapter.GetApplicationByAppContract(ctx, obj.InputIndex)
+	return r.adapter.GetApplicationByAppContract(withAppContract(ctx, obj.AppContract), obj.InputIndex)
 }
 
 // DelegateCallVoucher returns graph.DelegateCallVoucherResolver implementation.
@@ -0,0 +1,275 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const OtherApplicationAddress = "0x544a3B76B84b1E98c13437A1591E713Dd314387F"
+
+const nestedFieldsQuery = `query {
+	inputs(first: 10) {
+		edges {
+			node {
+				index
+				vouchers(first: 2, after: "MA==") { totalCount edges { node { index } } }
+				delegateCallVouchers(first: 10) { totalCount edges { node { index } } }
+				notices { totalCount }
+				reports(first: 0) { totalCount edges { node { index } } }
+				application { name address }
+			}
+		}
+	}
+}`
+
+type nestedResponse struct {
+	Data struct {
+		Inputs struct {
+			Edges []struct {
+				Node struct {
+					Index                int
+					Vouchers             outputConnection
+					DelegateCallVouchers outputConnection
+					Notices              outputConnection
+					Reports              outputConnection
+					Application          struct {
+						Name    string
+						Address string
+					}
+				}
+			}
+		}
+	}
+	Errors []any
+}
+
+type outputConnection struct {
+	TotalCount int
+	Edges      []struct {
+		Node struct {
+			Index int
+		}
+	}
+}
+
+func (c outputConnection) indexes() []int {
+	indexes := []int{}
+	for _, edge := range c.Edges {
+		indexes = append(indexes, edge.Node.Index)
+	}
+	return indexes
+}
+
+type ReaderSuite struct {
+	suite.Suite
+	ctx       context.Context
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	echo      *echo.Echo
+	recorder  *tracetest.SpanRecorder
+}
+
+func (s *ReaderSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "reader.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	service := services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		inputRepository,
+		&cRepos.ReportRepository{Db: s.db},
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+	s.createTestData(service)
+	s.echo = echo.New()
+	Register(s.ctx, s.echo, service, NewAdapterV1(s.ctx, s.db, service), NewGraphQLOpts())
+
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *ReaderSuite) TearDownTest() {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestReaderSuite(t *testing.T) {
+	suite.Run(t, new(ReaderSuite))
+}
+
+// createTestData creates two inputs for each application, each with three vouchers,
+// a delegate call voucher, a notice and two reports.
+func (s *ReaderSuite) createTestData(service *services.ConvenienceService) {
+	for a, address := range []string{ApplicationAddress, OtherApplicationAddress} {
+		appContract := common.HexToAddress(address)
+		_, err := service.ApplicationRepository.Create(s.ctx, &cModel.ConvenienceApplication{
+			ID:                 uint64(a + 1),
+			Name:               fmt.Sprintf("app%d", a),
+			ApplicationAddress: appContract.Hex(),
+		})
+		s.Require().NoError(err)
+		for i := range 2 {
+			_, err := service.InputRepository.Create(s.ctx, cModel.AdvanceInput{
+				ID:             fmt.Sprintf("%d-%d", a, i),
+				Index:          i,
+				Status:         cModel.CompletionStatusAccepted,
+				Payload:        "0x1122",
+				BlockTimestamp: time.Now(),
+				AppContract:    appContract,
+			})
+			s.Require().NoError(err)
+			for j := range 4 {
+				_, err = service.VoucherRepository.CreateVoucher(s.ctx, &cModel.ConvenienceVoucher{
+					AppContract:     appContract,
+					InputIndex:      uint64(i),
+					OutputIndex:     uint64(i*10 + j),
+					IsDelegatedCall: j == 3,
+				})
+				s.Require().NoError(err)
+			}
+			_, err = service.NoticeRepository.Create(s.ctx, &cModel.ConvenienceNotice{
+				AppContract: appContract.Hex(),
+				InputIndex:  uint64(i),
+				OutputIndex: uint64(i*10 + 4),
+			})
+			s.Require().NoError(err)
+			for j := range 2 {
+				_, err = service.ReportRepository.CreateReport(s.ctx, cModel.Report{
+					AppContract: appContract,
+					InputIndex:  i,
+					Index:       i*2 + j,
+				})
+				s.Require().NoError(err)
+			}
+		}
+	}
+}
+
+func (s *ReaderSuite) query(target string) nestedResponse {
+	body, err := json.Marshal(map[string]string{"query": nestedFieldsQuery})
+	s.Require().NoError(err)
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	s.Require().Equal(http.StatusOK, rec.Code)
+	var response nestedResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response), rec.Body.String())
+	s.Require().Empty(response.Errors, rec.Body.String())
+	return response
+}
+
+// batches counts the batches of each loader.
+func (s *ReaderSuite) batches() map[string]int {
+	batches := map[string]int{}
+	for _, span := range s.recorder.Ended() {
+		if strings.HasPrefix(span.Name(), "loader.") {
+			batches[span.Name()]++
+		}
+	}
+	return batches
+}
+
+func (s *ReaderSuite) TestNestedFieldsOnTheRootEndpoint() {
+	response := s.query("/graphql")
+	edges := response.Data.Inputs.Edges
+	s.Require().Len(edges, 4)
+	apps := map[string]int{}
+	for _, edge := range edges {
+		node := edge.Node
+		apps[node.Application.Address]++
+		// the fields are scoped to the application of the input
+		s.Equal(3, node.Vouchers.TotalCount)
+		s.Equal([]int{node.Index*10 + 1, node.Index*10 + 2}, node.Vouchers.indexes())
+		s.Equal(1, node.DelegateCallVouchers.TotalCount)
+		s.Equal([]int{node.Index*10 + 3}, node.DelegateCallVouchers.indexes())
+		s.Equal(1, node.Notices.TotalCount)
+		s.Equal(2, node.Reports.TotalCount)
+		s.Empty(node.Reports.indexes())
+	}
+	s.Equal(map[string]int{ApplicationAddress: 2, OtherApplicationAddress: 2}, apps)
+	// the fields of the inputs are batched, usually in a single batch per loader
+	batches := s.batches()
+	for _, loader := range []string{"vouchers", "delegate_call_vouchers", "notices", "reports", "applications"} {
+		s.Positive(batches["loader."+loader], loader)
+		s.Less(batches["loader."+loader], len(edges), loader)
+	}
+}
+
+func (s *ReaderSuite) TestNestedFieldsOnTheAppEndpoint() {
+	response := s.query("/graphql/" + OtherApplicationAddress)
+	edges := response.Data.Inputs.Edges
+	s.Require().Len(edges, 2)
+	for _, edge := range edges {
+		s.Equal("app1", edge.Node.Application.Name)
+		s.Equal(3, edge.Node.Vouchers.TotalCount)
+		s.Equal(1, edge.Node.DelegateCallVouchers.TotalCount)
+	}
+	s.Less(s.batches()["loader.vouchers"], len(edges))
+}
+
+func (s *ReaderSuite) TestQueriesReadASnapshot() {
+	extension := snapshotExtension{s.db}
+	for operation, snapshot := range map[ast.Operation]bool{
+		ast.Query:        true,
+		ast.Subscription: false,
+	} {
+		ctx := graphql.WithOperationContext(s.ctx, &graphql.OperationContext{
+			Operation: &ast.OperationDefinition{Operation: operation},
+		})
+		extension.InterceptResponse(ctx, func(ctx context.Context) *graphql.Response {
+			_, ok := cRepos.GetTransaction(ctx)
+			s.Equal(snapshot, ok, operation)
+			return &graphql.Response{}
+		})
+	}
+}
+
+func (s *ReaderSuite) TestReadsFromTheReplica() {
+	replica := s.dbFactory.CreateDb(s.ctx, "replica.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, replica))
+	defer replica.Close()
+	s.Require().NoError((&cRepos.InputRepository{Db: replica}).CreateTables(s.ctx))
+	service := services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		&cRepos.InputRepository{Db: s.db},
+		&cRepos.ReportRepository{Db: s.db},
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+	opts := NewGraphQLOpts()
+	opts.ReadRouter = cRepos.NewReadRouter(s.db, replica, 0)
+	s.echo = echo.New()
+	Register(s.ctx, s.echo, service, NewAdapterV1(s.ctx, s.db, service), opts)
+	// the replica is empty
+	s.Empty(s.query("/graphql").Data.Inputs.Edges)
+}
@@ -0,0 +1,2867 @@
+// Copyright (c) Gabriel de Quadros Ligneul
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// gRPC mirror of the GraphQL reader API described by reader.graphql.
+// Nested fields resolved by GraphQL, such as input.vouchers or voucher.input,
+// are fetched with the list and get calls filtered by the input index.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: reader.proto
+
+package readerpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CompletionStatus int32
+
+const (
+	CompletionStatus_COMPLETION_STATUS_UNSPECIFIED                   CompletionStatus = 0
+	CompletionStatus_COMPLETION_STATUS_UNPROCESSED                   CompletionStatus = 1
+	CompletionStatus_COMPLETION_STATUS_ACCEPTED                      CompletionStatus = 2
+	CompletionStatus_COMPLETION_STATUS_REJECTED                      CompletionStatus = 3
+	CompletionStatus_COMPLETION_STATUS_EXCEPTION                     CompletionStatus = 4
+	CompletionStatus_COMPLETION_STATUS_MACHINE_HALTED                CompletionStatus = 5
+	CompletionStatus_COMPLETION_STATUS_CYCLE_LIMIT_EXCEEDED          CompletionStatus = 6
+	CompletionStatus_COMPLETION_STATUS_TIME_LIMIT_EXCEEDED           CompletionStatus = 7
+	CompletionStatus_COMPLETION_STATUS_PAYLOAD_LENGTH_LIMIT_EXCEEDED CompletionStatus = 8
+)
+
+// Enum value maps for CompletionStatus.
+var (
+	CompletionStatus_name = map[int32]string{
+		0: "COMPLETION_STATUS_UNSPECIFIED",
+		1: "COMPLETION_STATUS_UNPROCESSED",
+		2: "COMPLETION_STATUS_ACCEPTED",
+		3: "COMPLETION_STATUS_REJECTED",
+		4: "COMPLETION_STATUS_EXCEPTION",
+		5: "COMPLETION_STATUS_MACHINE_HALTED",
+		6: "COMPLETION_STATUS_CYCLE_LIMIT_EXCEEDED",
+		7: "COMPLETION_STATUS_TIME_LIMIT_EXCEEDED",
+		8: "COMPLETION_STATUS_PAYLOAD_LENGTH_LIMIT_EXCEEDED",
+	}
+	CompletionStatus_value = map[string]int32{
+		"COMPLETION_STATUS_UNSPECIFIED":                   0,
+		"COMPLETION_STATUS_UNPROCESSED":                   1,
+		"COMPLETION_STATUS_ACCEPTED":                      2,
+		"COMPLETION_STATUS_REJECTED":                      3,
+		"COMPLETION_STATUS_EXCEPTION":                     4,
+		"COMPLETION_STATUS_MACHINE_HALTED":                5,
+		"COMPLETION_STATUS_CYCLE_LIMIT_EXCEEDED":          6,
+		"COMPLETION_STATUS_TIME_LIMIT_EXCEEDED":           7,
+		"COMPLETION_STATUS_PAYLOAD_LENGTH_LIMIT_EXCEEDED": 8,
+	}
+)
+
+func (x CompletionStatus) Enum() *CompletionStatus {
+	p := new(CompletionStatus)
+	*p = x
+	return p
+}
+
+func (x CompletionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CompletionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_reader_proto_enumTypes[0].Descriptor()
+}
+
+func (CompletionStatus) Type() protoreflect.EnumType {
+	return &file_reader_proto_enumTypes[0]
+}
+
+func (x CompletionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CompletionStatus.Descriptor instead.
+func (CompletionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{0}
+}
+
+// Data that can be used as proof to validate notices and execute vouchers on the base layer blockchain
+type Proof struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// BigInt
+	OutputIndex          string   `protobuf:"bytes,1,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	OutputHashesSiblings []string `protobuf:"bytes,2,rep,name=output_hashes_siblings,json=outputHashesSiblings,proto3" json:"output_hashes_siblings,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Proof) Reset() {
+	*x = Proof{}
+	mi := &file_reader_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proof) ProtoMessage() {}
+
+func (x *Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proof.ProtoReflect.Descriptor instead.
+func (*Proof) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Proof) GetOutputIndex() string {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return ""
+}
+
+func (x *Proof) GetOutputHashesSiblings() []string {
+	if x != nil {
+		return x.OutputHashesSiblings
+	}
+	return nil
+}
+
+// Request submitted to the application to advance its state
+type Input struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the input
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Input index starting from genesis
+	Index int64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// Status of the input
+	Status CompletionStatus `protobuf:"varint,3,opt,name=status,proto3,enum=cartesi.rollups.graphql.reader.v1.CompletionStatus" json:"status,omitempty"`
+	// Address responsible for submitting the input
+	MsgSender string `protobuf:"bytes,4,opt,name=msg_sender,json=msgSender,proto3" json:"msg_sender,omitempty"`
+	// Timestamp associated with the input submission, as defined by the base layer's block in which it was recorded
+	//
+	// Deprecated: Marked as deprecated in reader.proto.
+	Timestamp string `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Number of the base layer block in which the input was recorded
+	BlockNumber string `protobuf:"bytes,6,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// Input payload in Ethereum hex binary format, starting with '0x'
+	Payload string `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Timestamp associated with the Espresso input submission
+	//
+	// Deprecated: Marked as deprecated in reader.proto.
+	EspressoTimestamp string `protobuf:"bytes,8,opt,name=espresso_timestamp,json=espressoTimestamp,proto3" json:"espresso_timestamp,omitempty"`
+	// Number of the Espresso block in which the input was recorded
+	//
+	// Deprecated: Marked as deprecated in reader.proto.
+	EspressoBlockNumber string `protobuf:"bytes,9,opt,name=espresso_block_number,json=espressoBlockNumber,proto3" json:"espresso_block_number,omitempty"`
+	// Input index in the Input Box
+	InputBoxIndex  string `protobuf:"bytes,10,opt,name=input_box_index,json=inputBoxIndex,proto3" json:"input_box_index,omitempty"`
+	BlockTimestamp string `protobuf:"bytes,11,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	PrevRandao     string `protobuf:"bytes,12,opt,name=prev_randao,json=prevRandao,proto3" json:"prev_randao,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Input) Reset() {
+	*x = Input{}
+	mi := &file_reader_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Input) ProtoMessage() {}
+
+func (x *Input) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Input.ProtoReflect.Descriptor instead.
+func (*Input) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Input) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Input) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Input) GetStatus() CompletionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CompletionStatus_COMPLETION_STATUS_UNSPECIFIED
+}
+
+func (x *Input) GetMsgSender() string {
+	if x != nil {
+		return x.MsgSender
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in reader.proto.
+func (x *Input) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *Input) GetBlockNumber() string {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return ""
+}
+
+func (x *Input) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in reader.proto.
+func (x *Input) GetEspressoTimestamp() string {
+	if x != nil {
+		return x.EspressoTimestamp
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in reader.proto.
+func (x *Input) GetEspressoBlockNumber() string {
+	if x != nil {
+		return x.EspressoBlockNumber
+	}
+	return ""
+}
+
+func (x *Input) GetInputBoxIndex() string {
+	if x != nil {
+		return x.InputBoxIndex
+	}
+	return ""
+}
+
+func (x *Input) GetBlockTimestamp() string {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return ""
+}
+
+func (x *Input) GetPrevRandao() string {
+	if x != nil {
+		return x.PrevRandao
+	}
+	return ""
+}
+
+type Application struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Application ID
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Application name
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Application Address
+	Address       string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Application) Reset() {
+	*x = Application{}
+	mi := &file_reader_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Application) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Application) ProtoMessage() {}
+
+func (x *Application) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Application.ProtoReflect.Descriptor instead.
+func (*Application) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Application) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Application) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Application) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// Representation of a transaction that can be carried out on the base layer blockchain, such as a transfer of assets
+type Voucher struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Output index of the voucher
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Index of the input whose processing produced the voucher
+	InputIndex int64 `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with '0x'
+	Destination string `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Transaction payload in Ethereum hex binary format, starting with '0x'
+	Payload string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Proof object that allows this voucher to be validated and executed on the base layer blockchain
+	Proof *Proof `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+	// BigInt
+	Value string `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	// Indicates whether the voucher has been executed on the base layer blockchain
+	Executed bool `protobuf:"varint,7,opt,name=executed,proto3" json:"executed,omitempty"`
+	// The hash of executed transaction
+	TransactionHash string `protobuf:"bytes,8,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Voucher) Reset() {
+	*x = Voucher{}
+	mi := &file_reader_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Voucher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Voucher) ProtoMessage() {}
+
+func (x *Voucher) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Voucher.ProtoReflect.Descriptor instead.
+func (*Voucher) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Voucher) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Voucher) GetInputIndex() int64 {
+	if x != nil {
+		return x.InputIndex
+	}
+	return 0
+}
+
+func (x *Voucher) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Voucher) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Voucher) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *Voucher) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Voucher) GetExecuted() bool {
+	if x != nil {
+		return x.Executed
+	}
+	return false
+}
+
+func (x *Voucher) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+type DelegateCallVoucher struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Output index of the voucher
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Index of the input whose processing produced the voucher
+	InputIndex int64 `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	// Transaction destination address in Ethereum hex binary format (20 bytes), starting with '0x'
+	Destination string `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Transaction payload in Ethereum hex binary format, starting with '0x'
+	Payload string `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Proof object that allows this voucher to be validated and executed on the base layer blockchain
+	Proof *Proof `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+	// Indicates whether the voucher has been executed on the base layer blockchain
+	Executed bool `protobuf:"varint,6,opt,name=executed,proto3" json:"executed,omitempty"`
+	// The hash of executed transaction
+	TransactionHash string `protobuf:"bytes,7,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DelegateCallVoucher) Reset() {
+	*x = DelegateCallVoucher{}
+	mi := &file_reader_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelegateCallVoucher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegateCallVoucher) ProtoMessage() {}
+
+func (x *DelegateCallVoucher) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegateCallVoucher.ProtoReflect.Descriptor instead.
+func (*DelegateCallVoucher) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DelegateCallVoucher) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DelegateCallVoucher) GetInputIndex() int64 {
+	if x != nil {
+		return x.InputIndex
+	}
+	return 0
+}
+
+func (x *DelegateCallVoucher) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *DelegateCallVoucher) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *DelegateCallVoucher) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+func (x *DelegateCallVoucher) GetExecuted() bool {
+	if x != nil {
+		return x.Executed
+	}
+	return false
+}
+
+func (x *DelegateCallVoucher) GetTransactionHash() string {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return ""
+}
+
+// Informational statement that can be validated in the base layer blockchain
+type Notice struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Output index of the notice
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Index of the input whose processing produced the notice
+	InputIndex int64 `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	// Notice data as a payload in Ethereum hex binary format, starting with '0x'
+	Payload string `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Proof object that allows this notice to be validated by the base layer blockchain
+	Proof         *Proof `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notice) Reset() {
+	*x = Notice{}
+	mi := &file_reader_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notice) ProtoMessage() {}
+
+func (x *Notice) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notice.ProtoReflect.Descriptor instead.
+func (*Notice) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Notice) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Notice) GetInputIndex() int64 {
+	if x != nil {
+		return x.InputIndex
+	}
+	return 0
+}
+
+func (x *Notice) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Notice) GetProof() *Proof {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// Application log or diagnostic information
+type Report struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Report index
+	Index int64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Index of the input whose processing produced the report
+	InputIndex int64 `protobuf:"varint,2,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	// Report data as a payload in Ethereum hex binary format, starting with '0x'
+	Payload       string `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	mi := &file_reader_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Report) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Report) GetInputIndex() int64 {
+	if x != nil {
+		return x.InputIndex
+	}
+	return 0
+}
+
+func (x *Report) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+// Page metadata for the cursor-based Connection pagination pattern
+type PageInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor pointing to the first entry of the page
+	StartCursor *string `protobuf:"bytes,1,opt,name=start_cursor,json=startCursor,proto3,oneof" json:"start_cursor,omitempty"`
+	// Cursor pointing to the last entry of the page
+	EndCursor *string `protobuf:"bytes,2,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+	// Indicates if there are additional entries after the end cursor
+	HasNextPage bool `protobuf:"varint,3,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	// Indicates if there are additional entries before the start cursor
+	HasPreviousPage bool `protobuf:"varint,4,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_reader_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PageInfo) GetStartCursor() string {
+	if x != nil && x.StartCursor != nil {
+		return *x.StartCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetEndCursor() string {
+	if x != nil && x.EndCursor != nil {
+		return *x.EndCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+// Arguments of the cursor-based pagination, the same as in GraphQL
+type Pagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	First         *int32                 `protobuf:"varint,1,opt,name=first,proto3,oneof" json:"first,omitempty"`
+	Last          *int32                 `protobuf:"varint,2,opt,name=last,proto3,oneof" json:"last,omitempty"`
+	After         *string                `protobuf:"bytes,3,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	Before        *string                `protobuf:"bytes,4,opt,name=before,proto3,oneof" json:"before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_reader_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Pagination) GetFirst() int32 {
+	if x != nil && x.First != nil {
+		return *x.First
+	}
+	return 0
+}
+
+func (x *Pagination) GetLast() int32 {
+	if x != nil && x.Last != nil {
+		return *x.Last
+	}
+	return 0
+}
+
+func (x *Pagination) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *Pagination) GetBefore() string {
+	if x != nil && x.Before != nil {
+		return *x.Before
+	}
+	return ""
+}
+
+type InputEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Input                 `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputEdge) Reset() {
+	*x = InputEdge{}
+	mi := &file_reader_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputEdge) ProtoMessage() {}
+
+func (x *InputEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputEdge.ProtoReflect.Descriptor instead.
+func (*InputEdge) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InputEdge) GetNode() *Input {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *InputEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type InputConnection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Total number of entries that match the query
+	TotalCount    int64        `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Edges         []*InputEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo    `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputConnection) Reset() {
+	*x = InputConnection{}
+	mi := &file_reader_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputConnection) ProtoMessage() {}
+
+func (x *InputConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputConnection.ProtoReflect.Descriptor instead.
+func (*InputConnection) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InputConnection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *InputConnection) GetEdges() []*InputEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *InputConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type VoucherEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Voucher               `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoucherEdge) Reset() {
+	*x = VoucherEdge{}
+	mi := &file_reader_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoucherEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoucherEdge) ProtoMessage() {}
+
+func (x *VoucherEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoucherEdge.ProtoReflect.Descriptor instead.
+func (*VoucherEdge) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VoucherEdge) GetNode() *Voucher {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *VoucherEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type VoucherConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount    int64                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Edges         []*VoucherEdge         `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoucherConnection) Reset() {
+	*x = VoucherConnection{}
+	mi := &file_reader_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoucherConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoucherConnection) ProtoMessage() {}
+
+func (x *VoucherConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoucherConnection.ProtoReflect.Descriptor instead.
+func (*VoucherConnection) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VoucherConnection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *VoucherConnection) GetEdges() []*VoucherEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *VoucherConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type DelegateCallVoucherEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *DelegateCallVoucher   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DelegateCallVoucherEdge) Reset() {
+	*x = DelegateCallVoucherEdge{}
+	mi := &file_reader_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelegateCallVoucherEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegateCallVoucherEdge) ProtoMessage() {}
+
+func (x *DelegateCallVoucherEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegateCallVoucherEdge.ProtoReflect.Descriptor instead.
+func (*DelegateCallVoucherEdge) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DelegateCallVoucherEdge) GetNode() *DelegateCallVoucher {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *DelegateCallVoucherEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type DelegateCallVoucherConnection struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	TotalCount    int64                      `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Edges         []*DelegateCallVoucherEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo                  `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DelegateCallVoucherConnection) Reset() {
+	*x = DelegateCallVoucherConnection{}
+	mi := &file_reader_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelegateCallVoucherConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelegateCallVoucherConnection) ProtoMessage() {}
+
+func (x *DelegateCallVoucherConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelegateCallVoucherConnection.ProtoReflect.Descriptor instead.
+func (*DelegateCallVoucherConnection) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DelegateCallVoucherConnection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *DelegateCallVoucherConnection) GetEdges() []*DelegateCallVoucherEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *DelegateCallVoucherConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type NoticeEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Notice                `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoticeEdge) Reset() {
+	*x = NoticeEdge{}
+	mi := &file_reader_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoticeEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoticeEdge) ProtoMessage() {}
+
+func (x *NoticeEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoticeEdge.ProtoReflect.Descriptor instead.
+func (*NoticeEdge) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NoticeEdge) GetNode() *Notice {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *NoticeEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type NoticeConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount    int64                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Edges         []*NoticeEdge          `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoticeConnection) Reset() {
+	*x = NoticeConnection{}
+	mi := &file_reader_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoticeConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoticeConnection) ProtoMessage() {}
+
+func (x *NoticeConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoticeConnection.ProtoReflect.Descriptor instead.
+func (*NoticeConnection) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NoticeConnection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *NoticeConnection) GetEdges() []*NoticeEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *NoticeConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type ReportEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Report                `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportEdge) Reset() {
+	*x = ReportEdge{}
+	mi := &file_reader_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportEdge) ProtoMessage() {}
+
+func (x *ReportEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportEdge.ProtoReflect.Descriptor instead.
+func (*ReportEdge) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReportEdge) GetNode() *Report {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *ReportEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ReportConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount    int64                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Edges         []*ReportEdge          `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportConnection) Reset() {
+	*x = ReportConnection{}
+	mi := &file_reader_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportConnection) ProtoMessage() {}
+
+func (x *ReportConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportConnection.ProtoReflect.Descriptor instead.
+func (*ReportConnection) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReportConnection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ReportConnection) GetEdges() []*ReportEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *ReportConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type AppEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Node          *Application           `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppEdge) Reset() {
+	*x = AppEdge{}
+	mi := &file_reader_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppEdge) ProtoMessage() {}
+
+func (x *AppEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppEdge.ProtoReflect.Descriptor instead.
+func (*AppEdge) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AppEdge) GetNode() *Application {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *AppEdge) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type AppConnection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalCount    int64                  `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Edges         []*AppEdge             `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppConnection) Reset() {
+	*x = AppConnection{}
+	mi := &file_reader_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppConnection) ProtoMessage() {}
+
+func (x *AppConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppConnection.ProtoReflect.Descriptor instead.
+func (*AppConnection) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AppConnection) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *AppConnection) GetEdges() []*AppEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *AppConnection) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+// Filter object to restrict results depending on input properties
+type InputFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filter only inputs with index lower than a given value
+	IndexLowerThan *int64 `protobuf:"varint,1,opt,name=index_lower_than,json=indexLowerThan,proto3,oneof" json:"index_lower_than,omitempty"`
+	// Filter only inputs with index greater than a given value
+	IndexGreaterThan *int64 `protobuf:"varint,2,opt,name=index_greater_than,json=indexGreaterThan,proto3,oneof" json:"index_greater_than,omitempty"`
+	// Filter only inputs with the message sender
+	MsgSender *string `protobuf:"bytes,3,opt,name=msg_sender,json=msgSender,proto3,oneof" json:"msg_sender,omitempty"`
+	// Filter only inputs from 'inputbox' or 'espresso'
+	Type          *string `protobuf:"bytes,4,opt,name=type,proto3,oneof" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InputFilter) Reset() {
+	*x = InputFilter{}
+	mi := &file_reader_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InputFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InputFilter) ProtoMessage() {}
+
+func (x *InputFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InputFilter.ProtoReflect.Descriptor instead.
+func (*InputFilter) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *InputFilter) GetIndexLowerThan() int64 {
+	if x != nil && x.IndexLowerThan != nil {
+		return *x.IndexLowerThan
+	}
+	return 0
+}
+
+func (x *InputFilter) GetIndexGreaterThan() int64 {
+	if x != nil && x.IndexGreaterThan != nil {
+		return *x.IndexGreaterThan
+	}
+	return 0
+}
+
+func (x *InputFilter) GetMsgSender() string {
+	if x != nil && x.MsgSender != nil {
+		return *x.MsgSender
+	}
+	return ""
+}
+
+func (x *InputFilter) GetType() string {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ""
+}
+
+type AppFilter struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IndexLowerThan   *int64                 `protobuf:"varint,1,opt,name=index_lower_than,json=indexLowerThan,proto3,oneof" json:"index_lower_than,omitempty"`
+	IndexGreaterThan *int64                 `protobuf:"varint,2,opt,name=index_greater_than,json=indexGreaterThan,proto3,oneof" json:"index_greater_than,omitempty"`
+	// Filter only apps with name
+	Name *string `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Filter only apps with address
+	Address       *string `protobuf:"bytes,4,opt,name=address,proto3,oneof" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppFilter) Reset() {
+	*x = AppFilter{}
+	mi := &file_reader_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppFilter) ProtoMessage() {}
+
+func (x *AppFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppFilter.ProtoReflect.Descriptor instead.
+func (*AppFilter) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AppFilter) GetIndexLowerThan() int64 {
+	if x != nil && x.IndexLowerThan != nil {
+		return *x.IndexLowerThan
+	}
+	return 0
+}
+
+func (x *AppFilter) GetIndexGreaterThan() int64 {
+	if x != nil && x.IndexGreaterThan != nil {
+		return *x.IndexGreaterThan
+	}
+	return 0
+}
+
+func (x *AppFilter) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *AppFilter) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+type AddressFilterInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Eq            *string                `protobuf:"bytes,1,opt,name=eq,proto3,oneof" json:"eq,omitempty"`
+	Ne            *string                `protobuf:"bytes,2,opt,name=ne,proto3,oneof" json:"ne,omitempty"`
+	In            []string               `protobuf:"bytes,3,rep,name=in,proto3" json:"in,omitempty"`
+	Nin           []string               `protobuf:"bytes,4,rep,name=nin,proto3" json:"nin,omitempty"`
+	And           []*ConvenientFilter    `protobuf:"bytes,5,rep,name=and,proto3" json:"and,omitempty"`
+	Or            []*ConvenientFilter    `protobuf:"bytes,6,rep,name=or,proto3" json:"or,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressFilterInput) Reset() {
+	*x = AddressFilterInput{}
+	mi := &file_reader_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressFilterInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressFilterInput) ProtoMessage() {}
+
+func (x *AddressFilterInput) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressFilterInput.ProtoReflect.Descriptor instead.
+func (*AddressFilterInput) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddressFilterInput) GetEq() string {
+	if x != nil && x.Eq != nil {
+		return *x.Eq
+	}
+	return ""
+}
+
+func (x *AddressFilterInput) GetNe() string {
+	if x != nil && x.Ne != nil {
+		return *x.Ne
+	}
+	return ""
+}
+
+func (x *AddressFilterInput) GetIn() []string {
+	if x != nil {
+		return x.In
+	}
+	return nil
+}
+
+func (x *AddressFilterInput) GetNin() []string {
+	if x != nil {
+		return x.Nin
+	}
+	return nil
+}
+
+func (x *AddressFilterInput) GetAnd() []*ConvenientFilter {
+	if x != nil {
+		return x.And
+	}
+	return nil
+}
+
+func (x *AddressFilterInput) GetOr() []*ConvenientFilter {
+	if x != nil {
+		return x.Or
+	}
+	return nil
+}
+
+type BooleanFilterInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Eq            *bool                  `protobuf:"varint,1,opt,name=eq,proto3,oneof" json:"eq,omitempty"`
+	Ne            *bool                  `protobuf:"varint,2,opt,name=ne,proto3,oneof" json:"ne,omitempty"`
+	And           []*ConvenientFilter    `protobuf:"bytes,3,rep,name=and,proto3" json:"and,omitempty"`
+	Or            []*ConvenientFilter    `protobuf:"bytes,4,rep,name=or,proto3" json:"or,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BooleanFilterInput) Reset() {
+	*x = BooleanFilterInput{}
+	mi := &file_reader_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BooleanFilterInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BooleanFilterInput) ProtoMessage() {}
+
+func (x *BooleanFilterInput) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BooleanFilterInput.ProtoReflect.Descriptor instead.
+func (*BooleanFilterInput) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BooleanFilterInput) GetEq() bool {
+	if x != nil && x.Eq != nil {
+		return *x.Eq
+	}
+	return false
+}
+
+func (x *BooleanFilterInput) GetNe() bool {
+	if x != nil && x.Ne != nil {
+		return *x.Ne
+	}
+	return false
+}
+
+func (x *BooleanFilterInput) GetAnd() []*ConvenientFilter {
+	if x != nil {
+		return x.And
+	}
+	return nil
+}
+
+func (x *BooleanFilterInput) GetOr() []*ConvenientFilter {
+	if x != nil {
+		return x.Or
+	}
+	return nil
+}
+
+type ConvenientFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   *AddressFilterInput    `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Executed      *BooleanFilterInput    `protobuf:"bytes,2,opt,name=executed,proto3" json:"executed,omitempty"`
+	And           []*ConvenientFilter    `protobuf:"bytes,3,rep,name=and,proto3" json:"and,omitempty"`
+	Or            []*ConvenientFilter    `protobuf:"bytes,4,rep,name=or,proto3" json:"or,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvenientFilter) Reset() {
+	*x = ConvenientFilter{}
+	mi := &file_reader_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvenientFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvenientFilter) ProtoMessage() {}
+
+func (x *ConvenientFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvenientFilter.ProtoReflect.Descriptor instead.
+func (*ConvenientFilter) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConvenientFilter) GetDestination() *AddressFilterInput {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *ConvenientFilter) GetExecuted() *BooleanFilterInput {
+	if x != nil {
+		return x.Executed
+	}
+	return nil
+}
+
+func (x *ConvenientFilter) GetAnd() []*ConvenientFilter {
+	if x != nil {
+		return x.And
+	}
+	return nil
+}
+
+func (x *ConvenientFilter) GetOr() []*ConvenientFilter {
+	if x != nil {
+		return x.Or
+	}
+	return nil
+}
+
+type GetInputRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppContract   string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInputRequest) Reset() {
+	*x = GetInputRequest{}
+	mi := &file_reader_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInputRequest) ProtoMessage() {}
+
+func (x *GetInputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInputRequest.ProtoReflect.Descriptor instead.
+func (*GetInputRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetInputRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *GetInputRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListInputsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppContract   string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Where         *InputFilter           `protobuf:"bytes,3,opt,name=where,proto3" json:"where,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInputsRequest) Reset() {
+	*x = ListInputsRequest{}
+	mi := &file_reader_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInputsRequest) ProtoMessage() {}
+
+func (x *ListInputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInputsRequest.ProtoReflect.Descriptor instead.
+func (*ListInputsRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListInputsRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *ListInputsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListInputsRequest) GetWhere() *InputFilter {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+type GetOutputRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppContract   string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	OutputIndex   int64                  `protobuf:"varint,2,opt,name=output_index,json=outputIndex,proto3" json:"output_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutputRequest) Reset() {
+	*x = GetOutputRequest{}
+	mi := &file_reader_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutputRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutputRequest) ProtoMessage() {}
+
+func (x *GetOutputRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutputRequest.ProtoReflect.Descriptor instead.
+func (*GetOutputRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetOutputRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *GetOutputRequest) GetOutputIndex() int64 {
+	if x != nil {
+		return x.OutputIndex
+	}
+	return 0
+}
+
+type ListVouchersRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AppContract string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	Pagination  *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Only the vouchers of the given input
+	InputIndex    *int64              `protobuf:"varint,3,opt,name=input_index,json=inputIndex,proto3,oneof" json:"input_index,omitempty"`
+	Filter        []*ConvenientFilter `protobuf:"bytes,4,rep,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVouchersRequest) Reset() {
+	*x = ListVouchersRequest{}
+	mi := &file_reader_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVouchersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVouchersRequest) ProtoMessage() {}
+
+func (x *ListVouchersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVouchersRequest.ProtoReflect.Descriptor instead.
+func (*ListVouchersRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListVouchersRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *ListVouchersRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListVouchersRequest) GetInputIndex() int64 {
+	if x != nil && x.InputIndex != nil {
+		return *x.InputIndex
+	}
+	return 0
+}
+
+func (x *ListVouchersRequest) GetFilter() []*ConvenientFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ListOutputsRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AppContract string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	Pagination  *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Only the outputs of the given input
+	InputIndex    *int64 `protobuf:"varint,3,opt,name=input_index,json=inputIndex,proto3,oneof" json:"input_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOutputsRequest) Reset() {
+	*x = ListOutputsRequest{}
+	mi := &file_reader_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutputsRequest) ProtoMessage() {}
+
+func (x *ListOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutputsRequest.ProtoReflect.Descriptor instead.
+func (*ListOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListOutputsRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *ListOutputsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListOutputsRequest) GetInputIndex() int64 {
+	if x != nil && x.InputIndex != nil {
+		return *x.InputIndex
+	}
+	return 0
+}
+
+type GetReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppContract   string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	ReportIndex   int64                  `protobuf:"varint,2,opt,name=report_index,json=reportIndex,proto3" json:"report_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReportRequest) Reset() {
+	*x = GetReportRequest{}
+	mi := &file_reader_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReportRequest) ProtoMessage() {}
+
+func (x *GetReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReportRequest.ProtoReflect.Descriptor instead.
+func (*GetReportRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetReportRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *GetReportRequest) GetReportIndex() int64 {
+	if x != nil {
+		return x.ReportIndex
+	}
+	return 0
+}
+
+type ListApplicationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pagination    *Pagination            `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Where         *AppFilter             `protobuf:"bytes,2,opt,name=where,proto3" json:"where,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApplicationsRequest) Reset() {
+	*x = ListApplicationsRequest{}
+	mi := &file_reader_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApplicationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApplicationsRequest) ProtoMessage() {}
+
+func (x *ListApplicationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApplicationsRequest.ProtoReflect.Descriptor instead.
+func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListApplicationsRequest) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListApplicationsRequest) GetWhere() *AppFilter {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+// The watch starts after the given cursors, or from the first output when empty.
+// Resume a watch by passing back the cursor of the last output received of each kind.
+type WatchOutputsRequest struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	AppContract              string                 `protobuf:"bytes,1,opt,name=app_contract,json=appContract,proto3" json:"app_contract,omitempty"`
+	VoucherAfter             string                 `protobuf:"bytes,2,opt,name=voucher_after,json=voucherAfter,proto3" json:"voucher_after,omitempty"`
+	DelegateCallVoucherAfter string                 `protobuf:"bytes,3,opt,name=delegate_call_voucher_after,json=delegateCallVoucherAfter,proto3" json:"delegate_call_voucher_after,omitempty"`
+	NoticeAfter              string                 `protobuf:"bytes,4,opt,name=notice_after,json=noticeAfter,proto3" json:"notice_after,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *WatchOutputsRequest) Reset() {
+	*x = WatchOutputsRequest{}
+	mi := &file_reader_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOutputsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOutputsRequest) ProtoMessage() {}
+
+func (x *WatchOutputsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOutputsRequest.ProtoReflect.Descriptor instead.
+func (*WatchOutputsRequest) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *WatchOutputsRequest) GetAppContract() string {
+	if x != nil {
+		return x.AppContract
+	}
+	return ""
+}
+
+func (x *WatchOutputsRequest) GetVoucherAfter() string {
+	if x != nil {
+		return x.VoucherAfter
+	}
+	return ""
+}
+
+func (x *WatchOutputsRequest) GetDelegateCallVoucherAfter() string {
+	if x != nil {
+		return x.DelegateCallVoucherAfter
+	}
+	return ""
+}
+
+func (x *WatchOutputsRequest) GetNoticeAfter() string {
+	if x != nil {
+		return x.NoticeAfter
+	}
+	return ""
+}
+
+// Output of an application, sent in output index order
+type Output struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Output:
+	//
+	//	*Output_Voucher
+	//	*Output_DelegateCallVoucher
+	//	*Output_Notice
+	Output isOutput_Output `protobuf_oneof:"output"`
+	// Cursor of the output within the outputs of the same kind
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Output) Reset() {
+	*x = Output{}
+	mi := &file_reader_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Output) ProtoMessage() {}
+
+func (x *Output) ProtoReflect() protoreflect.Message {
+	mi := &file_reader_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Output.ProtoReflect.Descriptor instead.
+func (*Output) Descriptor() ([]byte, []int) {
+	return file_reader_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Output) GetOutput() isOutput_Output {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *Output) GetVoucher() *Voucher {
+	if x != nil {
+		if x, ok := x.Output.(*Output_Voucher); ok {
+			return x.Voucher
+		}
+	}
+	return nil
+}
+
+func (x *Output) GetDelegateCallVoucher() *DelegateCallVoucher {
+	if x != nil {
+		if x, ok := x.Output.(*Output_DelegateCallVoucher); ok {
+			return x.DelegateCallVoucher
+		}
+	}
+	return nil
+}
+
+func (x *Output) GetNotice() *Notice {
+	if x != nil {
+		if x, ok := x.Output.(*Output_Notice); ok {
+			return x.Notice
+		}
+	}
+	return nil
+}
+
+func (x *Output) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type isOutput_Output interface {
+	isOutput_Output()
+}
+
+type Output_Voucher struct {
+	Voucher *Voucher `protobuf:"bytes,1,opt,name=voucher,proto3,oneof"`
+}
+
+type Output_DelegateCallVoucher struct {
+	DelegateCallVoucher *DelegateCallVoucher `protobuf:"bytes,2,opt,name=delegate_call_voucher,json=delegateCallVoucher,proto3,oneof"`
+}
+
+type Output_Notice struct {
+	Notice *Notice `protobuf:"bytes,3,opt,name=notice,proto3,oneof"`
+}
+
+func (*Output_Voucher) isOutput_Output() {}
+
+func (*Output_DelegateCallVoucher) isOutput_Output() {}
+
+func (*Output_Notice) isOutput_Output() {}
+
+var File_reader_proto protoreflect.FileDescriptor
+
+const file_reader_proto_rawDesc = "" +
+	"\n" +
+	"\freader.proto\x12!cartesi.rollups.graphql.reader.v1\"`\n" +
+	"\x05Proof\x12!\n" +
+	"\foutput_index\x18\x01 \x01(\tR\voutputIndex\x124\n" +
+	"\x16output_hashes_siblings\x18\x02 \x03(\tR\x14outputHashesSiblings\"\xd5\x03\n" +
+	"\x05Input\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x03R\x05index\x12K\n" +
+	"\x06status\x18\x03 \x01(\x0e23.cartesi.rollups.graphql.reader.v1.CompletionStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"msg_sender\x18\x04 \x01(\tR\tmsgSender\x12 \n" +
+	"\ttimestamp\x18\x05 \x01(\tB\x02\x18\x01R\ttimestamp\x12!\n" +
+	"\fblock_number\x18\x06 \x01(\tR\vblockNumber\x12\x18\n" +
+	"\apayload\x18\a \x01(\tR\apayload\x121\n" +
+	"\x12espresso_timestamp\x18\b \x01(\tB\x02\x18\x01R\x11espressoTimestamp\x126\n" +
+	"\x15espresso_block_number\x18\t \x01(\tB\x02\x18\x01R\x13espressoBlockNumber\x12&\n" +
+	"\x0finput_box_index\x18\n" +
+	" \x01(\tR\rinputBoxIndex\x12'\n" +
+	"\x0fblock_timestamp\x18\v \x01(\tR\x0eblockTimestamp\x12\x1f\n" +
+	"\vprev_randao\x18\f \x01(\tR\n" +
+	"prevRandao\"K\n" +
+	"\vApplication\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"\x99\x02\n" +
+	"\aVoucher\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1f\n" +
+	"\vinput_index\x18\x02 \x01(\x03R\n" +
+	"inputIndex\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12>\n" +
+	"\x05proof\x18\x05 \x01(\v2(.cartesi.rollups.graphql.reader.v1.ProofR\x05proof\x12\x14\n" +
+	"\x05value\x18\x06 \x01(\tR\x05value\x12\x1a\n" +
+	"\bexecuted\x18\a \x01(\bR\bexecuted\x12)\n" +
+	"\x10transaction_hash\x18\b \x01(\tR\x0ftransactionHash\"\x8f\x02\n" +
+	"\x13DelegateCallVoucher\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1f\n" +
+	"\vinput_index\x18\x02 \x01(\x03R\n" +
+	"inputIndex\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\tR\apayload\x12>\n" +
+	"\x05proof\x18\x05 \x01(\v2(.cartesi.rollups.graphql.reader.v1.ProofR\x05proof\x12\x1a\n" +
+	"\bexecuted\x18\x06 \x01(\bR\bexecuted\x12)\n" +
+	"\x10transaction_hash\x18\a \x01(\tR\x0ftransactionHash\"\x99\x01\n" +
+	"\x06Notice\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1f\n" +
+	"\vinput_index\x18\x02 \x01(\x03R\n" +
+	"inputIndex\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12>\n" +
+	"\x05proof\x18\x04 \x01(\v2(.cartesi.rollups.graphql.reader.v1.ProofR\x05proof\"Y\n" +
+	"\x06Report\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x03R\x05index\x12\x1f\n" +
+	"\vinput_index\x18\x02 \x01(\x03R\n" +
+	"inputIndex\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\"\xc6\x01\n" +
+	"\bPageInfo\x12&\n" +
+	"\fstart_cursor\x18\x01 \x01(\tH\x00R\vstartCursor\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"end_cursor\x18\x02 \x01(\tH\x01R\tendCursor\x88\x01\x01\x12\"\n" +
+	"\rhas_next_page\x18\x03 \x01(\bR\vhasNextPage\x12*\n" +
+	"\x11has_previous_page\x18\x04 \x01(\bR\x0fhasPreviousPageB\x0f\n" +
+	"\r_start_cursorB\r\n" +
+	"\v_end_cursor\"\xa0\x01\n" +
+	"\n" +
+	"Pagination\x12\x19\n" +
+	"\x05first\x18\x01 \x01(\x05H\x00R\x05first\x88\x01\x01\x12\x17\n" +
+	"\x04last\x18\x02 \x01(\x05H\x01R\x04last\x88\x01\x01\x12\x19\n" +
+	"\x05after\x18\x03 \x01(\tH\x02R\x05after\x88\x01\x01\x12\x1b\n" +
+	"\x06before\x18\x04 \x01(\tH\x03R\x06before\x88\x01\x01B\b\n" +
+	"\x06_firstB\a\n" +
+	"\x05_lastB\b\n" +
+	"\x06_afterB\t\n" +
+	"\a_before\"a\n" +
+	"\tInputEdge\x12<\n" +
+	"\x04node\x18\x01 \x01(\v2(.cartesi.rollups.graphql.reader.v1.InputR\x04node\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xc0\x01\n" +
+	"\x0fInputConnection\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12B\n" +
+	"\x05edges\x18\x02 \x03(\v2,.cartesi.rollups.graphql.reader.v1.InputEdgeR\x05edges\x12H\n" +
+	"\tpage_info\x18\x03 \x01(\v2+.cartesi.rollups.graphql.reader.v1.PageInfoR\bpageInfo\"e\n" +
+	"\vVoucherEdge\x12>\n" +
+	"\x04node\x18\x01 \x01(\v2*.cartesi.rollups.graphql.reader.v1.VoucherR\x04node\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xc4\x01\n" +
+	"\x11VoucherConnection\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12D\n" +
+	"\x05edges\x18\x02 \x03(\v2..cartesi.rollups.graphql.reader.v1.VoucherEdgeR\x05edges\x12H\n" +
+	"\tpage_info\x18\x03 \x01(\v2+.cartesi.rollups.graphql.reader.v1.PageInfoR\bpageInfo\"}\n" +
+	"\x17DelegateCallVoucherEdge\x12J\n" +
+	"\x04node\x18\x01 \x01(\v26.cartesi.rollups.graphql.reader.v1.DelegateCallVoucherR\x04node\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xdc\x01\n" +
+	"\x1dDelegateCallVoucherConnection\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12P\n" +
+	"\x05edges\x18\x02 \x03(\v2:.cartesi.rollups.graphql.reader.v1.DelegateCallVoucherEdgeR\x05edges\x12H\n" +
+	"\tpage_info\x18\x03 \x01(\v2+.cartesi.rollups.graphql.reader.v1.PageInfoR\bpageInfo\"c\n" +
+	"\n" +
+	"NoticeEdge\x12=\n" +
+	"\x04node\x18\x01 \x01(\v2).cartesi.rollups.graphql.reader.v1.NoticeR\x04node\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xc2\x01\n" +
+	"\x10NoticeConnection\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12C\n" +
+	"\x05edges\x18\x02 \x03(\v2-.cartesi.rollups.graphql.reader.v1.NoticeEdgeR\x05edges\x12H\n" +
+	"\tpage_info\x18\x03 \x01(\v2+.cartesi.rollups.graphql.reader.v1.PageInfoR\bpageInfo\"c\n" +
+	"\n" +
+	"ReportEdge\x12=\n" +
+	"\x04node\x18\x01 \x01(\v2).cartesi.rollups.graphql.reader.v1.ReportR\x04node\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xc2\x01\n" +
+	"\x10ReportConnection\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12C\n" +
+	"\x05edges\x18\x02 \x03(\v2-.cartesi.rollups.graphql.reader.v1.ReportEdgeR\x05edges\x12H\n" +
+	"\tpage_info\x18\x03 \x01(\v2+.cartesi.rollups.graphql.reader.v1.PageInfoR\bpageInfo\"e\n" +
+	"\aAppEdge\x12B\n" +
+	"\x04node\x18\x01 \x01(\v2..cartesi.rollups.graphql.reader.v1.ApplicationR\x04node\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"\xbc\x01\n" +
+	"\rAppConnection\x12\x1f\n" +
+	"\vtotal_count\x18\x01 \x01(\x03R\n" +
+	"totalCount\x12@\n" +
+	"\x05edges\x18\x02 \x03(\v2*.cartesi.rollups.graphql.reader.v1.AppEdgeR\x05edges\x12H\n" +
+	"\tpage_info\x18\x03 \x01(\v2+.cartesi.rollups.graphql.reader.v1.PageInfoR\bpageInfo\"\xf0\x01\n" +
+	"\vInputFilter\x12-\n" +
+	"\x10index_lower_than\x18\x01 \x01(\x03H\x00R\x0eindexLowerThan\x88\x01\x01\x121\n" +
+	"\x12index_greater_than\x18\x02 \x01(\x03H\x01R\x10indexGreaterThan\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"msg_sender\x18\x03 \x01(\tH\x02R\tmsgSender\x88\x01\x01\x12\x17\n" +
+	"\x04type\x18\x04 \x01(\tH\x03R\x04type\x88\x01\x01B\x13\n" +
+	"\x11_index_lower_thanB\x15\n" +
+	"\x13_index_greater_thanB\r\n" +
+	"\v_msg_senderB\a\n" +
+	"\x05_type\"\xe6\x01\n" +
+	"\tAppFilter\x12-\n" +
+	"\x10index_lower_than\x18\x01 \x01(\x03H\x00R\x0eindexLowerThan\x88\x01\x01\x121\n" +
+	"\x12index_greater_than\x18\x02 \x01(\x03H\x01R\x10indexGreaterThan\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x02R\x04name\x88\x01\x01\x12\x1d\n" +
+	"\aaddress\x18\x04 \x01(\tH\x03R\aaddress\x88\x01\x01B\x13\n" +
+	"\x11_index_lower_thanB\x15\n" +
+	"\x13_index_greater_thanB\a\n" +
+	"\x05_nameB\n" +
+	"\n" +
+	"\b_address\"\xfa\x01\n" +
+	"\x12AddressFilterInput\x12\x13\n" +
+	"\x02eq\x18\x01 \x01(\tH\x00R\x02eq\x88\x01\x01\x12\x13\n" +
+	"\x02ne\x18\x02 \x01(\tH\x01R\x02ne\x88\x01\x01\x12\x0e\n" +
+	"\x02in\x18\x03 \x03(\tR\x02in\x12\x10\n" +
+	"\x03nin\x18\x04 \x03(\tR\x03nin\x12E\n" +
+	"\x03and\x18\x05 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x03and\x12C\n" +
+	"\x02or\x18\x06 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x02orB\x05\n" +
+	"\x03_eqB\x05\n" +
+	"\x03_ne\"\xd8\x01\n" +
+	"\x12BooleanFilterInput\x12\x13\n" +
+	"\x02eq\x18\x01 \x01(\bH\x00R\x02eq\x88\x01\x01\x12\x13\n" +
+	"\x02ne\x18\x02 \x01(\bH\x01R\x02ne\x88\x01\x01\x12E\n" +
+	"\x03and\x18\x03 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x03and\x12C\n" +
+	"\x02or\x18\x04 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x02orB\x05\n" +
+	"\x03_eqB\x05\n" +
+	"\x03_ne\"\xca\x02\n" +
+	"\x10ConvenientFilter\x12W\n" +
+	"\vdestination\x18\x01 \x01(\v25.cartesi.rollups.graphql.reader.v1.AddressFilterInputR\vdestination\x12Q\n" +
+	"\bexecuted\x18\x02 \x01(\v25.cartesi.rollups.graphql.reader.v1.BooleanFilterInputR\bexecuted\x12E\n" +
+	"\x03and\x18\x03 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x03and\x12C\n" +
+	"\x02or\x18\x04 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x02or\"D\n" +
+	"\x0fGetInputRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xcb\x01\n" +
+	"\x11ListInputsRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12M\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2-.cartesi.rollups.graphql.reader.v1.PaginationR\n" +
+	"pagination\x12D\n" +
+	"\x05where\x18\x03 \x01(\v2..cartesi.rollups.graphql.reader.v1.InputFilterR\x05where\"X\n" +
+	"\x10GetOutputRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12!\n" +
+	"\foutput_index\x18\x02 \x01(\x03R\voutputIndex\"\x8a\x02\n" +
+	"\x13ListVouchersRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12M\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2-.cartesi.rollups.graphql.reader.v1.PaginationR\n" +
+	"pagination\x12$\n" +
+	"\vinput_index\x18\x03 \x01(\x03H\x00R\n" +
+	"inputIndex\x88\x01\x01\x12K\n" +
+	"\x06filter\x18\x04 \x03(\v23.cartesi.rollups.graphql.reader.v1.ConvenientFilterR\x06filterB\x0e\n" +
+	"\f_input_index\"\xbc\x01\n" +
+	"\x12ListOutputsRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12M\n" +
+	"\n" +
+	"pagination\x18\x02 \x01(\v2-.cartesi.rollups.graphql.reader.v1.PaginationR\n" +
+	"pagination\x12$\n" +
+	"\vinput_index\x18\x03 \x01(\x03H\x00R\n" +
+	"inputIndex\x88\x01\x01B\x0e\n" +
+	"\f_input_index\"X\n" +
+	"\x10GetReportRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12!\n" +
+	"\freport_index\x18\x02 \x01(\x03R\vreportIndex\"\xac\x01\n" +
+	"\x17ListApplicationsRequest\x12M\n" +
+	"\n" +
+	"pagination\x18\x01 \x01(\v2-.cartesi.rollups.graphql.reader.v1.PaginationR\n" +
+	"pagination\x12B\n" +
+	"\x05where\x18\x02 \x01(\v2,.cartesi.rollups.graphql.reader.v1.AppFilterR\x05where\"\xbf\x01\n" +
+	"\x13WatchOutputsRequest\x12!\n" +
+	"\fapp_contract\x18\x01 \x01(\tR\vappContract\x12#\n" +
+	"\rvoucher_after\x18\x02 \x01(\tR\fvoucherAfter\x12=\n" +
+	"\x1bdelegate_call_voucher_after\x18\x03 \x01(\tR\x18delegateCallVoucherAfter\x12!\n" +
+	"\fnotice_after\x18\x04 \x01(\tR\vnoticeAfter\"\xa5\x02\n" +
+	"\x06Output\x12F\n" +
+	"\avoucher\x18\x01 \x01(\v2*.cartesi.rollups.graphql.reader.v1.VoucherH\x00R\avoucher\x12l\n" +
+	"\x15delegate_call_voucher\x18\x02 \x01(\v26.cartesi.rollups.graphql.reader.v1.DelegateCallVoucherH\x00R\x13delegateCallVoucher\x12C\n" +
+	"\x06notice\x18\x03 \x01(\v2).cartesi.rollups.graphql.reader.v1.NoticeH\x00R\x06notice\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursorB\b\n" +
+	"\x06output*\xeb\x02\n" +
+	"\x10CompletionStatus\x12!\n" +
+	"\x1dCOMPLETION_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dCOMPLETION_STATUS_UNPROCESSED\x10\x01\x12\x1e\n" +
+	"\x1aCOMPLETION_STATUS_ACCEPTED\x10\x02\x12\x1e\n" +
+	"\x1aCOMPLETION_STATUS_REJECTED\x10\x03\x12\x1f\n" +
+	"\x1bCOMPLETION_STATUS_EXCEPTION\x10\x04\x12$\n" +
+	" COMPLETION_STATUS_MACHINE_HALTED\x10\x05\x12*\n" +
+	"&COMPLETION_STATUS_CYCLE_LIMIT_EXCEEDED\x10\x06\x12)\n" +
+	"%COMPLETION_STATUS_TIME_LIMIT_EXCEEDED\x10\a\x123\n" +
+	"/COMPLETION_STATUS_PAYLOAD_LENGTH_LIMIT_EXCEEDED\x10\b2\xbe\v\n" +
+	"\x06Reader\x12h\n" +
+	"\bGetInput\x122.cartesi.rollups.graphql.reader.v1.GetInputRequest\x1a(.cartesi.rollups.graphql.reader.v1.Input\x12v\n" +
+	"\n" +
+	"ListInputs\x124.cartesi.rollups.graphql.reader.v1.ListInputsRequest\x1a2.cartesi.rollups.graphql.reader.v1.InputConnection\x12m\n" +
+	"\n" +
+	"GetVoucher\x123.cartesi.rollups.graphql.reader.v1.GetOutputRequest\x1a*.cartesi.rollups.graphql.reader.v1.Voucher\x12|\n" +
+	"\fListVouchers\x126.cartesi.rollups.graphql.reader.v1.ListVouchersRequest\x1a4.cartesi.rollups.graphql.reader.v1.VoucherConnection\x12\x85\x01\n" +
+	"\x16GetDelegateCallVoucher\x123.cartesi.rollups.graphql.reader.v1.GetOutputRequest\x1a6.cartesi.rollups.graphql.reader.v1.DelegateCallVoucher\x12\x94\x01\n" +
+	"\x18ListDelegateCallVouchers\x126.cartesi.rollups.graphql.reader.v1.ListVouchersRequest\x1a@.cartesi.rollups.graphql.reader.v1.DelegateCallVoucherConnection\x12k\n" +
+	"\tGetNotice\x123.cartesi.rollups.graphql.reader.v1.GetOutputRequest\x1a).cartesi.rollups.graphql.reader.v1.Notice\x12y\n" +
+	"\vListNotices\x125.cartesi.rollups.graphql.reader.v1.ListOutputsRequest\x1a3.cartesi.rollups.graphql.reader.v1.NoticeConnection\x12k\n" +
+	"\tGetReport\x123.cartesi.rollups.graphql.reader.v1.GetReportRequest\x1a).cartesi.rollups.graphql.reader.v1.Report\x12y\n" +
+	"\vListReports\x125.cartesi.rollups.graphql.reader.v1.ListOutputsRequest\x1a3.cartesi.rollups.graphql.reader.v1.ReportConnection\x12\x80\x01\n" +
+	"\x10ListApplications\x12:.cartesi.rollups.graphql.reader.v1.ListApplicationsRequest\x1a0.cartesi.rollups.graphql.reader.v1.AppConnection\x12s\n" +
+	"\fWatchOutputs\x126.cartesi.rollups.graphql.reader.v1.WatchOutputsRequest\x1a).cartesi.rollups.graphql.reader.v1.Output0\x01B;Z9github.com/cartesi/rollups-graphql/v2/pkg/reader/readerpbb\x06proto3"
+
+var (
+	file_reader_proto_rawDescOnce sync.Once
+	file_reader_proto_rawDescData []byte
+)
+
+func file_reader_proto_rawDescGZIP() []byte {
+	file_reader_proto_rawDescOnce.Do(func() {
+		file_reader_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reader_proto_rawDesc), len(file_reader_proto_rawDesc)))
+	})
+	return file_reader_proto_rawDescData
+}
+
+var file_reader_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_reader_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_reader_proto_goTypes = []any{
+	(CompletionStatus)(0),                 // 0: cartesi.rollups.graphql.reader.v1.CompletionStatus
+	(*Proof)(nil),                         // 1: cartesi.rollups.graphql.reader.v1.Proof
+	(*Input)(nil),                         // 2: cartesi.rollups.graphql.reader.v1.Input
+	(*Application)(nil),                   // 3: cartesi.rollups.graphql.reader.v1.Application
+	(*Voucher)(nil),                       // 4: cartesi.rollups.graphql.reader.v1.Voucher
+	(*DelegateCallVoucher)(nil),           // 5: cartesi.rollups.graphql.reader.v1.DelegateCallVoucher
+	(*Notice)(nil),                        // 6: cartesi.rollups.graphql.reader.v1.Notice
+	(*Report)(nil),                        // 7: cartesi.rollups.graphql.reader.v1.Report
+	(*PageInfo)(nil),                      // 8: cartesi.rollups.graphql.reader.v1.PageInfo
+	(*Pagination)(nil),                    // 9: cartesi.rollups.graphql.reader.v1.Pagination
+	(*InputEdge)(nil),                     // 10: cartesi.rollups.graphql.reader.v1.InputEdge
+	(*InputConnection)(nil),               // 11: cartesi.rollups.graphql.reader.v1.InputConnection
+	(*VoucherEdge)(nil),                   // 12: cartesi.rollups.graphql.reader.v1.VoucherEdge
+	(*VoucherConnection)(nil),             // 13: cartesi.rollups.graphql.reader.v1.VoucherConnection
+	(*DelegateCallVoucherEdge)(nil),       // 14: cartesi.rollups.graphql.reader.v1.DelegateCallVoucherEdge
+	(*DelegateCallVoucherConnection)(nil), // 15: cartesi.rollups.graphql.reader.v1.DelegateCallVoucherConnection
+	(*NoticeEdge)(nil),                    // 16: cartesi.rollups.graphql.reader.v1.NoticeEdge
+	(*NoticeConnection)(nil),              // 17: cartesi.rollups.graphql.reader.v1.NoticeConnection
+	(*ReportEdge)(nil),                    // 18: cartesi.rollups.graphql.reader.v1.ReportEdge
+	(*ReportConnection)(nil),              // 19: cartesi.rollups.graphql.reader.v1.ReportConnection
+	(*AppEdge)(nil),                       // 20: cartesi.rollups.graphql.reader.v1.AppEdge
+	(*AppConnection)(nil),                 // 21: cartesi.rollups.graphql.reader.v1.AppConnection
+	(*InputFilter)(nil),                   // 22: cartesi.rollups.graphql.reader.v1.InputFilter
+	(*AppFilter)(nil),                     // 23: cartesi.rollups.graphql.reader.v1.AppFilter
+	(*AddressFilterInput)(nil),            // 24: cartesi.rollups.graphql.reader.v1.AddressFilterInput
+	(*BooleanFilterInput)(nil),            // 25: cartesi.rollups.graphql.reader.v1.BooleanFilterInput
+	(*ConvenientFilter)(nil),              // 26: cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	(*GetInputRequest)(nil),               // 27: cartesi.rollups.graphql.reader.v1.GetInputRequest
+	(*ListInputsRequest)(nil),             // 28: cartesi.rollups.graphql.reader.v1.ListInputsRequest
+	(*GetOutputRequest)(nil),              // 29: cartesi.rollups.graphql.reader.v1.GetOutputRequest
+	(*ListVouchersRequest)(nil),           // 30: cartesi.rollups.graphql.reader.v1.ListVouchersRequest
+	(*ListOutputsRequest)(nil),            // 31: cartesi.rollups.graphql.reader.v1.ListOutputsRequest
+	(*GetReportRequest)(nil),              // 32: cartesi.rollups.graphql.reader.v1.GetReportRequest
+	(*ListApplicationsRequest)(nil),       // 33: cartesi.rollups.graphql.reader.v1.ListApplicationsRequest
+	(*WatchOutputsRequest)(nil),           // 34: cartesi.rollups.graphql.reader.v1.WatchOutputsRequest
+	(*Output)(nil),                        // 35: cartesi.rollups.graphql.reader.v1.Output
+}
+var file_reader_proto_depIdxs = []int32{
+	0,  // 0: cartesi.rollups.graphql.reader.v1.Input.status:type_name -> cartesi.rollups.graphql.reader.v1.CompletionStatus
+	1,  // 1: cartesi.rollups.graphql.reader.v1.Voucher.proof:type_name -> cartesi.rollups.graphql.reader.v1.Proof
+	1,  // 2: cartesi.rollups.graphql.reader.v1.DelegateCallVoucher.proof:type_name -> cartesi.rollups.graphql.reader.v1.Proof
+	1,  // 3: cartesi.rollups.graphql.reader.v1.Notice.proof:type_name -> cartesi.rollups.graphql.reader.v1.Proof
+	2,  // 4: cartesi.rollups.graphql.reader.v1.InputEdge.node:type_name -> cartesi.rollups.graphql.reader.v1.Input
+	10, // 5: cartesi.rollups.graphql.reader.v1.InputConnection.edges:type_name -> cartesi.rollups.graphql.reader.v1.InputEdge
+	8,  // 6: cartesi.rollups.graphql.reader.v1.InputConnection.page_info:type_name -> cartesi.rollups.graphql.reader.v1.PageInfo
+	4,  // 7: cartesi.rollups.graphql.reader.v1.VoucherEdge.node:type_name -> cartesi.rollups.graphql.reader.v1.Voucher
+	12, // 8: cartesi.rollups.graphql.reader.v1.VoucherConnection.edges:type_name -> cartesi.rollups.graphql.reader.v1.VoucherEdge
+	8,  // 9: cartesi.rollups.graphql.reader.v1.VoucherConnection.page_info:type_name -> cartesi.rollups.graphql.reader.v1.PageInfo
+	5,  // 10: cartesi.rollups.graphql.reader.v1.DelegateCallVoucherEdge.node:type_name -> cartesi.rollups.graphql.reader.v1.DelegateCallVoucher
+	14, // 11: cartesi.rollups.graphql.reader.v1.DelegateCallVoucherConnection.edges:type_name -> cartesi.rollups.graphql.reader.v1.DelegateCallVoucherEdge
+	8,  // 12: cartesi.rollups.graphql.reader.v1.DelegateCallVoucherConnection.page_info:type_name -> cartesi.rollups.graphql.reader.v1.PageInfo
+	6,  // 13: cartesi.rollups.graphql.reader.v1.NoticeEdge.node:type_name -> cartesi.rollups.graphql.reader.v1.Notice
+	16, // 14: cartesi.rollups.graphql.reader.v1.NoticeConnection.edges:type_name -> cartesi.rollups.graphql.reader.v1.NoticeEdge
+	8,  // 15: cartesi.rollups.graphql.reader.v1.NoticeConnection.page_info:type_name -> cartesi.rollups.graphql.reader.v1.PageInfo
+	7,  // 16: cartesi.rollups.graphql.reader.v1.ReportEdge.node:type_name -> cartesi.rollups.graphql.reader.v1.Report
+	18, // 17: cartesi.rollups.graphql.reader.v1.ReportConnection.edges:type_name -> cartesi.rollups.graphql.reader.v1.ReportEdge
+	8,  // 18: cartesi.rollups.graphql.reader.v1.ReportConnection.page_info:type_name -> cartesi.rollups.graphql.reader.v1.PageInfo
+	3,  // 19: cartesi.rollups.graphql.reader.v1.AppEdge.node:type_name -> cartesi.rollups.graphql.reader.v1.Application
+	20, // 20: cartesi.rollups.graphql.reader.v1.AppConnection.edges:type_name -> cartesi.rollups.graphql.reader.v1.AppEdge
+	8,  // 21: cartesi.rollups.graphql.reader.v1.AppConnection.page_info:type_name -> cartesi.rollups.graphql.reader.v1.PageInfo
+	26, // 22: cartesi.rollups.graphql.reader.v1.AddressFilterInput.and:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	26, // 23: cartesi.rollups.graphql.reader.v1.AddressFilterInput.or:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	26, // 24: cartesi.rollups.graphql.reader.v1.BooleanFilterInput.and:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	26, // 25: cartesi.rollups.graphql.reader.v1.BooleanFilterInput.or:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	24, // 26: cartesi.rollups.graphql.reader.v1.ConvenientFilter.destination:type_name -> cartesi.rollups.graphql.reader.v1.AddressFilterInput
+	25, // 27: cartesi.rollups.graphql.reader.v1.ConvenientFilter.executed:type_name -> cartesi.rollups.graphql.reader.v1.BooleanFilterInput
+	26, // 28: cartesi.rollups.graphql.reader.v1.ConvenientFilter.and:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	26, // 29: cartesi.rollups.graphql.reader.v1.ConvenientFilter.or:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	9,  // 30: cartesi.rollups.graphql.reader.v1.ListInputsRequest.pagination:type_name -> cartesi.rollups.graphql.reader.v1.Pagination
+	22, // 31: cartesi.rollups.graphql.reader.v1.ListInputsRequest.where:type_name -> cartesi.rollups.graphql.reader.v1.InputFilter
+	9,  // 32: cartesi.rollups.graphql.reader.v1.ListVouchersRequest.pagination:type_name -> cartesi.rollups.graphql.reader.v1.Pagination
+	26, // 33: cartesi.rollups.graphql.reader.v1.ListVouchersRequest.filter:type_name -> cartesi.rollups.graphql.reader.v1.ConvenientFilter
+	9,  // 34: cartesi.rollups.graphql.reader.v1.ListOutputsRequest.pagination:type_name -> cartesi.rollups.graphql.reader.v1.Pagination
+	9,  // 35: cartesi.rollups.graphql.reader.v1.ListApplicationsRequest.pagination:type_name -> cartesi.rollups.graphql.reader.v1.Pagination
+	23, // 36: cartesi.rollups.graphql.reader.v1.ListApplicationsRequest.where:type_name -> cartesi.rollups.graphql.reader.v1.AppFilter
+	4,  // 37: cartesi.rollups.graphql.reader.v1.Output.voucher:type_name -> cartesi.rollups.graphql.reader.v1.Voucher
+	5,  // 38: cartesi.rollups.graphql.reader.v1.Output.delegate_call_voucher:type_name -> cartesi.rollups.graphql.reader.v1.DelegateCallVoucher
+	6,  // 39: cartesi.rollups.graphql.reader.v1.Output.notice:type_name -> cartesi.rollups.graphql.reader.v1.Notice
+	27, // 40: cartesi.rollups.graphql.reader.v1.Reader.GetInput:input_type -> cartesi.rollups.graphql.reader.v1.GetInputRequest
+	28, // 41: cartesi.rollups.graphql.reader.v1.Reader.ListInputs:input_type -> cartesi.rollups.graphql.reader.v1.ListInputsRequest
+	29, // 42: cartesi.rollups.graphql.reader.v1.Reader.GetVoucher:input_type -> cartesi.rollups.graphql.reader.v1.GetOutputRequest
+	30, // 43: cartesi.rollups.graphql.reader.v1.Reader.ListVouchers:input_type -> cartesi.rollups.graphql.reader.v1.ListVouchersRequest
+	29, // 44: cartesi.rollups.graphql.reader.v1.Reader.GetDelegateCallVoucher:input_type -> cartesi.rollups.graphql.reader.v1.GetOutputRequest
+	30, // 45: cartesi.rollups.graphql.reader.v1.Reader.ListDelegateCallVouchers:input_type -> cartesi.rollups.graphql.reader.v1.ListVouchersRequest
+	29, // 46: cartesi.rollups.graphql.reader.v1.Reader.GetNotice:input_type -> cartesi.rollups.graphql.reader.v1.GetOutputRequest
+	31, // 47: cartesi.rollups.graphql.reader.v1.Reader.ListNotices:input_type -> cartesi.rollups.graphql.reader.v1.ListOutputsRequest
+	32, // 48: cartesi.rollups.graphql.reader.v1.Reader.GetReport:input_type -> cartesi.rollups.graphql.reader.v1.GetReportRequest
+	31, // 49: cartesi.rollups.graphql.reader.v1.Reader.ListReports:input_type -> cartesi.rollups.graphql.reader.v1.ListOutputsRequest
+	33, // 50: cartesi.rollups.graphql.reader.v1.Reader.ListApplications:input_type -> cartesi.rollups.graphql.reader.v1.ListApplicationsRequest
+	34, // 51: cartesi.rollups.graphql.reader.v1.Reader.WatchOutputs:input_type -> cartesi.rollups.graphql.reader.v1.WatchOutputsRequest
+	2,  // 52: cartesi.rollups.graphql.reader.v1.Reader.GetInput:output_type -> cartesi.rollups.graphql.reader.v1.Input
+	11, // 53: cartesi.rollups.graphql.reader.v1.Reader.ListInputs:output_type -> cartesi.rollups.graphql.reader.v1.InputConnection
+	4,  // 54: cartesi.rollups.graphql.reader.v1.Reader.GetVoucher:output_type -> cartesi.rollups.graphql.reader.v1.Voucher
+	13, // 55: cartesi.rollups.graphql.reader.v1.Reader.ListVouchers:output_type -> cartesi.rollups.graphql.reader.v1.VoucherConnection
+	5,  // 56: cartesi.rollups.graphql.reader.v1.Reader.GetDelegateCallVoucher:output_type -> cartesi.rollups.graphql.reader.v1.DelegateCallVoucher
+	15, // 57: cartesi.rollups.graphql.reader.v1.Reader.ListDelegateCallVouchers:output_type -> cartesi.rollups.graphql.reader.v1.DelegateCallVoucherConnection
+	6,  // 58: cartesi.rollups.graphql.reader.v1.Reader.GetNotice:output_type -> cartesi.rollups.graphql.reader.v1.Notice
+	17, // 59: cartesi.rollups.graphql.reader.v1.Reader.ListNotices:output_type -> cartesi.rollups.graphql.reader.v1.NoticeConnection
+	7,  // 60: cartesi.rollups.graphql.reader.v1.Reader.GetReport:output_type -> cartesi.rollups.graphql.reader.v1.Report
+	19, // 61: cartesi.rollups.graphql.reader.v1.Reader.ListReports:output_type -> cartesi.rollups.graphql.reader.v1.ReportConnection
+	21, // 62: cartesi.rollups.graphql.reader.v1.Reader.ListApplications:output_type -> cartesi.rollups.graphql.reader.v1.AppConnection
+	35, // 63: cartesi.rollups.graphql.reader.v1.Reader.WatchOutputs:output_type -> cartesi.rollups.graphql.reader.v1.Output
+	52, // [52:64] is the sub-list for method output_type
+	40, // [40:52] is the sub-list for method input_type
+	40, // [40:40] is the sub-list for extension type_name
+	40, // [40:40] is the sub-list for extension extendee
+	0,  // [0:40] is the sub-list for field type_name
+}
+
+func init() { file_reader_proto_init() }
+func file_reader_proto_init() {
+	if File_reader_proto != nil {
+		return
+	}
+	file_reader_proto_msgTypes[7].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[8].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[21].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[22].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[23].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[24].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[29].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[30].OneofWrappers = []any{}
+	file_reader_proto_msgTypes[34].OneofWrappers = []any{
+		(*Output_Voucher)(nil),
+		(*Output_DelegateCallVoucher)(nil),
+		(*Output_Notice)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reader_proto_rawDesc), len(file_reader_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   35,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_reader_proto_goTypes,
+		DependencyIndexes: file_reader_proto_depIdxs,
+		EnumInfos:         file_reader_proto_enumTypes,
+		MessageInfos:      file_reader_proto_msgTypes,
+	}.Build()
+	File_reader_proto = out.File
+	file_reader_proto_goTypes = nil
+	file_reader_proto_depIdxs = nil
+}
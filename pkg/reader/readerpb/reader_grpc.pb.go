@@ -0,0 +1,576 @@
+// Copyright (c) Gabriel de Quadros Ligneul
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// gRPC mirror of the GraphQL reader API described by reader.graphql.
+// Nested fields resolved by GraphQL, such as input.vouchers or voucher.input,
+// are fetched with the list and get calls filtered by the input index.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: reader.proto
+
+package readerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Reader_GetInput_FullMethodName                 = "/cartesi.rollups.graphql.reader.v1.Reader/GetInput"
+	Reader_ListInputs_FullMethodName               = "/cartesi.rollups.graphql.reader.v1.Reader/ListInputs"
+	Reader_GetVoucher_FullMethodName               = "/cartesi.rollups.graphql.reader.v1.Reader/GetVoucher"
+	Reader_ListVouchers_FullMethodName             = "/cartesi.rollups.graphql.reader.v1.Reader/ListVouchers"
+	Reader_GetDelegateCallVoucher_FullMethodName   = "/cartesi.rollups.graphql.reader.v1.Reader/GetDelegateCallVoucher"
+	Reader_ListDelegateCallVouchers_FullMethodName = "/cartesi.rollups.graphql.reader.v1.Reader/ListDelegateCallVouchers"
+	Reader_GetNotice_FullMethodName                = "/cartesi.rollups.graphql.reader.v1.Reader/GetNotice"
+	Reader_ListNotices_FullMethodName              = "/cartesi.rollups.graphql.reader.v1.Reader/ListNotices"
+	Reader_GetReport_FullMethodName                = "/cartesi.rollups.graphql.reader.v1.Reader/GetReport"
+	Reader_ListReports_FullMethodName              = "/cartesi.rollups.graphql.reader.v1.Reader/ListReports"
+	Reader_ListApplications_FullMethodName         = "/cartesi.rollups.graphql.reader.v1.Reader/ListApplications"
+	Reader_WatchOutputs_FullMethodName             = "/cartesi.rollups.graphql.reader.v1.Reader/WatchOutputs"
+)
+
+// ReaderClient is the client API for Reader service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Reads the data of the applications synchronized from the node.
+// Every call scoped to an application takes its contract address as app_contract.
+type ReaderClient interface {
+	// Get input based on its identifier
+	GetInput(ctx context.Context, in *GetInputRequest, opts ...grpc.CallOption) (*Input, error)
+	// Get inputs with support for pagination
+	ListInputs(ctx context.Context, in *ListInputsRequest, opts ...grpc.CallOption) (*InputConnection, error)
+	// Get a voucher based on its index
+	GetVoucher(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (*Voucher, error)
+	// Get vouchers with support for pagination
+	ListVouchers(ctx context.Context, in *ListVouchersRequest, opts ...grpc.CallOption) (*VoucherConnection, error)
+	GetDelegateCallVoucher(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (*DelegateCallVoucher, error)
+	ListDelegateCallVouchers(ctx context.Context, in *ListVouchersRequest, opts ...grpc.CallOption) (*DelegateCallVoucherConnection, error)
+	// Get a notice based on its index
+	GetNotice(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (*Notice, error)
+	// Get notices with support for pagination
+	ListNotices(ctx context.Context, in *ListOutputsRequest, opts ...grpc.CallOption) (*NoticeConnection, error)
+	// Get a report based on its index
+	GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*Report, error)
+	// Get reports with support for pagination
+	ListReports(ctx context.Context, in *ListOutputsRequest, opts ...grpc.CallOption) (*ReportConnection, error)
+	// Get apps with support for pagination
+	ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*AppConnection, error)
+	// Stream the vouchers, delegate call vouchers and notices of an application as they are synchronized
+	WatchOutputs(ctx context.Context, in *WatchOutputsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Output], error)
+}
+
+type readerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReaderClient(cc grpc.ClientConnInterface) ReaderClient {
+	return &readerClient{cc}
+}
+
+func (c *readerClient) GetInput(ctx context.Context, in *GetInputRequest, opts ...grpc.CallOption) (*Input, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Input)
+	err := c.cc.Invoke(ctx, Reader_GetInput_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) ListInputs(ctx context.Context, in *ListInputsRequest, opts ...grpc.CallOption) (*InputConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InputConnection)
+	err := c.cc.Invoke(ctx, Reader_ListInputs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) GetVoucher(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (*Voucher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Voucher)
+	err := c.cc.Invoke(ctx, Reader_GetVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) ListVouchers(ctx context.Context, in *ListVouchersRequest, opts ...grpc.CallOption) (*VoucherConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoucherConnection)
+	err := c.cc.Invoke(ctx, Reader_ListVouchers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) GetDelegateCallVoucher(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (*DelegateCallVoucher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DelegateCallVoucher)
+	err := c.cc.Invoke(ctx, Reader_GetDelegateCallVoucher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) ListDelegateCallVouchers(ctx context.Context, in *ListVouchersRequest, opts ...grpc.CallOption) (*DelegateCallVoucherConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DelegateCallVoucherConnection)
+	err := c.cc.Invoke(ctx, Reader_ListDelegateCallVouchers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) GetNotice(ctx context.Context, in *GetOutputRequest, opts ...grpc.CallOption) (*Notice, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Notice)
+	err := c.cc.Invoke(ctx, Reader_GetNotice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) ListNotices(ctx context.Context, in *ListOutputsRequest, opts ...grpc.CallOption) (*NoticeConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoticeConnection)
+	err := c.cc.Invoke(ctx, Reader_ListNotices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) GetReport(ctx context.Context, in *GetReportRequest, opts ...grpc.CallOption) (*Report, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Report)
+	err := c.cc.Invoke(ctx, Reader_GetReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) ListReports(ctx context.Context, in *ListOutputsRequest, opts ...grpc.CallOption) (*ReportConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportConnection)
+	err := c.cc.Invoke(ctx, Reader_ListReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*AppConnection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AppConnection)
+	err := c.cc.Invoke(ctx, Reader_ListApplications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *readerClient) WatchOutputs(ctx context.Context, in *WatchOutputsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Output], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Reader_ServiceDesc.Streams[0], Reader_WatchOutputs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOutputsRequest, Output]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Reader_WatchOutputsClient = grpc.ServerStreamingClient[Output]
+
+// ReaderServer is the server API for Reader service.
+// All implementations must embed UnimplementedReaderServer
+// for forward compatibility.
+//
+// Reads the data of the applications synchronized from the node.
+// Every call scoped to an application takes its contract address as app_contract.
+type ReaderServer interface {
+	// Get input based on its identifier
+	GetInput(context.Context, *GetInputRequest) (*Input, error)
+	// Get inputs with support for pagination
+	ListInputs(context.Context, *ListInputsRequest) (*InputConnection, error)
+	// Get a voucher based on its index
+	GetVoucher(context.Context, *GetOutputRequest) (*Voucher, error)
+	// Get vouchers with support for pagination
+	ListVouchers(context.Context, *ListVouchersRequest) (*VoucherConnection, error)
+	GetDelegateCallVoucher(context.Context, *GetOutputRequest) (*DelegateCallVoucher, error)
+	ListDelegateCallVouchers(context.Context, *ListVouchersRequest) (*DelegateCallVoucherConnection, error)
+	// Get a notice based on its index
+	GetNotice(context.Context, *GetOutputRequest) (*Notice, error)
+	// Get notices with support for pagination
+	ListNotices(context.Context, *ListOutputsRequest) (*NoticeConnection, error)
+	// Get a report based on its index
+	GetReport(context.Context, *GetReportRequest) (*Report, error)
+	// Get reports with support for pagination
+	ListReports(context.Context, *ListOutputsRequest) (*ReportConnection, error)
+	// Get apps with support for pagination
+	ListApplications(context.Context, *ListApplicationsRequest) (*AppConnection, error)
+	// Stream the vouchers, delegate call vouchers and notices of an application as they are synchronized
+	WatchOutputs(*WatchOutputsRequest, grpc.ServerStreamingServer[Output]) error
+	mustEmbedUnimplementedReaderServer()
+}
+
+// UnimplementedReaderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReaderServer struct{}
+
+func (UnimplementedReaderServer) GetInput(context.Context, *GetInputRequest) (*Input, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInput not implemented")
+}
+func (UnimplementedReaderServer) ListInputs(context.Context, *ListInputsRequest) (*InputConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInputs not implemented")
+}
+func (UnimplementedReaderServer) GetVoucher(context.Context, *GetOutputRequest) (*Voucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVoucher not implemented")
+}
+func (UnimplementedReaderServer) ListVouchers(context.Context, *ListVouchersRequest) (*VoucherConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVouchers not implemented")
+}
+func (UnimplementedReaderServer) GetDelegateCallVoucher(context.Context, *GetOutputRequest) (*DelegateCallVoucher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDelegateCallVoucher not implemented")
+}
+func (UnimplementedReaderServer) ListDelegateCallVouchers(context.Context, *ListVouchersRequest) (*DelegateCallVoucherConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDelegateCallVouchers not implemented")
+}
+func (UnimplementedReaderServer) GetNotice(context.Context, *GetOutputRequest) (*Notice, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNotice not implemented")
+}
+func (UnimplementedReaderServer) ListNotices(context.Context, *ListOutputsRequest) (*NoticeConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotices not implemented")
+}
+func (UnimplementedReaderServer) GetReport(context.Context, *GetReportRequest) (*Report, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReport not implemented")
+}
+func (UnimplementedReaderServer) ListReports(context.Context, *ListOutputsRequest) (*ReportConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReports not implemented")
+}
+func (UnimplementedReaderServer) ListApplications(context.Context, *ListApplicationsRequest) (*AppConnection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListApplications not implemented")
+}
+func (UnimplementedReaderServer) WatchOutputs(*WatchOutputsRequest, grpc.ServerStreamingServer[Output]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOutputs not implemented")
+}
+func (UnimplementedReaderServer) mustEmbedUnimplementedReaderServer() {}
+func (UnimplementedReaderServer) testEmbeddedByValue()                {}
+
+// UnsafeReaderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReaderServer will
+// result in compilation errors.
+type UnsafeReaderServer interface {
+	mustEmbedUnimplementedReaderServer()
+}
+
+func RegisterReaderServer(s grpc.ServiceRegistrar, srv ReaderServer) {
+	// If the following call pancis, it indicates UnimplementedReaderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Reader_ServiceDesc, srv)
+}
+
+func _Reader_GetInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).GetInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_GetInput_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).GetInput(ctx, req.(*GetInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_ListInputs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).ListInputs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_ListInputs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).ListInputs(ctx, req.(*ListInputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_GetVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).GetVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_GetVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).GetVoucher(ctx, req.(*GetOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_ListVouchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVouchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).ListVouchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_ListVouchers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).ListVouchers(ctx, req.(*ListVouchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_GetDelegateCallVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).GetDelegateCallVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_GetDelegateCallVoucher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).GetDelegateCallVoucher(ctx, req.(*GetOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_ListDelegateCallVouchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVouchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).ListDelegateCallVouchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_ListDelegateCallVouchers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).ListDelegateCallVouchers(ctx, req.(*ListVouchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_GetNotice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).GetNotice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_GetNotice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).GetNotice(ctx, req.(*GetOutputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_ListNotices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).ListNotices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_ListNotices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).ListNotices(ctx, req.(*ListOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_GetReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).GetReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_GetReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).GetReport(ctx, req.(*GetReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_ListReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutputsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).ListReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_ListReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).ListReports(ctx, req.(*ListOutputsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_ListApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReaderServer).ListApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reader_ListApplications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReaderServer).ListApplications(ctx, req.(*ListApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reader_WatchOutputs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOutputsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReaderServer).WatchOutputs(m, &grpc.GenericServerStream[WatchOutputsRequest, Output]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Reader_WatchOutputsServer = grpc.ServerStreamingServer[Output]
+
+// Reader_ServiceDesc is the grpc.ServiceDesc for Reader service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Reader_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cartesi.rollups.graphql.reader.v1.Reader",
+	HandlerType: (*ReaderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInput",
+			Handler:    _Reader_GetInput_Handler,
+		},
+		{
+			MethodName: "ListInputs",
+			Handler:    _Reader_ListInputs_Handler,
+		},
+		{
+			MethodName: "GetVoucher",
+			Handler:    _Reader_GetVoucher_Handler,
+		},
+		{
+			MethodName: "ListVouchers",
+			Handler:    _Reader_ListVouchers_Handler,
+		},
+		{
+			MethodName: "GetDelegateCallVoucher",
+			Handler:    _Reader_GetDelegateCallVoucher_Handler,
+		},
+		{
+			MethodName: "ListDelegateCallVouchers",
+			Handler:    _Reader_ListDelegateCallVouchers_Handler,
+		},
+		{
+			MethodName: "GetNotice",
+			Handler:    _Reader_GetNotice_Handler,
+		},
+		{
+			MethodName: "ListNotices",
+			Handler:    _Reader_ListNotices_Handler,
+		},
+		{
+			MethodName: "GetReport",
+			Handler:    _Reader_GetReport_Handler,
+		},
+		{
+			MethodName: "ListReports",
+			Handler:    _Reader_ListReports_Handler,
+		},
+		{
+			MethodName: "ListApplications",
+			Handler:    _Reader_ListApplications_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOutputs",
+			Handler:       _Reader_WatchOutputs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "reader.proto",
+}
@@ -0,0 +1,53 @@
+package reader
+
+import (
+	"context"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+)
+
+// readRouting routes the reads of each request to the database chosen by the router.
+func readRouting(router *repository.ReadRouter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := router.WithReadDB(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// GrpcReadRouting returns the server options routing the reads of each call
+// to the database chosen by the router.
+func GrpcReadRouting(router *repository.ReadRouter) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(
+			ctx context.Context,
+			req any,
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			return handler(router.WithReadDB(ctx), req)
+		}),
+		grpc.ChainStreamInterceptor(func(
+			srv any,
+			stream grpc.ServerStream,
+			info *grpc.StreamServerInfo,
+			handler grpc.StreamHandler,
+		) error {
+			return handler(srv, &routedStream{stream, router.WithReadDB(stream.Context())})
+		}),
+	}
+}
+
+// routedStream is a server stream whose context routes the reads.
+type routedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *routedStream) Context() context.Context {
+	return s.ctx
+}
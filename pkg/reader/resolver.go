@@ -1,6 +1,9 @@
 package reader
 
 import (
+	"context"
+
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
 	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
 )
 
@@ -12,3 +15,12 @@ type Resolver struct {
 	convenienceService *services.ConvenienceService
 	adapter            Adapter
 }
+
+// withAppContract scopes the fields of an object to its application when the
+// endpoint does not, as the root /graphql, so they are found and batched per application.
+func withAppContract(ctx context.Context, appContract string) context.Context {
+	if appContract == "" || ctx.Value(cModel.AppContractKey) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, cModel.AppContractKey, appContract)
+}
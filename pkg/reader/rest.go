@@ -0,0 +1,396 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	graphql "github.com/cartesi/rollups-graphql/v2/pkg/reader/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+// Page is the paginated response of the REST API. The cursors of the page info are
+// the same as in the GraphQL API and are passed back with the after and before parameters.
+type Page[T any] struct {
+	TotalCount int               `json:"totalCount"`
+	Items      []T               `json:"items"`
+	PageInfo   *graphql.PageInfo `json:"pageInfo"`
+}
+
+func newPage[T any](conn *graphql.Connection[T]) Page[T] {
+	items := make([]T, len(conn.Edges))
+	for i, edge := range conn.Edges {
+		items[i] = edge.Node
+	}
+	return Page[T]{TotalCount: conn.TotalCount, Items: items, PageInfo: conn.PageInfo}
+}
+
+func pageResult[T any](conn *graphql.Connection[T], err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return newPage(conn), nil
+}
+
+func itemResult[T any](value *T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// A query parameter of a REST route.
+type restParam struct {
+	name        string
+	kind        reflect.Kind
+	description string
+}
+
+// restRoute describes a read-only REST resource, for both echo and the OpenAPI document.
+type restRoute struct {
+	path      string
+	summary   string
+	query     []restParam
+	paginated bool
+	// type of the item returned, or of the items of the page
+	item    reflect.Type
+	handler func(c *restContext) (any, error)
+}
+
+var paginationParams = []restParam{
+	{"first", reflect.Int, "Get at most the first n entries (forward pagination)"},
+	{"after", reflect.String, "Get entries after the provided cursor (forward pagination)"},
+	{"last", reflect.Int, "Get at most the last n entries (backward pagination)"},
+	{"before", reflect.String, "Get entries before the provided cursor (backward pagination)"},
+}
+
+// restContext parses the parameters of a request, keeping the first error.
+type restContext struct {
+	echo.Context
+	ctx context.Context
+	err error
+}
+
+func (c *restContext) intParam(name string) int {
+	value, err := strconv.Atoi(c.Param(name))
+	if err != nil || value < 0 {
+		c.fail(name)
+	}
+	return value
+}
+
+func (c *restContext) intQuery(name string) *int {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		c.fail(name)
+	}
+	return &value
+}
+
+func (c *restContext) boolQuery(name string) *bool {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.fail(name)
+	}
+	return &value
+}
+
+func (c *restContext) stringQuery(name string) *string {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return nil
+	}
+	return &raw
+}
+
+func (c *restContext) addressQuery(name string) *string {
+	raw := c.stringQuery(name)
+	if raw != nil && !common.IsHexAddress(*raw) {
+		c.fail(name)
+	}
+	return raw
+}
+
+func (c *restContext) fail(name string) {
+	if c.err == nil {
+		c.err = echo.NewHTTPError(http.StatusBadRequest, "invalid "+name)
+	}
+}
+
+func (c *restContext) pagination() (first *int, last *int, after *string, before *string) {
+	return c.intQuery("first"), c.intQuery("last"), c.stringQuery("after"), c.stringQuery("before")
+}
+
+// Filters of the vouchers and delegate call vouchers lists.
+func (c *restContext) outputFilter() []*graphql.ConvenientFilter {
+	var filter []*graphql.ConvenientFilter
+	if executed := c.boolQuery("executed"); executed != nil {
+		filter = append(filter, &graphql.ConvenientFilter{
+			Executed: &graphql.BooleanFilterInput{Eq: executed},
+		})
+	}
+	if destination := c.addressQuery("destination"); destination != nil {
+		filter = append(filter, &graphql.ConvenientFilter{
+			Destination: &graphql.AddressFilterInput{Eq: destination},
+		})
+	}
+	return filter
+}
+
+var outputFilterParams = []restParam{
+	{"executed", reflect.Bool, "Filter the vouchers by execution"},
+	{"destination", reflect.String, "Filter the vouchers by destination address"},
+}
+
+func restRoutes(adapter Adapter) []restRoute {
+	var (
+		inputType    = reflect.TypeFor[graphql.Input]()
+		voucherType  = reflect.TypeFor[graphql.Voucher]()
+		delegateType = reflect.TypeFor[graphql.DelegateCallVoucher]()
+		noticeType   = reflect.TypeFor[graphql.Notice]()
+		reportType   = reflect.TypeFor[graphql.Report]()
+		appType      = reflect.TypeFor[graphql.Application]()
+	)
+	return []restRoute{
+		{
+			path: "/apps", summary: "List the applications", paginated: true, item: appType,
+			query: append([]restParam{
+				{"name", reflect.String, "Filter the applications by name"},
+				{"address", reflect.String, "Filter the applications by address"},
+			}, paginationParams...),
+			handler: func(c *restContext) (any, error) {
+				first, last, after, before := c.pagination()
+				filter := &graphql.AppFilter{Name: c.stringQuery("name"), Address: c.addressQuery("address")}
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetApplications(c.ctx, first, last, after, before, filter))
+			},
+		},
+		{
+			path: "/apps/:appContract", summary: "Get an application", item: appType,
+			handler: func(c *restContext) (any, error) {
+				return itemResult(adapter.GetApplicationByAppContract(c.ctx, 0))
+			},
+		},
+		{
+			path: "/apps/:appContract/inputs", summary: "List the inputs", paginated: true, item: inputType,
+			query: append([]restParam{
+				{"indexGreaterThan", reflect.Int, "Filter only inputs with index greater than a given value"},
+				{"indexLowerThan", reflect.Int, "Filter only inputs with index lower than a given value"},
+				{"msgSender", reflect.String, "Filter only inputs with the message sender"},
+				{"type", reflect.String, "Filter only inputs from 'inputbox' or 'espresso'"},
+			}, paginationParams...),
+			handler: func(c *restContext) (any, error) {
+				first, last, after, before := c.pagination()
+				where := &graphql.InputFilter{
+					IndexGreaterThan: c.intQuery("indexGreaterThan"),
+					IndexLowerThan:   c.intQuery("indexLowerThan"),
+					MsgSender:        c.addressQuery("msgSender"),
+					Type:             c.stringQuery("type"),
+				}
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetInputs(c.ctx, first, last, after, before, where))
+			},
+		},
+		{
+			path: "/apps/:appContract/inputs/:index", summary: "Get an input by index", item: inputType,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("index")
+				if c.err != nil {
+					return nil, c.err
+				}
+				return itemResult(adapter.GetInputByIndex(c.ctx, index))
+			},
+		},
+		{
+			path: "/apps/:appContract/inputs/:index/vouchers", summary: "List the vouchers of an input",
+			paginated: true, item: voucherType, query: paginationParams,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("index")
+				first, last, after, before := c.pagination()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetVouchers(c.ctx, first, last, after, before, &index, nil))
+			},
+		},
+		{
+			path:      "/apps/:appContract/inputs/:index/delegate-call-vouchers",
+			summary:   "List the delegate call vouchers of an input",
+			paginated: true, item: delegateType, query: paginationParams,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("index")
+				first, last, after, before := c.pagination()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetDelegateCallVouchers(c.ctx, first, last, after, before, &index, nil))
+			},
+		},
+		{
+			path: "/apps/:appContract/inputs/:index/notices", summary: "List the notices of an input",
+			paginated: true, item: noticeType, query: paginationParams,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("index")
+				first, last, after, before := c.pagination()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetNotices(c.ctx, first, last, after, before, &index))
+			},
+		},
+		{
+			path: "/apps/:appContract/inputs/:index/reports", summary: "List the reports of an input",
+			paginated: true, item: reportType, query: paginationParams,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("index")
+				first, last, after, before := c.pagination()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetReports(c.ctx, first, last, after, before, &index))
+			},
+		},
+		{
+			path: "/apps/:appContract/vouchers", summary: "List the vouchers",
+			paginated: true, item: voucherType, query: append(outputFilterParams, paginationParams...),
+			handler: func(c *restContext) (any, error) {
+				first, last, after, before := c.pagination()
+				filter := c.outputFilter()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetVouchers(c.ctx, first, last, after, before, nil, filter))
+			},
+		},
+		{
+			path: "/apps/:appContract/vouchers/:outputIndex", summary: "Get a voucher by output index",
+			item: voucherType,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("outputIndex")
+				if c.err != nil {
+					return nil, c.err
+				}
+				return itemResult(adapter.GetVoucher(c.ctx, index))
+			},
+		},
+		{
+			path: "/apps/:appContract/delegate-call-vouchers", summary: "List the delegate call vouchers",
+			paginated: true, item: delegateType, query: append(outputFilterParams, paginationParams...),
+			handler: func(c *restContext) (any, error) {
+				first, last, after, before := c.pagination()
+				filter := c.outputFilter()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetDelegateCallVouchers(c.ctx, first, last, after, before, nil, filter))
+			},
+		},
+		{
+			path:    "/apps/:appContract/delegate-call-vouchers/:outputIndex",
+			summary: "Get a delegate call voucher by output index", item: delegateType,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("outputIndex")
+				if c.err != nil {
+					return nil, c.err
+				}
+				return itemResult(adapter.GetDelegateCallVoucher(c.ctx, index))
+			},
+		},
+		{
+			path: "/apps/:appContract/notices", summary: "List the notices",
+			paginated: true, item: noticeType, query: paginationParams,
+			handler: func(c *restContext) (any, error) {
+				first, last, after, before := c.pagination()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetNotices(c.ctx, first, last, after, before, nil))
+			},
+		},
+		{
+			path: "/apps/:appContract/notices/:outputIndex", summary: "Get a notice by output index",
+			item: noticeType,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("outputIndex")
+				if c.err != nil {
+					return nil, c.err
+				}
+				return itemResult(adapter.GetNotice(c.ctx, index))
+			},
+		},
+		{
+			path: "/apps/:appContract/reports", summary: "List the reports",
+			paginated: true, item: reportType, query: paginationParams,
+			handler: func(c *restContext) (any, error) {
+				first, last, after, before := c.pagination()
+				if c.err != nil {
+					return nil, c.err
+				}
+				return pageResult(adapter.GetReports(c.ctx, first, last, after, before, nil))
+			},
+		},
+		{
+			path: "/apps/:appContract/reports/:index", summary: "Get a report by index", item: reportType,
+			handler: func(c *restContext) (any, error) {
+				index := c.intParam("index")
+				if c.err != nil {
+					return nil, c.err
+				}
+				return itemResult(adapter.GetReport(c.ctx, index))
+			},
+		},
+	}
+}
+
+// registerRest registers the read-only REST mirror of the reader API and its OpenAPI document.
+func registerRest(e *echo.Echo, adapter Adapter) {
+	routes := restRoutes(adapter)
+	for _, route := range routes {
+		e.GET(route.path, restHandler(route))
+	}
+	document := newOpenAPI(routes)
+	e.GET("/openapi.json", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, document)
+	})
+}
+
+func restHandler(route restRoute) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if appContract := c.Param("appContract"); strings.Contains(route.path, ":appContract") {
+			if !common.IsHexAddress(appContract) {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid appContract")
+			}
+			ctx = context.WithValue(ctx, cModel.AppContractKey, common.HexToAddress(appContract).Hex())
+		}
+		result, err := route.handler(&restContext{Context: c, ctx: ctx})
+		if err != nil {
+			if _, ok := err.(*echo.HTTPError); ok {
+				return err
+			}
+			if errors.Is(err, cRepos.ErrNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return err
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
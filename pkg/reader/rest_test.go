@@ -0,0 +1,156 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	graphql "github.com/cartesi/rollups-graphql/v2/pkg/reader/model"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type RestSuite struct {
+	suite.Suite
+	ctx       context.Context
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	echo      *echo.Echo
+}
+
+func (s *RestSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "rest.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	reportRepository := &cRepos.ReportRepository{Db: s.db}
+	for i := range 5 {
+		_, err := inputRepository.Create(s.ctx, cModel.AdvanceInput{
+			ID:             fmt.Sprint(i),
+			Index:          i,
+			Status:         cModel.CompletionStatusAccepted,
+			Payload:        "0x1122",
+			BlockNumber:    1,
+			BlockTimestamp: time.UnixMilli(1700000000000),
+			AppContract:    common.HexToAddress(ApplicationAddress),
+		})
+		s.Require().NoError(err)
+		_, err = reportRepository.CreateReport(s.ctx, cModel.Report{
+			Index:       i,
+			InputIndex:  i,
+			Payload:     "0x3344",
+			AppContract: common.HexToAddress(ApplicationAddress),
+		})
+		s.Require().NoError(err)
+	}
+	service := services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		inputRepository,
+		reportRepository,
+		&cRepos.ApplicationRepository{Db: s.db},
+	)
+	s.echo = echo.New()
+	registerRest(s.echo, NewAdapterV1(s.ctx, s.db, service))
+}
+
+func (s *RestSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestRestSuite(t *testing.T) {
+	suite.Run(t, new(RestSuite))
+}
+
+func (s *RestSuite) get(target string, result any) int {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	if rec.Code == http.StatusOK && result != nil {
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), result))
+	}
+	return rec.Code
+}
+
+func (s *RestSuite) TestInputsPagination() {
+	var page Page[graphql.Input]
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/inputs?first=2", &page))
+	s.Equal(5, page.TotalCount)
+	s.Require().Len(page.Items, 2)
+	s.Equal(1, page.Items[1].Index)
+	s.True(page.PageInfo.HasNextPage)
+
+	var next Page[graphql.Input]
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/inputs?first=2&after="+*page.PageInfo.EndCursor, &next))
+	s.Require().Len(next.Items, 2)
+	s.Equal(2, next.Items[0].Index)
+
+	var filtered Page[graphql.Input]
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/inputs?indexGreaterThan=3", &filtered))
+	s.Equal(1, filtered.TotalCount)
+}
+
+func (s *RestSuite) TestInputByIndex() {
+	var input graphql.Input
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/inputs/3", &input))
+	s.Equal(3, input.Index)
+	s.Equal(graphql.CompletionStatusAccepted, input.Status)
+	s.Equal(http.StatusNotFound, s.get("/apps/"+ApplicationAddress+"/inputs/99", nil))
+	s.Equal(http.StatusBadRequest, s.get("/apps/"+ApplicationAddress+"/inputs/x", nil))
+	s.Equal(http.StatusBadRequest, s.get("/apps/0x1234/inputs/1", nil))
+}
+
+func (s *RestSuite) TestReportsOfInput() {
+	var page Page[graphql.Report]
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/inputs/2/reports", &page))
+	s.Equal(1, page.TotalCount)
+	s.Equal(graphql.Report{Index: 2, InputIndex: 2, Payload: "0x3344"}, page.Items[0])
+
+	var report map[string]any
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/reports/4", &report))
+	s.Equal(map[string]any{"index": float64(4), "inputIndex": float64(4), "payload": "0x3344"}, report)
+}
+
+func (s *RestSuite) TestVouchersBadFilter() {
+	s.Equal(http.StatusBadRequest, s.get("/apps/"+ApplicationAddress+"/vouchers?executed=maybe", nil))
+	var page Page[graphql.Voucher]
+	s.Equal(http.StatusOK, s.get("/apps/"+ApplicationAddress+"/vouchers?executed=true", &page))
+	s.Equal(0, page.TotalCount)
+	s.Empty(page.Items)
+}
+
+func (s *RestSuite) TestOpenAPI() {
+	var document struct {
+		OpenAPI    string                               `json:"openapi"`
+		Paths      map[string]any                       `json:"paths"`
+		Components map[string]map[string]map[string]any `json:"components"`
+	}
+	s.Equal(http.StatusOK, s.get("/openapi.json", &document))
+	s.Equal(OPENAPI_VERSION, document.OpenAPI)
+	s.Contains(document.Paths, "/apps/{appContract}/inputs/{index}/vouchers")
+	s.Contains(document.Paths, "/apps/{appContract}/vouchers/{outputIndex}")
+	s.Len(document.Paths, len(restRoutes(nil)))
+	schemas := document.Components["schemas"]
+	s.Contains(schemas, "InputPage")
+	voucher := schemas["Voucher"]["properties"].(map[string]any)
+	s.Contains(voucher, "inputIndex")
+	s.Equal(map[string]any{"$ref": "#/components/schemas/Proof"}, voucher["proof"])
+}
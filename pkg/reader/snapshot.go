@@ -0,0 +1,59 @@
+package reader
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/jmoiron/sqlx"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// snapshotExtension runs each query in a read-only transaction of the database,
+// so all of its resolvers read the same snapshot even while the synchronizers write.
+type snapshotExtension struct {
+	db *sqlx.DB
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = snapshotExtension{}
+
+func (snapshotExtension) ExtensionName() string {
+	return "Snapshot"
+}
+
+func (snapshotExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e snapshotExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil || oc.Operation.Operation != ast.Query {
+		return next(ctx)
+	}
+	if _, ok := repository.GetTransaction(ctx); ok {
+		return next(ctx)
+	}
+	// the snapshot is read from the database chosen by the read router, if any
+	ctx, tx, err := repository.StartReadSnapshot(ctx, repository.ReadDB(ctx, e.db))
+	if err != nil {
+		slog.ErrorContext(ctx, "graphql: failed to start the snapshot", "error", err)
+		return graphql.ErrorResponse(ctx, "failed to read the database")
+	}
+	// the snapshot only reads, rolling it back releases it the same way as a commit;
+	// it is already done when the request was canceled
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			slog.WarnContext(ctx, "graphql: failed to release the snapshot", "error", err)
+		}
+	}()
+	return next(ctx)
+}
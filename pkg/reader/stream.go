@@ -0,0 +1,119 @@
+package reader
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	"github.com/cartesi/rollups-graphql/v2/pkg/export"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	MIMEApplicationNDJSON = "application/x-ndjson"
+
+	// Rows written between two flushes of the response
+	STREAM_FLUSH_ROWS = 100
+)
+
+// Writes the rows of an application from the given index.
+type streamFunc func(
+	ctx context.Context, appContract string, fromIndex uint64, write func(record export.Record) error,
+) error
+
+// registerStream registers the endpoints streaming the rows of an application as
+// newline-delimited JSON, one object per line as in the export command.
+// The rows are written as they are read from the database cursor, so a slow client
+// slows down the reads instead of piling them up in memory.
+func registerStream(e *echo.Echo, convenienceService *services.ConvenienceService) {
+	e.GET("/api/v1/apps/:appContract/inputs", streamHandler(
+		func(ctx context.Context, appContract string, fromIndex uint64, write func(export.Record) error) error {
+			return convenienceService.InputRepository.StreamByAppContract(ctx, appContract, fromIndex,
+				func(input cModel.AdvanceInput) error {
+					record, err := export.InputRecord(ctx, input)
+					if err != nil {
+						return err
+					}
+					return write(record)
+				})
+		}))
+	e.GET("/api/v1/apps/:appContract/vouchers", streamHandler(
+		func(ctx context.Context, appContract string, fromIndex uint64, write func(export.Record) error) error {
+			return convenienceService.VoucherRepository.StreamByAppContract(ctx, appContract, fromIndex,
+				func(voucher cModel.ConvenienceVoucher) error {
+					return write(export.VoucherRecord(voucher))
+				})
+		}))
+	e.GET("/api/v1/apps/:appContract/notices", streamHandler(
+		func(ctx context.Context, appContract string, fromIndex uint64, write func(export.Record) error) error {
+			return convenienceService.NoticeRepository.StreamByAppContract(ctx, appContract, fromIndex,
+				func(notice cModel.ConvenienceNotice) error {
+					return write(export.NoticeRecord(notice))
+				})
+		}))
+	e.GET("/api/v1/apps/:appContract/reports", streamHandler(
+		func(ctx context.Context, appContract string, fromIndex uint64, write func(export.Record) error) error {
+			return convenienceService.ReportRepository.StreamByAppContract(ctx, appContract, fromIndex,
+				func(report cModel.Report) error {
+					return write(export.ReportRecord(report))
+				})
+		}))
+}
+
+func streamHandler(stream streamFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		appContract := c.Param("appContract")
+		if !common.IsHexAddress(appContract) {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid application address")
+		}
+		fromIndex := uint64(0)
+		if raw := c.QueryParam("fromIndex"); raw != "" {
+			var err error
+			fromIndex, err = strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid fromIndex")
+			}
+		}
+
+		ctx := c.Request().Context()
+		res := c.Response()
+		rows := 0
+		err := stream(ctx, common.HexToAddress(appContract).Hex(), fromIndex, func(record export.Record) error {
+			if !res.Committed {
+				res.Header().Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+				res.WriteHeader(http.StatusOK)
+			}
+			line, err := record.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			_, err = res.Write(append(line, '\n'))
+			if err != nil {
+				return err
+			}
+			rows++
+			if rows%STREAM_FLUSH_ROWS == 0 {
+				res.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			if !res.Committed {
+				return err
+			}
+			// the status is already sent, so the client only sees a broken stream
+			slog.ErrorContext(ctx, "Stream aborted", "path", c.Path(), "rows", rows, "error", err)
+			panic(http.ErrAbortHandler)
+		}
+		if !res.Committed {
+			res.Header().Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+			res.WriteHeader(http.StatusOK)
+		}
+		res.Flush()
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package reader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	cModel "github.com/cartesi/rollups-graphql/v2/pkg/convenience/model"
+	cRepos "github.com/cartesi/rollups-graphql/v2/pkg/convenience/repository"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/services"
+	"github.com/cartesi/rollups-graphql/v2/pkg/export"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamSuite struct {
+	suite.Suite
+	ctx       context.Context
+	db        *sqlx.DB
+	dbFactory *commons.DbFactory
+	echo      *echo.Echo
+}
+
+func (s *StreamSuite) SetupTest() {
+	var err error
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	s.dbFactory, err = commons.NewDbFactory()
+	s.Require().NoError(err)
+	s.db = s.dbFactory.CreateDb(s.ctx, "stream.sqlite3")
+	s.Require().NoError(migrations.Migrate(s.ctx, s.db))
+	inputRepository := &cRepos.InputRepository{Db: s.db}
+	s.Require().NoError(inputRepository.CreateTables(s.ctx))
+	reportRepository := &cRepos.ReportRepository{Db: s.db}
+	for i := range 5 {
+		_, err := inputRepository.Create(s.ctx, cModel.AdvanceInput{
+			ID:             fmt.Sprint(i),
+			Index:          i,
+			Status:         cModel.CompletionStatusAccepted,
+			Payload:        "0x1122",
+			BlockNumber:    1,
+			BlockTimestamp: time.UnixMilli(1700000000000),
+			AppContract:    common.HexToAddress(ApplicationAddress),
+		})
+		s.Require().NoError(err)
+		_, err = reportRepository.CreateReport(s.ctx, cModel.Report{
+			Index:       i,
+			InputIndex:  i,
+			Payload:     "0x3344",
+			AppContract: common.HexToAddress(ApplicationAddress),
+		})
+		s.Require().NoError(err)
+	}
+	s.echo = echo.New()
+	registerStream(s.echo, services.NewConvenienceService(
+		&cRepos.VoucherRepository{Db: s.db},
+		&cRepos.NoticeRepository{Db: s.db},
+		inputRepository,
+		reportRepository,
+		&cRepos.ApplicationRepository{Db: s.db},
+	))
+}
+
+func (s *StreamSuite) TearDownTest() {
+	s.NoError(s.db.Close())
+	s.dbFactory.Cleanup(s.ctx)
+}
+
+func TestStreamSuite(t *testing.T) {
+	suite.Run(t, new(StreamSuite))
+}
+
+func (s *StreamSuite) get(target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *StreamSuite) TestStreamInputs() {
+	rec := s.get("/api/v1/apps/" + strings.ToLower(ApplicationAddress) + "/inputs?fromIndex=3")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(MIMEApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+	lines := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+	s.Require().Len(lines, 2)
+	s.True(strings.HasPrefix(lines[0], `{"index":3,"id":"3","status":"ACCEPTED"`))
+	s.True(strings.HasPrefix(lines[1], `{"index":4,`))
+}
+
+func (s *StreamSuite) TestStreamReports() {
+	rec := s.get("/api/v1/apps/" + ApplicationAddress + "/reports")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(5, strings.Count(rec.Body.String(), "\n"))
+	s.True(strings.HasPrefix(rec.Body.String(), `{"index":0,"inputIndex":0,"payload":"0x3344"}`+"\n"))
+}
+
+func (s *StreamSuite) TestStreamEmpty() {
+	rec := s.get("/api/v1/apps/" + ApplicationAddress + "/vouchers")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(MIMEApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+	s.Empty(rec.Body.String())
+}
+
+func (s *StreamSuite) TestStreamBadRequest() {
+	s.Equal(http.StatusBadRequest, s.get("/api/v1/apps/0x1234/notices").Code)
+	s.Equal(http.StatusBadRequest, s.get("/api/v1/apps/"+ApplicationAddress+"/notices?fromIndex=-1").Code)
+}
+
+func (s *StreamSuite) TestStreamAborted() {
+	e := echo.New()
+	e.GET("/fail/:appContract", streamHandler(
+		func(ctx context.Context, appContract string, fromIndex uint64, write func(export.Record) error) error {
+			return errors.New("cursor closed")
+		}))
+	req := httptest.NewRequest(http.MethodGet, "/fail/"+ApplicationAddress, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	s.Equal(http.StatusInternalServerError, rec.Code)
+
+	e.GET("/broken/:appContract", streamHandler(
+		func(ctx context.Context, appContract string, fromIndex uint64, write func(export.Record) error) error {
+			err := write(export.Record{{Name: "index", Value: 0}})
+			s.Require().NoError(err)
+			return errors.New("cursor closed")
+		}))
+	req = httptest.NewRequest(http.MethodGet, "/broken/"+ApplicationAddress, nil)
+	s.PanicsWithValue(http.ErrAbortHandler, func() {
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
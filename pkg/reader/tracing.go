@@ -0,0 +1,62 @@
+package reader
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/cartesi/rollups-graphql/v2/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// tracingExtension starts a span for each operation and for each field with a resolver.
+// The fields read from their parent object do not get a span.
+type tracingExtension struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = tracingExtension{}
+
+func (tracingExtension) ExtensionName() string {
+	return "Tracing"
+}
+
+func (tracingExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (tracingExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if !graphql.HasOperationContext(ctx) {
+		return next(ctx)
+	}
+	oc := graphql.GetOperationContext(ctx)
+	operation := "query"
+	if oc.Operation != nil {
+		operation = string(oc.Operation.Operation)
+	}
+	ctx, span := tracing.Start(ctx, "graphql."+operation+" "+oc.OperationName,
+		attribute.String("graphql.operation.type", operation),
+		attribute.String("graphql.operation.name", oc.OperationName),
+	)
+	defer span.End()
+	response := next(ctx)
+	if response != nil && len(response.Errors) > 0 {
+		span.SetStatus(codes.Error, response.Errors.Error())
+	}
+	return response
+}
+
+func (tracingExtension) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+	ctx, span := tracing.Start(ctx, "graphql.resolve "+fc.Object+"."+fc.Field.Name,
+		attribute.String("graphql.field.path", fc.Path().String()),
+	)
+	res, err := next(ctx)
+	tracing.End(span, err)
+	return res, err
+}
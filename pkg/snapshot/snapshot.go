@@ -0,0 +1,447 @@
+// This package copies the convenience database, sync checkpoints included, to a portable
+// snapshot that can be restored onto an empty database of either backend.
+//
+// A snapshot is a stream of JSON lines: a header, then for each table a line with its
+// columns followed by one JSON array per row, and a trailer with the row counts.
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	FORMAT         = "rollups-graphql-snapshot"
+	FORMAT_VERSION = 1
+
+	TYPE_TEXT      = "text"
+	TYPE_INTEGER   = "integer"
+	TYPE_NUMERIC   = "numeric"
+	TYPE_BOOLEAN   = "boolean"
+	TYPE_TIMESTAMP = "timestamp"
+)
+
+// Tables of the convenience database in restore order. The raw references and
+// synchronizer_fetch hold the checkpoints the synchronizers resume from.
+var Tables = []string{
+	"convenience_application",
+	"convenience_inputs",
+	"convenience_vouchers",
+	"convenience_notices",
+	"convenience_reports",
+	"convenience_input_raw_references",
+	"convenience_output_raw_references",
+	"convenience_dead_letters",
+	"synchronizer_fetch",
+}
+
+var ErrNotEmpty = errors.New("the database is not empty")
+
+type Header struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	SchemaVersion uint      `json:"schemaVersion"`
+	Database      string    `json:"database"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type Column struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Summary describes a snapshot written or restored.
+type Summary struct {
+	Header
+	Rows map[string]int64 `json:"rows"`
+}
+
+// A line of the snapshot other than a row.
+type record struct {
+	Table   string           `json:"table,omitempty"`
+	Columns []Column         `json:"columns,omitempty"`
+	Rows    map[string]int64 `json:"rows,omitempty"`
+}
+
+// Snapshot writes all the tables as seen by a single read-only transaction.
+func Snapshot(ctx context.Context, db *sqlx.DB, w io.Writer) (*Summary, error) {
+	version, _, err := schemaVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	// a deferred SQLite transaction reads from the same snapshot once started
+	txOpts := &sql.TxOptions{ReadOnly: true}
+	if db.DriverName() != "sqlite3" {
+		txOpts.Isolation = sql.LevelRepeatableRead
+	}
+	tx, err := db.BeginTxx(ctx, txOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	summary := &Summary{
+		Header: Header{
+			Format:        FORMAT,
+			Version:       FORMAT_VERSION,
+			SchemaVersion: version,
+			Database:      db.DriverName(),
+			CreatedAt:     time.Now().UTC(),
+		},
+		Rows: map[string]int64{},
+	}
+	encoder := json.NewEncoder(w)
+	err = encoder.Encode(summary.Header)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range Tables {
+		count, err := snapshotTable(ctx, tx, encoder, table)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot of %s: %w", table, err)
+		}
+		summary.Rows[table] = count
+	}
+	err = encoder.Encode(record{Rows: summary.Rows})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func snapshotTable(ctx context.Context, tx *sqlx.Tx, encoder *json.Encoder, table string) (int64, error) {
+	rows, err := tx.QueryxContext(ctx, `SELECT * FROM `+table)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	columns := make([]Column, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = Column{Name: columnType.Name(), Type: portableType(columnType.DatabaseTypeName())}
+	}
+	err = encoder.Encode(record{Table: table, Columns: columns})
+	if err != nil {
+		return 0, err
+	}
+	count := int64(0)
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return count, err
+		}
+		for i, value := range values {
+			values[i], err = encodeValue(columns[i], value)
+			if err != nil {
+				return count, err
+			}
+		}
+		err = encoder.Encode(values)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
+
+// Restore loads a snapshot, gzipped or not, into an empty database in a single transaction.
+// The schema is migrated to the latest version first, so an older snapshot can be restored.
+func Restore(ctx context.Context, db *sqlx.DB, r io.Reader) (*Summary, error) {
+	reader, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	var header Header
+	err = decoder.Decode(&header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot header: %w", err)
+	}
+	if header.Format != FORMAT || header.Version != FORMAT_VERSION {
+		return nil, fmt.Errorf("unsupported snapshot format %s version %d", header.Format, header.Version)
+	}
+	err = migrations.CheckSchema(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	_, latest, err := schemaVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if header.SchemaVersion > latest {
+		return nil, fmt.Errorf(
+			"snapshot schema version %d is newer than the latest known version %d",
+			header.SchemaVersion, latest,
+		)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, table := range Tables {
+		var count int64
+		err = tx.GetContext(ctx, &count, `SELECT count(*) FROM `+table)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, fmt.Errorf("%w, %s has %d rows", ErrNotEmpty, table, count)
+		}
+	}
+
+	summary := &Summary{Header: header, Rows: map[string]int64{}}
+	var (
+		table   string
+		columns []Column
+		insert  *sqlx.Stmt
+	)
+	defer func() {
+		if insert != nil {
+			insert.Close()
+		}
+	}()
+	for {
+		var line json.RawMessage
+		err = decoder.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("truncated snapshot, the row counts are missing")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(line) > 0 && line[0] == '[' {
+			if insert == nil {
+				return nil, fmt.Errorf("row without a table")
+			}
+			err = restoreRow(ctx, insert, columns, line)
+			if err != nil {
+				return nil, fmt.Errorf("restore of %s: %w", table, err)
+			}
+			summary.Rows[table]++
+			continue
+		}
+		var rec record
+		err = json.Unmarshal(line, &rec)
+		if err != nil {
+			return nil, err
+		}
+		if rec.Table == "" {
+			for name, count := range rec.Rows {
+				if summary.Rows[name] != count {
+					return nil, fmt.Errorf(
+						"snapshot of %s has %d rows, expected %d", name, summary.Rows[name], count,
+					)
+				}
+			}
+			break
+		}
+		if insert != nil {
+			insert.Close()
+		}
+		table, columns = rec.Table, rec.Columns
+		summary.Rows[table] = 0
+		insert, err = prepareInsert(ctx, tx, table, columns)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if db.DriverName() != "sqlite3" {
+		// ids of synchronizer_fetch restored explicitly do not advance its sequence
+		_, err = tx.ExecContext(ctx, `SELECT setval(pg_get_serial_sequence('synchronizer_fetch', 'id'),
+			COALESCE(MAX(id), 0) + 1, false) FROM synchronizer_fetch`)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Snapshot restored", "createdAt", header.CreatedAt, "rows", summary.Rows)
+	return summary, nil
+}
+
+// prepareInsert checks the table and columns against the schema before building the statement.
+func prepareInsert(ctx context.Context, tx *sqlx.Tx, table string, columns []Column) (*sqlx.Stmt, error) {
+	if !slices.Contains(Tables, table) {
+		return nil, fmt.Errorf("unknown table %s", table)
+	}
+	rows, err := tx.QueryxContext(ctx, `SELECT * FROM `+table+` LIMIT 0`)
+	if err != nil {
+		return nil, err
+	}
+	known, err := rows.Columns()
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(columns))
+	params := make([]string, len(columns))
+	for i, column := range columns {
+		if !slices.Contains(known, column.Name) {
+			return nil, fmt.Errorf("unknown column %s.%s", table, column.Name)
+		}
+		names[i] = column.Name
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return tx.PreparexContext(ctx, fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`,
+		table, strings.Join(names, ", "), strings.Join(params, ", ")))
+}
+
+func restoreRow(ctx context.Context, insert *sqlx.Stmt, columns []Column, line json.RawMessage) error {
+	decoder := json.NewDecoder(strings.NewReader(string(line)))
+	decoder.UseNumber()
+	var values []any
+	err := decoder.Decode(&values)
+	if err != nil {
+		return err
+	}
+	if len(values) != len(columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(values), len(columns))
+	}
+	for i, value := range values {
+		values[i], err = decodeValue(columns[i], value)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = insert.ExecContext(ctx, values...)
+	return err
+}
+
+func schemaVersion(ctx context.Context, db *sqlx.DB) (uint, uint, error) {
+	schema, err := migrations.New(ctx, db)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer schema.Close()
+	status, err := schema.Check()
+	if err != nil {
+		return 0, 0, err
+	}
+	return status.Version, status.Latest, nil
+}
+
+func decompress(r io.Reader) (io.Reader, error) {
+	reader := bufio.NewReader(r)
+	magic, err := reader.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(reader)
+	}
+	return reader, nil
+}
+
+// portableType maps the column types of both backends to the types of the snapshot.
+func portableType(databaseType string) string {
+	databaseType = strings.ToUpper(databaseType)
+	switch {
+	case strings.Contains(databaseType, "BOOL"):
+		return TYPE_BOOLEAN
+	case strings.Contains(databaseType, "INT"), strings.Contains(databaseType, "SERIAL"):
+		return TYPE_INTEGER
+	case strings.Contains(databaseType, "NUMERIC"), strings.Contains(databaseType, "DECIMAL"):
+		return TYPE_NUMERIC
+	case strings.Contains(databaseType, "TIME"), strings.Contains(databaseType, "DATE"):
+		return TYPE_TIMESTAMP
+	default:
+		return TYPE_TEXT
+	}
+}
+
+func encodeValue(column Column, value any) (any, error) {
+	if bytes, ok := value.([]byte); ok {
+		value = string(bytes)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	switch column.Type {
+	case TYPE_BOOLEAN:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case TYPE_INTEGER:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case TYPE_NUMERIC:
+		switch v := value.(type) {
+		case int64:
+			return json.Number(strconv.FormatInt(v, 10)), nil
+		case float64:
+			return json.Number(strconv.FormatFloat(v, 'f', -1, 64)), nil
+		case string:
+			return json.Number(v), nil
+		}
+	case TYPE_TIMESTAMP:
+		switch v := value.(type) {
+		case time.Time:
+			return v.Format(time.RFC3339Nano), nil
+		case string:
+			return v, nil
+		}
+	case TYPE_TEXT:
+		return fmt.Sprint(value), nil
+	}
+	return nil, fmt.Errorf("unexpected %T value in %s column %s", value, column.Type, column.Name)
+}
+
+func decodeValue(column Column, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch column.Type {
+	case TYPE_BOOLEAN:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case TYPE_INTEGER:
+		if v, ok := value.(json.Number); ok {
+			return v.Int64()
+		}
+	case TYPE_NUMERIC:
+		if v, ok := value.(json.Number); ok {
+			return v.String(), nil
+		}
+	case TYPE_TIMESTAMP:
+		if v, ok := value.(string); ok {
+			return time.Parse(time.RFC3339Nano, v)
+		}
+	case TYPE_TEXT:
+		if v, ok := value.(string); ok {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %T value in %s column %s", value, column.Type, column.Name)
+}
@@ -0,0 +1,151 @@
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/stretchr/testify/suite"
+)
+
+const snapshotApp = "0x5112cF49F2511ac7b13A032c4c62A48410FC28Fb"
+
+type SnapshotSuite struct {
+	suite.Suite
+	ctx     context.Context
+	tempDir string
+	source  *sqlx.DB
+	target  *sqlx.DB
+}
+
+func (s *SnapshotSuite) SetupTest() {
+	s.ctx = context.Background()
+	commons.ConfigureLog(slog.LevelDebug)
+	tempDir, err := os.MkdirTemp("", "")
+	s.Require().NoError(err)
+	s.tempDir = tempDir
+	s.source = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "source.sqlite3"))
+	s.target = sqlx.MustConnect("sqlite3", filepath.Join(tempDir, "target.sqlite3"))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.source))
+	s.Require().NoError(migrations.Migrate(s.ctx, s.target))
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC)
+	queries := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO convenience_application (id, name, app_contract) VALUES (1, 'app', $1)`,
+			[]any{snapshotApp}},
+		{`INSERT INTO convenience_inputs (id, input_index, app_contract, status, payload, block_timestamp)
+			VALUES ('1', 0, $1, 'ACCEPTED', '0x01', 1700000000000)`, []any{snapshotApp}},
+		{`INSERT INTO convenience_vouchers (input_index, output_index, app_contract, payload, executed, pruned_at)
+			VALUES (0, 0, $1, '0x', true, $2)`, []any{snapshotApp, createdAt}},
+		{`INSERT INTO convenience_notices (input_index, output_index, app_contract, payload)
+			VALUES (0, 1, $1, '0x03')`, []any{snapshotApp}},
+		{`INSERT INTO convenience_input_raw_references (id, app_id, input_index, app_contract, status, created_at)
+			VALUES ('1', 1, 0, $1, 'ACCEPTED', $2)`, []any{snapshotApp, createdAt}},
+		{`INSERT INTO convenience_output_raw_references (app_id, input_index, app_contract, output_index,
+			has_proof, type, executed, updated_at, created_at, sync_priority)
+			VALUES (1, 0, $1, 0, false, 'voucher', true, $2, $2, 1)`, []any{snapshotApp, createdAt}},
+		{`INSERT INTO synchronizer_fetch (id, timestamp_after, ini_cursor_after) VALUES (7, 1700000000, 'cursor')`,
+			nil},
+	}
+	for _, q := range queries {
+		_, err := s.source.Exec(q.query, q.args...)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *SnapshotSuite) TearDownTest() {
+	s.source.Close()
+	s.target.Close()
+	s.NoError(os.RemoveAll(s.tempDir))
+}
+
+func TestSnapshotSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotSuite))
+}
+
+// withoutHeader drops the first line, which has the creation time.
+func withoutHeader(snapshot string) string {
+	_, rest, _ := strings.Cut(snapshot, "\n")
+	return rest
+}
+
+func (s *SnapshotSuite) TestSnapshotAndRestore() {
+	var out bytes.Buffer
+	summary, err := Snapshot(s.ctx, s.source, &out)
+	s.Require().NoError(err)
+	s.Equal(uint(3), summary.SchemaVersion)
+	s.Equal(int64(1), summary.Rows["convenience_inputs"])
+	s.Equal(int64(0), summary.Rows["convenience_reports"])
+	s.Equal(int64(1), summary.Rows["synchronizer_fetch"])
+
+	restored, err := Restore(s.ctx, s.target, bytes.NewReader(out.Bytes()))
+	s.Require().NoError(err)
+	s.Equal(summary.Rows, restored.Rows)
+
+	var again bytes.Buffer
+	_, err = Snapshot(s.ctx, s.target, &again)
+	s.Require().NoError(err)
+	s.Equal(withoutHeader(out.String()), withoutHeader(again.String()))
+
+	var createdAt time.Time
+	s.Require().NoError(s.target.Get(&createdAt, `SELECT created_at FROM convenience_input_raw_references`))
+	s.Equal(time.Date(2025, 1, 2, 3, 4, 5, 6000, time.UTC), createdAt.UTC())
+	var executed bool
+	s.Require().NoError(s.target.Get(&executed, `SELECT executed FROM convenience_vouchers`))
+	s.True(executed)
+}
+
+func (s *SnapshotSuite) TestRestoreGzip() {
+	var out bytes.Buffer
+	w := gzip.NewWriter(&out)
+	_, err := Snapshot(s.ctx, s.source, w)
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	summary, err := Restore(s.ctx, s.target, &out)
+	s.Require().NoError(err)
+	s.Equal(int64(1), summary.Rows["convenience_notices"])
+}
+
+func (s *SnapshotSuite) TestRestoreNotEmpty() {
+	var out bytes.Buffer
+	_, err := Snapshot(s.ctx, s.source, &out)
+	s.Require().NoError(err)
+	_, err = Restore(s.ctx, s.source, &out)
+	s.ErrorIs(err, ErrNotEmpty)
+}
+
+func (s *SnapshotSuite) TestRestoreTruncated() {
+	var out bytes.Buffer
+	_, err := Snapshot(s.ctx, s.source, &out)
+	s.Require().NoError(err)
+	lines := strings.SplitAfter(out.String(), "\n")
+	truncated := strings.Join(lines[:len(lines)-3], "")
+	_, err = Restore(s.ctx, s.target, strings.NewReader(truncated))
+	s.ErrorContains(err, "truncated snapshot")
+
+	// nothing is left behind
+	var count int
+	s.Require().NoError(s.target.Get(&count, `SELECT count(*) FROM convenience_inputs`))
+	s.Zero(count)
+}
+
+func (s *SnapshotSuite) TestRestoreUnknownColumn() {
+	snapshot := `{"format":"rollups-graphql-snapshot","version":1,"schemaVersion":3}
+{"table":"convenience_application","columns":[{"name":"id; DROP TABLE x","type":"integer"}]}
+`
+	_, err := Restore(s.ctx, s.target, strings.NewReader(snapshot))
+	s.ErrorContains(err, "unknown column")
+}
@@ -0,0 +1,45 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// The gRPC worker starts and manage a gRPC server.
+type GrpcWorker struct {
+	Address string
+	Server  *grpc.Server
+}
+
+func (w GrpcWorker) String() string {
+	return "grpc"
+}
+
+func (w GrpcWorker) Start(ctx context.Context, ready chan<- struct{}) error {
+	ln, err := net.Listen("tcp", w.Address)
+	if err != nil {
+		return err
+	}
+
+	ready <- struct{}{}
+
+	// create the goroutine to stop the server
+	// a graceful stop would wait forever for the streaming calls
+	go func() {
+		<-ctx.Done()
+		w.Server.Stop()
+	}()
+
+	// serve
+	err = w.Server.Serve(ln)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+	return err
+}
@@ -9,15 +9,26 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"strings"
 )
 
+// Prefix of the addresses of unix sockets, e.g. unix:/run/admin.sock
+const UnixAddressPrefix = "unix:"
+
 // The HTTP worker starts and manage an HTTP server.
 type HttpWorker struct {
+	// Optional name to tell servers apart, defaults to http
+	Name string
+	// TCP address, or path of a unix socket after the unix: prefix
 	Address string
 	Handler http.Handler
 }
 
 func (w HttpWorker) String() string {
+	if w.Name != "" {
+		return w.Name
+	}
 	return "http"
 }
 
@@ -27,7 +38,7 @@ func (w HttpWorker) Start(ctx context.Context, ready chan<- struct{}) error {
 		Addr:    w.Address,
 		Handler: w.Handler,
 	}
-	ln, err := net.Listen("tcp", w.Address)
+	ln, err := listen(w.Address)
 	if err != nil {
 		return err
 	}
@@ -50,3 +61,26 @@ func (w HttpWorker) Start(ctx context.Context, ready chan<- struct{}) error {
 	}
 	return err
 }
+
+// listen on a TCP address or on a unix socket only reachable by its owner.
+// The socket left by a previous run is removed.
+func listen(address string) (net.Listener, error) {
+	path, ok := strings.CutPrefix(address, UnixAddressPrefix)
+	if !ok {
+		return net.Listen("tcp", address)
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
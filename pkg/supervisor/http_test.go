@@ -0,0 +1,61 @@
+package supervisor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HttpSuite struct {
+	suite.Suite
+}
+
+func TestHttpSuite(t *testing.T) {
+	suite.Run(t, new(HttpSuite))
+}
+
+func (s *HttpSuite) TestUnixSocket() {
+	path := filepath.Join(s.T().TempDir(), "admin.sock")
+	// a socket left by a previous run does not prevent the start
+	stale, err := net.Listen("unix", path)
+	s.Require().NoError(err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	s.Require().NoError(stale.Close())
+
+	worker := HttpWorker{
+		Address: UnixAddressPrefix + path,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ready := make(chan struct{}, 1)
+	done := make(chan error)
+	go func() {
+		done <- worker.Start(ctx, ready)
+	}()
+	<-ready
+
+	info, err := os.Stat(path)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	client := http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	resp, err := client.Get("http://admin/admin/workers")
+	s.Require().NoError(err)
+	s.NoError(resp.Body.Close())
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+
+	cancel()
+	s.NoError(<-done)
+}
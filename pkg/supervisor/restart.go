@@ -0,0 +1,154 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type RestartMode string
+
+const (
+	// Exit with the worker, stopping the supervisor. This is the default.
+	RestartNever RestartMode = "never"
+	// Restart when the worker returns an error or panics.
+	RestartOnFailure RestartMode = "on-failure"
+	// Restart whenever the worker exits before the context is done.
+	RestartAlways RestartMode = "always"
+)
+
+const (
+	DefaultMinBackoff = time.Second
+	DefaultMaxBackoff = time.Minute
+)
+
+type RestartPolicy struct {
+	Mode RestartMode
+	// Maximum number of restarts before giving up, zero means no limit
+	MaxRestarts int
+	// The backoff starts at MinBackoff and doubles after each restart up to MaxBackoff.
+	// It is reset when the worker runs for longer than MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// RestartWorker restarts the wrapped worker according to its policy,
+// so a failing worker does not bring down the other ones.
+// It only exits, stopping the supervisor, when the policy gives up.
+type RestartWorker struct {
+	Worker Worker
+	Policy RestartPolicy
+	States *WorkerStates
+}
+
+// WithRestart registers the worker with a restart policy.
+func WithRestart(worker Worker, policy RestartPolicy, states *WorkerStates) RestartWorker {
+	if policy.MinBackoff == 0 {
+		policy.MinBackoff = DefaultMinBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = DefaultMaxBackoff
+	}
+	policy.MaxBackoff = max(policy.MaxBackoff, policy.MinBackoff)
+	states.setPolicy(worker.String(), policy.Mode)
+	return RestartWorker{Worker: worker, Policy: policy, States: states}
+}
+
+func (w RestartWorker) String() string {
+	return w.Worker.String()
+}
+
+func (w RestartWorker) Start(ctx context.Context, ready chan<- struct{}) error {
+	name := w.String()
+	backoff := w.Policy.MinBackoff
+	restarts := 0
+	readySent := false
+	for {
+		startedAt := time.Now()
+		err := w.startOnce(ctx, ready, &readySent)
+		if ctx.Err() != nil {
+			return err
+		}
+		if !w.shouldRestart(err) {
+			return err
+		}
+		if w.Policy.MaxRestarts > 0 && restarts >= w.Policy.MaxRestarts {
+			slog.ErrorContext(ctx, "supervisor: worker reached the restart limit",
+				"restarts", restarts, "error", err)
+			if err == nil {
+				err = fmt.Errorf("worker %s exited after %d restarts", name, restarts)
+			}
+			return err
+		}
+		if time.Since(startedAt) > w.Policy.MaxBackoff {
+			backoff = w.Policy.MinBackoff
+		}
+		restarts++
+		w.States.recordRestart(name, err, backoff)
+		slog.WarnContext(ctx, "supervisor: restarting worker",
+			"restart", restarts, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, w.Policy.MaxBackoff)
+	}
+}
+
+func (w RestartWorker) shouldRestart(err error) bool {
+	switch w.Policy.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// startOnce runs the worker a single time, turning a panic into an error
+// and forwarding its first ready signal to the supervisor.
+func (w RestartWorker) startOnce(ctx context.Context, ready chan<- struct{}, readySent *bool) (err error) {
+	innerReady := make(chan struct{}, 1)
+	done := make(chan struct{})
+	forwarded := make(chan bool, 1)
+	defer func() {
+		if <-forwarded {
+			*readySent = true
+		}
+	}()
+	defer close(done)
+	go func() {
+		select {
+		case <-innerReady:
+			w.States.setStatus(w.String(), WorkerRunning, nil)
+			if *readySent {
+				forwarded <- false
+				return
+			}
+			select {
+			case ready <- struct{}{}:
+				forwarded <- true
+			case <-ctx.Done():
+				forwarded <- false
+			}
+		case <-done:
+			forwarded <- false
+		}
+	}()
+	if w.Policy.Mode != RestartNever {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("worker panic: %v", r)
+			}
+		}()
+	}
+	err = w.Worker.Start(ctx, innerReady)
+	if errors.Is(err, context.Canceled) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
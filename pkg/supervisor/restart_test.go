@@ -0,0 +1,118 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type flakyWorker struct {
+	failures int32
+	starts   atomic.Int32
+	panics   bool
+}
+
+func (w *flakyWorker) String() string {
+	return "flaky"
+}
+
+func (w *flakyWorker) Start(ctx context.Context, ready chan<- struct{}) error {
+	ready <- struct{}{}
+	if w.starts.Add(1) <= w.failures {
+		if w.panics {
+			panic("node database unavailable")
+		}
+		return errors.New("node database unavailable")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+type RestartSuite struct {
+	suite.Suite
+	states *WorkerStates
+}
+
+func (s *RestartSuite) SetupTest() {
+	s.states = NewWorkerStates()
+}
+
+func TestRestartSuite(t *testing.T) {
+	suite.Run(t, new(RestartSuite))
+}
+
+func (s *RestartSuite) policy(mode RestartMode, maxRestarts int) RestartPolicy {
+	return RestartPolicy{
+		Mode:        mode,
+		MaxRestarts: maxRestarts,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func (s *RestartSuite) TestRestartOnFailureUntilHealthy() {
+	worker := &flakyWorker{failures: 2}
+	restartWorker := WithRestart(worker, s.policy(RestartOnFailure, 0), s.states)
+	ctx, cancel := context.WithCancel(context.Background())
+	ready := make(chan struct{})
+	result := make(chan error)
+	go func() {
+		result <- restartWorker.Start(ctx, ready)
+	}()
+	<-ready
+	s.Eventually(func() bool { return worker.starts.Load() == 3 }, time.Second, time.Millisecond)
+	cancel()
+	s.NoError(<-result)
+
+	state, ok := s.states.Get("flaky")
+	s.Require().True(ok)
+	s.Equal(2, state.Restarts)
+	s.Equal(RestartOnFailure, state.Policy)
+	s.Len(state.History, 2)
+	s.Equal("node database unavailable", state.LastError)
+}
+
+func (s *RestartSuite) TestGiveUpAfterMaxRestarts() {
+	worker := &flakyWorker{failures: 10, panics: true}
+	restartWorker := WithRestart(worker, s.policy(RestartOnFailure, 2), s.states)
+	ready := make(chan struct{}, 1)
+	err := restartWorker.Start(context.Background(), ready)
+	s.ErrorContains(err, "worker panic")
+	s.Equal(int32(3), worker.starts.Load())
+}
+
+func (s *RestartSuite) TestNeverRestart() {
+	worker := &flakyWorker{failures: 1}
+	restartWorker := WithRestart(worker, s.policy(RestartNever, 0), s.states)
+	ready := make(chan struct{}, 1)
+	err := restartWorker.Start(context.Background(), ready)
+	s.Error(err)
+	s.Equal(int32(1), worker.starts.Load())
+}
+
+func (s *RestartSuite) TestSupervisorKeepsRunningWhileWorkerRestarts() {
+	worker := &flakyWorker{failures: 3}
+	supervisor := SupervisorWorker{
+		Workers: []Worker{WithRestart(worker, s.policy(RestartOnFailure, 0), s.states)},
+		States:  s.states,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ready := make(chan struct{}, 1)
+	result := make(chan error)
+	go func() {
+		result <- supervisor.Start(ctx, ready)
+	}()
+	<-ready
+	s.Eventually(func() bool {
+		state, _ := s.states.Get("flaky")
+		return state.Status == WorkerRunning && state.Restarts == 3
+	}, time.Second, time.Millisecond)
+	cancel()
+	s.NoError(<-result)
+	state, _ := s.states.Get("flaky")
+	s.Equal(WorkerStopped, state.Status)
+}
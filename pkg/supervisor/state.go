@@ -0,0 +1,119 @@
+package supervisor
+
+import (
+	"sync"
+	"time"
+)
+
+// Number of restarts kept in the history of each worker.
+const MaxRestartHistory = 20
+
+type WorkerStatus string
+
+const (
+	WorkerStarting   WorkerStatus = "starting"
+	WorkerRunning    WorkerStatus = "running"
+	WorkerRestarting WorkerStatus = "restarting"
+	WorkerStopped    WorkerStatus = "stopped"
+	WorkerFailed     WorkerStatus = "failed"
+)
+
+type RestartRecord struct {
+	At      time.Time     `json:"at"`
+	Error   string        `json:"error,omitempty"`
+	Backoff time.Duration `json:"backoff"`
+}
+
+type WorkerState struct {
+	Name      string          `json:"name"`
+	Status    WorkerStatus    `json:"status"`
+	Policy    RestartMode     `json:"policy"`
+	Restarts  int             `json:"restarts"`
+	LastError string          `json:"lastError,omitempty"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	History   []RestartRecord `json:"history"`
+}
+
+// WorkerStates records the state and restart history of the supervised workers.
+// A nil *WorkerStates is valid and records nothing.
+type WorkerStates struct {
+	mu     sync.Mutex
+	states map[string]*WorkerState
+	order  []string
+}
+
+func NewWorkerStates() *WorkerStates {
+	return &WorkerStates{states: map[string]*WorkerState{}}
+}
+
+// List returns a copy of the worker states in registration order.
+func (s *WorkerStates) List() []WorkerState {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]WorkerState, 0, len(s.order))
+	for _, name := range s.order {
+		state := *s.states[name]
+		state.History = append([]RestartRecord{}, state.History...)
+		states = append(states, state)
+	}
+	return states
+}
+
+func (s *WorkerStates) Get(name string) (WorkerState, bool) {
+	for _, state := range s.List() {
+		if state.Name == name {
+			return state, true
+		}
+	}
+	return WorkerState{}, false
+}
+
+func (s *WorkerStates) setPolicy(name string, mode RestartMode) {
+	s.update(name, func(state *WorkerState) {
+		state.Policy = mode
+	})
+}
+
+func (s *WorkerStates) setStatus(name string, status WorkerStatus, err error) {
+	s.update(name, func(state *WorkerState) {
+		state.Status = status
+		if err != nil {
+			state.LastError = err.Error()
+		}
+	})
+}
+
+func (s *WorkerStates) recordRestart(name string, err error, backoff time.Duration) {
+	s.update(name, func(state *WorkerState) {
+		record := RestartRecord{At: time.Now(), Backoff: backoff}
+		if err != nil {
+			record.Error = err.Error()
+			state.LastError = record.Error
+		}
+		state.Status = WorkerRestarting
+		state.Restarts++
+		state.History = append(state.History, record)
+		if len(state.History) > MaxRestartHistory {
+			state.History = state.History[len(state.History)-MaxRestartHistory:]
+		}
+	})
+}
+
+func (s *WorkerStates) update(name string, fn func(state *WorkerState)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[name]
+	if !ok {
+		state = &WorkerState{Name: name, Policy: RestartNever, History: []RestartRecord{}}
+		s.states[name] = state
+		s.order = append(s.order, name)
+	}
+	fn(state)
+	state.UpdatedAt = time.Now()
+}
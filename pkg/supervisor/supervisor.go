@@ -20,10 +20,13 @@ const DefaultSupervisorTimeout = time.Second * 15
 
 // Start the workers in order, waiting for each one to be ready before starting the next one.
 // When a worker exits, send a cancel signal to all of them and wait for them to finish.
+// Wrap a worker with WithRestart to restart it instead.
 type SupervisorWorker struct {
 	Name    string
 	Workers []Worker
 	Timeout time.Duration
+	// Optional registry of the worker states
+	States *WorkerStates
 }
 
 func (w SupervisorWorker) String() string {
@@ -50,18 +53,22 @@ Loop:
 
 		wg.Add(1)
 		innerReady := make(chan struct{})
+		w.States.setStatus(worker.String(), WorkerStarting, nil)
 		go func() {
 			defer wg.Done()
 			defer cancel()
 			err := worker.Start(ctx, innerReady)
 			if err != nil && !errors.Is(err, context.Canceled) {
+				w.States.setStatus(worker.String(), WorkerFailed, err)
 				slog.WarnContext(ctx, "supervisor: worker exitted with error", "error", err)
 			} else {
+				w.States.setStatus(worker.String(), WorkerStopped, nil)
 				slog.DebugContext(ctx, "supervisor: worker exitted with success")
 			}
 		}()
 		select {
 		case <-innerReady:
+			w.States.setStatus(worker.String(), WorkerRunning, nil)
 			slog.DebugContext(ctx, "supervisor: worker is ready")
 		case <-time.After(timeout):
 			slog.WarnContext(ctx, "supervisor: worker timed out")
@@ -0,0 +1,117 @@
+// Package tracing sets up the OpenTelemetry tracing of the server.
+// The spans are created through the global tracer provider, so they cost nothing
+// while the tracing is disabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/carlmjohnson/versioninfo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// Export to the collector set by the OTEL_EXPORTER_OTLP_* environment variables
+	ExporterOTLP = "otlp"
+	// Export to a local file, one JSON span per line
+	ExporterFile = "file"
+
+	ServiceName         = "cartesi-rollups-graphql"
+	instrumentationName = "github.com/cartesi/rollups-graphql"
+)
+
+type Opts struct {
+	// Exporter of the spans, the tracing is disabled when empty
+	Exporter string
+	// File of the file exporter
+	File string
+	// Fraction of the new traces sampled, the decision of an incoming parent is kept
+	SampleRatio float64
+}
+
+// Enabled reports whether the spans are exported.
+func (o Opts) Enabled() bool {
+	return o.Exporter != ""
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// The returned function flushes the pending spans and stops the exporter.
+func Setup(ctx context.Context, opts Opts) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	var file *os.File
+	switch opts.Exporter {
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExporterFile:
+		file, err = os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %q", opts.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(versioninfo.Short()),
+		),
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the attributes above
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// Tracer returns the tracer of the server.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span as a child of the span of the context.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
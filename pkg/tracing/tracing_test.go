@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type TracingSuite struct {
+	suite.Suite
+	recorder   *tracetest.SpanRecorder
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+func TestTracingSuite(t *testing.T) {
+	suite.Run(t, new(TracingSuite))
+}
+
+func (s *TracingSuite) SetupTest() {
+	s.provider = otel.GetTracerProvider()
+	s.propagator = otel.GetTextMapPropagator()
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (s *TracingSuite) TearDownTest() {
+	otel.SetTracerProvider(s.provider)
+	otel.SetTextMapPropagator(s.propagator)
+}
+
+func (s *TracingSuite) serve(handler echo.HandlerFunc, header string) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.Use(Middleware())
+	e.POST("/graphql/:appContract", handler)
+	req := httptest.NewRequest(http.MethodPost, "/graphql/0x01", nil)
+	if header != "" {
+		req.Header.Set("traceparent", header)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *TracingSuite) TestMiddlewareContinuesTheIncomingTrace() {
+	s.serve(func(c echo.Context) error {
+		_, span := Start(c.Request().Context(), "child")
+		span.End()
+		return c.String(http.StatusOK, "ok")
+	}, traceparent)
+
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 2)
+	child, server := spans[0], spans[1]
+	s.Equal("POST /graphql/:appContract", server.Name())
+	s.Equal(trace.SpanKindServer, server.SpanKind())
+	s.Equal("4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	s.Equal("00f067aa0ba902b7", server.Parent().SpanID().String())
+	s.True(server.Parent().IsRemote())
+	s.Contains(server.Attributes(), semconv.HTTPResponseStatusCode(http.StatusOK))
+	s.Equal(server.SpanContext().SpanID(), child.Parent().SpanID())
+}
+
+func (s *TracingSuite) TestMiddlewareStartsATraceWithoutHeader() {
+	s.serve(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	}, "")
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.False(spans[0].Parent().IsValid())
+}
+
+func (s *TracingSuite) TestMiddlewareRecordsTheErrors() {
+	rec := s.serve(func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database down")
+	}, "")
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	spans := s.recorder.Ended()
+	s.Require().Len(spans, 1)
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Contains(spans[0].Attributes(), semconv.HTTPResponseStatusCode(http.StatusServiceUnavailable))
+}
+
+func (s *TracingSuite) TestFileExporter() {
+	path := filepath.Join(s.T().TempDir(), "spans.json")
+	shutdown, err := Setup(context.Background(), Opts{Exporter: ExporterFile, File: path, SampleRatio: 1})
+	s.Require().NoError(err)
+	_, span := Start(context.Background(), "sync.cycle")
+	End(span, nil)
+	s.Require().NoError(shutdown(context.Background()))
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Contains(string(content), `"Name":"sync.cycle"`)
+	s.Contains(string(content), ServiceName)
+}
+
+func (s *TracingSuite) TestUnknownExporter() {
+	_, err := Setup(context.Background(), Opts{Exporter: "jaeger"})
+	s.ErrorContains(err, "unknown tracing exporter")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"log/slog"
+	"os/signal"
+	"syscall"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	synchronizernode "github.com/cartesi/rollups-graphql/v2/pkg/convenience/synchronizer_node"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resyncApp       string
+	resyncFromInput uint64
+)
+
+var ResyncCmd = &cobra.Command{
+	Use:   "resync",
+	Short: "Delete the synced data and sync it again from the node database",
+	Long: "Delete the synced data of one or all applications from an input index on and sync it again " +
+		"from the node database. Each application is rebuilt in a single transaction, " +
+		"so a running server keeps serving the previous data until the rebuild is committed.",
+	Args: cobra.NoArgs,
+	Run:  resync,
+}
+
+func init() {
+	ResyncCmd.Flags().StringVar(&resyncApp, "app", "", "Address of the application to resync, all of them when empty")
+	ResyncCmd.Flags().Uint64Var(&resyncFromInput, "from-input", 0, "Index of the first input to resync")
+	ResyncCmd.Flags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
+		"The sqlite file to load the state")
+	ResyncCmd.Flags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
+		"DB to use. PostgreSQL or SQLite")
+}
+
+func resync(cmd *cobra.Command, args []string) {
+	cfg := loadConfig(cmd)
+	commons.ConfigureLogForProduction(slog.LevelInfo, cfg.Log.Color)
+	setOpts(cmd.Context(), cfg)
+	requireNodeDb(cmd.Context())
+
+	var appContract *common.Address
+	if resyncApp != "" {
+		if !common.IsHexAddress(resyncApp) {
+			exitf(cmd.Context(), "invalid application address: %s", resyncApp)
+		}
+		address := common.HexToAddress(resyncApp)
+		appContract = &address
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db := bootstrap.CreateDBInstance(ctx, opts)
+	defer db.Close()
+	cobra.CheckErr(bootstrap.MigrateSchema(ctx, db, opts.MigrateOnStart))
+	container := convenience.NewContainer(db, opts.AutoCount)
+
+	dbRawUrl, dbNodeV2 := bootstrap.OpenNodeDb(opts)
+	defer dbNodeV2.Close()
+	worker := bootstrap.NewSynchronizerWorker(ctx, container, dbRawUrl, dbNodeV2)
+	resync := synchronizernode.NewSynchronizerResync(worker, container.GetResyncRepository(ctx))
+
+	cobra.CheckErr(resync.Resync(ctx, appContract, resyncFromInput))
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/snapshot"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotOutput string
+	restoreInput   string
+)
+
+var SnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Write a point-in-time snapshot of the GraphQL database",
+	Long: "Write all the synced data and the sync checkpoints, as seen by a single read-only transaction, " +
+		"to a portable snapshot that the restore command loads into an empty PostgreSQL or SQLite database. " +
+		"The snapshot is gzipped when the output file ends with .gz.",
+	Args: cobra.NoArgs,
+	Run:  writeSnapshot,
+}
+
+var RestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Load a snapshot into an empty GraphQL database",
+	Long: "Migrate the schema and load a snapshot, gzipped or not, into an empty database in a single " +
+		"transaction. The server started on it resumes syncing from the checkpoints of the snapshot.",
+	Args: cobra.NoArgs,
+	Run:  restoreSnapshot,
+}
+
+func init() {
+	SnapshotCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "", "File to write, stdout when empty")
+	RestoreCmd.Flags().StringVarP(&restoreInput, "input", "i", "", "File to read, stdin when empty")
+	for _, cmd := range []*cobra.Command{SnapshotCmd, RestoreCmd} {
+		cmd.Flags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
+			"The sqlite file to load the state")
+		cmd.Flags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
+			"DB to use. PostgreSQL or SQLite")
+	}
+}
+
+func writeSnapshot(cmd *cobra.Command, args []string) {
+	cfg := loadConfig(cmd)
+	// stdout may carry the snapshot
+	commons.ConfigureLogForProductionTo(os.Stderr, slog.LevelInfo, cfg.Log.Color)
+	setOpts(cmd.Context(), cfg)
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db := bootstrap.CreateDBInstance(ctx, opts)
+	defer db.Close()
+	cobra.CheckErr(bootstrap.MigrateSchema(ctx, db, opts.MigrateOnStart))
+
+	var out io.WriteCloser = os.Stdout
+	if snapshotOutput != "" {
+		file, err := os.Create(snapshotOutput)
+		cobra.CheckErr(err)
+		defer file.Close()
+		out = file
+		if strings.HasSuffix(snapshotOutput, ".gz") {
+			out = gzip.NewWriter(file)
+		}
+	}
+	w := bufio.NewWriter(out)
+	summary, err := snapshot.Snapshot(ctx, db, w)
+	cobra.CheckErr(err)
+	cobra.CheckErr(w.Flush())
+	if out != os.Stdout {
+		cobra.CheckErr(out.Close())
+	}
+	slog.InfoContext(ctx, "Snapshot written", "schemaVersion", summary.SchemaVersion, "rows", summary.Rows)
+}
+
+func restoreSnapshot(cmd *cobra.Command, args []string) {
+	cfg := loadConfig(cmd)
+	commons.ConfigureLogForProduction(slog.LevelInfo, cfg.Log.Color)
+	setOpts(cmd.Context(), cfg)
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db := bootstrap.CreateDBInstance(ctx, opts)
+	defer db.Close()
+	cobra.CheckErr(bootstrap.MigrateSchema(ctx, db, opts.MigrateOnStart))
+
+	var in io.Reader = os.Stdin
+	if restoreInput != "" {
+		file, err := os.Open(restoreInput)
+		cobra.CheckErr(err)
+		defer file.Close()
+		in = file
+	}
+	_, err := snapshot.Restore(ctx, db, in)
+	cobra.CheckErr(err)
+}
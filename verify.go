@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cartesi/rollups-graphql/v2/pkg/bootstrap"
+	"github.com/cartesi/rollups-graphql/v2/pkg/commons"
+	"github.com/cartesi/rollups-graphql/v2/pkg/convenience"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var (
+	verifyApp    string
+	verifyRepair bool
+)
+
+var VerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Compare the synced data with the node database",
+	Long: "Compare the counts and content hashes of the inputs, outputs and reports of each application " +
+		"with the node database and print the missing, extra and divergent rows as JSON. " +
+		"Exits with status 2 when an application is inconsistent.",
+	Args: cobra.NoArgs,
+	Run:  verify,
+}
+
+func init() {
+	VerifyCmd.Flags().StringVar(&verifyApp, "app", "", "Address of the application to verify, all of them when empty")
+	VerifyCmd.Flags().BoolVar(&verifyRepair, "repair", false,
+		"Resync the inconsistent applications from their first divergent input")
+	VerifyCmd.Flags().StringVar(&opts.SqliteFile, "sqlite-file", opts.SqliteFile,
+		"The sqlite file to load the state")
+	VerifyCmd.Flags().StringVar(&opts.DbImplementation, "db-implementation", opts.DbImplementation,
+		"DB to use. PostgreSQL or SQLite")
+}
+
+func verify(cmd *cobra.Command, args []string) {
+	cfg := loadConfig(cmd)
+	commons.ConfigureLogForProductionTo(os.Stderr, slog.LevelInfo, cfg.Log.Color)
+	setOpts(cmd.Context(), cfg)
+	requireNodeDb(cmd.Context())
+
+	var appContract *common.Address
+	if verifyApp != "" {
+		if !common.IsHexAddress(verifyApp) {
+			exitf(cmd.Context(), "invalid application address: %s", verifyApp)
+		}
+		address := common.HexToAddress(verifyApp)
+		appContract = &address
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	db := bootstrap.CreateDBInstance(ctx, opts)
+	defer db.Close()
+	cobra.CheckErr(bootstrap.MigrateSchema(ctx, db, opts.MigrateOnStart))
+	container := convenience.NewContainer(db, opts.AutoCount)
+
+	dbRawUrl, dbNodeV2 := bootstrap.OpenNodeDb(opts)
+	defer dbNodeV2.Close()
+	worker := bootstrap.NewSynchronizerWorker(ctx, container, dbRawUrl, dbNodeV2)
+	verifier := bootstrap.NewSynchronizerVerifier(ctx, container, worker)
+
+	report, err := verifier.Verify(ctx, appContract, verifyRepair)
+	cobra.CheckErr(err)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	cobra.CheckErr(encoder.Encode(report))
+	if !report.Consistent {
+		os.Exit(2)
+	}
+}